@@ -21,6 +21,17 @@ func NewAliasType(typeID string) TypeFactory {
 	return &aliasType{typeID}
 }
 
+// AliasedTypeID returns the type ID the given TypeFactory is an alias for.
+// The second return value is false if the given TypeFactory has not been created via NewAliasType.
+func AliasedTypeID(t TypeFactory) (string, bool) {
+	a, isAlias := t.(*aliasType)
+	if isAlias == false {
+		return "", false
+	}
+
+	return a.typeID, true
+}
+
 func (a *aliasType) Arguments() []interface{} {
 	return []interface{}{"@" + a.typeID}
 }
@@ -32,5 +43,5 @@ func (a *aliasType) Generate(resolver *ParameterResolver) (interface{}, error) {
 		return r.Generate(resolver)
 	}
 
-	return resolver.Container.Get(a.typeID)
+	return resolver.get(a.typeID)
 }
@@ -42,6 +42,19 @@ var _ = Describe("aliasType", func() {
 		})
 	})
 
+	Describe("AliasedTypeID()", func() {
+		It("should return the aliased type ID", func() {
+			typeID, isAlias := goldi.AliasedTypeID(goldi.NewAliasType("foo::ReturnString"))
+			Expect(isAlias).To(BeTrue())
+			Expect(typeID).To(Equal("foo::ReturnString"))
+		})
+
+		It("should return false for other type factories", func() {
+			_, isAlias := goldi.AliasedTypeID(goldi.NewStructType(Foo{}))
+			Expect(isAlias).To(BeFalse())
+		})
+	})
+
 	Describe("Generate()", func() {
 		var (
 			container *goldi.Container
@@ -0,0 +1,43 @@
+package goldi
+
+import "reflect"
+
+// An ArgumentResolver can be added to a ParameterResolver to resolve factory arguments that goldi does not understand natively.
+// This can be used to load values from external sources such as a key value store.
+//
+// The ParameterResolver consults all of its argument resolvers in the order they have been added whenever it encounters
+// an argument that is neither a parameter, a type reference nor an inline type factory. The first resolver that claims
+// the argument by returning true wins. The returned value is converted to the expected type if necessary.
+//
+// Argument resolvers are only consulted when a type is generated, so they can not change the kind of an argument that
+// NewType checks when the type is registered. A string argument like "consul:service/port" is rejected by NewType if
+// the factory expects an int. Resolvers that produce values of another kind should therefore claim arguments of a
+// dedicated type (e.g. a struct like ConsulKey{"service/port"}) which NewType passes through unchecked.
+type ArgumentResolver interface {
+	ResolveArgument(argument interface{}, expectedType reflect.Type) (value interface{}, isResolved bool, err error)
+}
+
+// AddArgumentResolver appends the given ArgumentResolver to the chain of custom argument resolvers.
+func (r *ParameterResolver) AddArgumentResolver(resolver ArgumentResolver) {
+	r.ArgumentResolvers = append(r.ArgumentResolvers, resolver)
+}
+
+func (r *ParameterResolver) resolveLiteral(parameter reflect.Value, expectedType reflect.Type) (reflect.Value, ResolutionKind, error) {
+	if parameter.IsValid() == false {
+		return parameter, LiteralResolution, nil
+	}
+
+	for _, resolver := range r.ArgumentResolvers {
+		value, isResolved, err := resolver.ResolveArgument(parameter.Interface(), expectedType)
+		if err != nil {
+			return reflect.Value{}, CustomResolution, err
+		}
+
+		if isResolved {
+			result, err := coerce(value, expectedType)
+			return result, CustomResolution, err
+		}
+	}
+
+	return parameter, LiteralResolution, nil
+}
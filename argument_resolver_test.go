@@ -0,0 +1,98 @@
+package goldi_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// consulKey can be used as argument for factory parameters that are no strings.
+type consulKey struct {
+	Key string
+}
+
+// consulResolver resolves all string arguments with the prefix "consul:" and all consulKey arguments
+// from an in memory key value store.
+type consulResolver struct {
+	values map[string]interface{}
+}
+
+func (c *consulResolver) ResolveArgument(argument interface{}, expectedType reflect.Type) (interface{}, bool, error) {
+	var key string
+	switch a := argument.(type) {
+	case string:
+		if !strings.HasPrefix(a, "consul:") {
+			return nil, false, nil
+		}
+		key = strings.TrimPrefix(a, "consul:")
+	case consulKey:
+		key = a.Key
+	default:
+		return nil, false, nil
+	}
+
+	value, exists := c.values[key]
+	if !exists {
+		return nil, true, fmt.Errorf("consul key %q does not exist", key)
+	}
+
+	return value, true, nil
+}
+
+var _ = Describe("ArgumentResolver", func() {
+	var (
+		container *goldi.Container
+		consul    *consulResolver
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		consul = &consulResolver{values: map[string]interface{}{"service/name": "consul value"}}
+		container.Resolver.AddArgumentResolver(consul)
+	})
+
+	It("should resolve arguments using the custom resolver", func() {
+		container.RegisterType("foo", NewMockTypeWithArgs, "consul:service/name", true)
+		Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("consul value"))
+	})
+
+	It("should resolve arguments of a dedicated type into another kind", func() {
+		consul.values["feature/enabled"] = true
+		container.RegisterType("foo", NewMockTypeWithArgs, "consul:service/name", consulKey{"feature/enabled"})
+		Expect(container.MustGet("foo").(*MockType).BoolParameter).To(BeTrue())
+	})
+
+	It("should not be able to change the kind of string arguments that is checked when the type is registered", func() {
+		consul.values["feature/enabled"] = true
+		container.RegisterType("foo", NewMockTypeWithArgs, "consul:service/name", "consul:feature/enabled")
+		_, err := container.Get("foo")
+		Expect(err).To(MatchError(ContainSubstring("input argument 2 is of type string but needs to be a bool")))
+	})
+
+	It("should not pass parameters and type references to the custom resolver", func() {
+		container.Config["name"] = "parameter value"
+		container.RegisterType("foo", NewMockTypeWithArgs, "%name%", true)
+		Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("parameter value"))
+	})
+
+	It("should leave arguments that have not been claimed by any resolver untouched", func() {
+		container.RegisterType("foo", NewMockTypeWithArgs, "static value", true)
+		Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("static value"))
+	})
+
+	It("should return errors of the custom resolver", func() {
+		container.RegisterType("foo", NewMockTypeWithArgs, "consul:missing", true)
+		_, err := container.Get("foo")
+		Expect(err).To(MatchError(`goldi: error while generating type "foo": consul key "missing" does not exist`))
+	})
+
+	It("should report the custom resolution kind", func() {
+		_, kind, err := container.Resolver.ResolveDetailed("consul:service/name", reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kind).To(Equal(goldi.CustomResolution))
+	})
+})
@@ -0,0 +1,64 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type autoConfigurator struct {
+	ifaceType reflect.Type
+	configure func(instance interface{}) error
+}
+
+// AddAutoConfigurator registers a function that is called with every generated type that implements the interface
+// ifacePtr points to. This avoids wiring the same configurator to many types (e.g. to call Init on every type
+// that implements some Initializable interface):
+//
+//	container.AddAutoConfigurator((*Initializable)(nil), func(instance interface{}) error {
+//		return instance.(Initializable).Init()
+//	})
+//
+// Auto configurators run after a type has been generated and before it is cached, in the order in which they have been
+// added. If an auto configurator returns an error the type is not cached and Get returns that error.
+// Only types that are generated after the auto configurator has been added are configured.
+//
+// AddAutoConfigurator returns an error if ifacePtr is no pointer to an interface or fn is nil.
+func (c *Container) AddAutoConfigurator(ifacePtr interface{}, fn func(instance interface{}) error) error {
+	ifaceType, err := boundInterfaceType(ifacePtr)
+	if err != nil {
+		return fmt.Errorf("goldi: can not add auto configurator: %s", err)
+	}
+
+	if fn == nil {
+		return fmt.Errorf("goldi: can not add auto configurator for %v: the configurator function is nil", ifaceType)
+	}
+
+	c.mutex.Lock()
+	c.autoConfigs = append(c.autoConfigs, autoConfigurator{ifaceType, fn})
+	c.mutex.Unlock()
+	return nil
+}
+
+// autoConfigure calls all auto configurators whose interface is implemented by the given instance.
+func (c *Container) autoConfigure(instance interface{}) error {
+	if instance == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	configurators := c.autoConfigs
+	c.mutex.Unlock()
+
+	instanceType := reflect.TypeOf(instance)
+	for _, configurator := range configurators {
+		if instanceType.Implements(configurator.ifaceType) == false {
+			continue
+		}
+
+		if err := configurator.configure(instance); err != nil {
+			return fmt.Errorf("auto configurator for %v failed: %w", configurator.ifaceType, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package goldi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type initializable interface {
+	Init() error
+}
+
+type initializableType struct {
+	Initialized int
+	Err         error
+}
+
+func (t *initializableType) Init() error {
+	t.Initialized++
+	return t.Err
+}
+
+var _ = Describe("Container.AddAutoConfigurator", func() {
+	var (
+		container  *goldi.Container
+		initialize = func(instance interface{}) error {
+			return instance.(initializable).Init()
+		}
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should configure all types that implement the interface", func() {
+		Expect(container.AddAutoConfigurator((*initializable)(nil), initialize)).To(Succeed())
+		container.Register("a", goldi.NewStructType(initializableType{}))
+		container.Register("b", goldi.NewStructType(initializableType{}))
+
+		Expect(container.MustGet("a").(*initializableType).Initialized).To(Equal(1))
+		Expect(container.MustGet("b").(*initializableType).Initialized).To(Equal(1))
+		Expect(container.MustGet("a").(*initializableType).Initialized).To(Equal(1), "types must only be configured when they are generated")
+	})
+
+	It("should configure scoped types", func() {
+		Expect(container.AddAutoConfigurator((*initializable)(nil), initialize)).To(Succeed())
+		container.Register("a", goldi.NewStructType(initializableType{}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		instance, err := container.ScopedGet(ctx, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.(*initializableType).Initialized).To(Equal(1))
+	})
+
+	It("should not configure types that do not implement the interface", func() {
+		var configured []interface{}
+		Expect(container.AddAutoConfigurator((*fmt.Stringer)(nil), func(instance interface{}) error {
+			configured = append(configured, instance)
+			return nil
+		})).To(Succeed())
+		container.Register("a", goldi.NewStructType(initializableType{}))
+		container.Register("b", goldi.NewType(NewMockType))
+
+		container.MustGet("a")
+		container.MustGet("b")
+		Expect(configured).To(BeEmpty())
+	})
+
+	It("should call the auto configurators in the order in which they have been added", func() {
+		var calls []string
+		Expect(container.AddAutoConfigurator((*initializable)(nil), func(interface{}) error {
+			calls = append(calls, "first")
+			return nil
+		})).To(Succeed())
+		Expect(container.AddAutoConfigurator((*initializable)(nil), func(interface{}) error {
+			calls = append(calls, "second")
+			return nil
+		})).To(Succeed())
+		container.Register("a", goldi.NewStructType(initializableType{}))
+
+		container.MustGet("a")
+		Expect(calls).To(Equal([]string{"first", "second"}))
+	})
+
+	It("should return the error of an auto configurator and not cache the type", func() {
+		Expect(container.AddAutoConfigurator((*initializable)(nil), initialize)).To(Succeed())
+		container.Register("a", goldi.NewStructType(initializableType{}, 0, errors.New("oops")))
+
+		_, err := container.Get("a")
+		Expect(err).To(MatchError(`goldi: error while generating type "a": auto configurator for goldi_test.initializable failed: oops`))
+
+		var cached []string
+		container.Each(func(typeID string, _ interface{}) { cached = append(cached, typeID) })
+		Expect(cached).To(BeEmpty())
+	})
+
+	It("should return an error if the interface is invalid", func() {
+		Expect(container.AddAutoConfigurator(initializableType{}, initialize)).To(MatchError(HavePrefix("goldi: can not add auto configurator: ")))
+		Expect(container.AddAutoConfigurator((*initializable)(nil), nil)).To(MatchError("goldi: can not add auto configurator for goldi_test.initializable: the configurator function is nil"))
+	})
+})
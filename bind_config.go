@@ -0,0 +1,106 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindConfig sets the fields of the struct target points to using the parameters whose names start with the given
+// prefix. This allows to use strongly typed configuration objects that are backed by the parameters of the container.
+//
+// The parameter of a field is named like the field (ignoring the case) or like the name in the "goldi" tag of the
+// field. Nested structs use the name of the struct field followed by a dot as additional prefix:
+//
+//	type DatabaseConfig struct {
+//		Host    string        `goldi:"host,required"`
+//		Timeout time.Duration // parameter "database.timeout"
+//		Pool    struct {
+//			Size int // parameter "database.pool.size"
+//		}
+//	}
+//
+//	var config DatabaseConfig
+//	err := container.BindConfig("database.", &config)
+//
+// The parameter values are converted into the types of the fields just like factory arguments.
+// Fields without parameter keep their current value unless they are tagged as required. Fields tagged
+// with "-" are ignored. All errors are aggregated into the returned MultiError.
+func (c *Container) BindConfig(prefix string, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("goldi: can not bind config to %T: target must be a non-nil pointer to a struct", target)
+	}
+
+	err := NewMultiError("goldi: could not bind config")
+	c.bindConfig(prefix, v.Elem(), err)
+	return err.ErrorOrNil()
+}
+
+func (c *Container) bindConfig(prefix string, target reflect.Value, err *MultiError) {
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if field.IsExported() == false {
+			continue
+		}
+
+		name, isRequired := field.Name, false
+		if tag, hasTag := field.Tag.Lookup("goldi"); hasTag {
+			options := strings.Split(tag, ",")
+			if options[0] == "-" {
+				continue
+			}
+
+			if options[0] != "" {
+				name = options[0]
+			}
+			isRequired = containsOption(options[1:], "required")
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			c.bindConfig(prefix+name+".", target.Field(i), err)
+			continue
+		}
+
+		parameterName, value, isSet := c.lookupParameter(prefix + name)
+		if isSet == false {
+			if isRequired {
+				err.Add(fmt.Errorf("missing required parameter %q", prefix+name))
+			}
+			continue
+		}
+
+		result, coerceErr := coerce(value, field.Type)
+		if coerceErr != nil {
+			err.Add(fmt.Errorf("parameter %q: %s", parameterName, coerceErr))
+			continue
+		}
+
+		target.Field(i).Set(result)
+	}
+}
+
+// lookupParameter returns the parameter with the given name. If there is no parameter with exactly this name
+// it falls back to a parameter whose name only differs in case.
+func (c *Container) lookupParameter(name string) (string, interface{}, bool) {
+	if value, isSet := c.parameter(name); isSet {
+		return name, value, true
+	}
+
+	for parameterName, value := range c.parameters() {
+		if strings.EqualFold(parameterName, name) {
+			return parameterName, value, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func containsOption(options []string, option string) bool {
+	for _, o := range options {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
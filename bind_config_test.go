@@ -0,0 +1,80 @@
+package goldi_test
+
+import (
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type DatabaseConfig struct {
+	Host    string `goldi:"hostname,required"`
+	Port    int
+	Timeout time.Duration
+	Ignored string `goldi:"-"`
+	Pool    struct {
+		Size    int
+		MaxIdle int `goldi:"max_idle"`
+	}
+}
+
+var _ = Describe("Container.BindConfig", func() {
+	var (
+		container *goldi.Container
+		config    map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{
+			"database.hostname":      "localhost",
+			"database.port":          "5432",
+			"database.timeout":       "2s",
+			"database.ignored":       "foo",
+			"database.pool.size":     10,
+			"database.pool.max_idle": 2.0,
+			"cache.hostname":         "redis",
+		}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+	})
+
+	It("should bind the parameters with the given prefix to the fields of the struct", func() {
+		var c DatabaseConfig
+		Expect(container.BindConfig("database.", &c)).To(Succeed())
+		Expect(c.Host).To(Equal("localhost"))
+		Expect(c.Port).To(Equal(5432))
+		Expect(c.Timeout).To(Equal(2 * time.Second))
+		Expect(c.Ignored).To(BeEmpty())
+		Expect(c.Pool.Size).To(Equal(10))
+		Expect(c.Pool.MaxIdle).To(Equal(2))
+	})
+
+	It("should keep the values of fields without parameters", func() {
+		c := DatabaseConfig{Port: 3306}
+		Expect(container.BindConfig("cache.", &c)).To(Succeed())
+		Expect(c.Host).To(Equal("redis"))
+		Expect(c.Port).To(Equal(3306))
+	})
+
+	It("should return an error if a required parameter is missing", func() {
+		var c DatabaseConfig
+		err := container.BindConfig("queue.", &c)
+		Expect(err).To(MatchError(`goldi: could not bind config: missing required parameter "queue.hostname"`))
+	})
+
+	It("should aggregate all conversion errors", func() {
+		config["database.port"] = "not a port"
+		config["database.pool.size"] = "many"
+
+		var c DatabaseConfig
+		err := container.BindConfig("database.", &c)
+		Expect(err).To(MatchError(ContainSubstring(`parameter "database.port": can not convert "not a port" to int`)))
+		Expect(err).To(MatchError(ContainSubstring(`parameter "database.pool.size": can not convert "many" to int`)))
+		Expect(err.(*goldi.MultiError).Errors).To(HaveLen(2))
+	})
+
+	It("should return an error if the target is no pointer to a struct", func() {
+		var c DatabaseConfig
+		Expect(container.BindConfig("database.", c)).To(MatchError("goldi: can not bind config to goldi_test.DatabaseConfig: target must be a non-nil pointer to a struct"))
+	})
+})
@@ -0,0 +1,65 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Bind binds the interface ifacePtr points to to the type with the given ID so it can be retrieved via GetByType.
+// The interface is passed as pointer because interface types can not be passed directly:
+//     container.Bind((*io.Writer)(nil), "file_writer")
+//
+// Bind returns an error if ifacePtr is no pointer to an interface, the implementation type has not been
+// registered or if it does not implement the interface. Note that the implementation is checked without generating it
+// so types whose concrete type can not be determined statically (see Container.StaticType) are checked in GetByType.
+func (c *Container) Bind(ifacePtr interface{}, implTypeID string) error {
+	ifaceType, err := boundInterfaceType(ifacePtr)
+	if err != nil {
+		return fmt.Errorf("goldi: can not bind type %q: %s", implTypeID, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, isDefined := c.TypeRegistry[implTypeID]; isDefined == false {
+		return newUnknownTypeReferenceError(implTypeID, "goldi: can not bind type %q: no such type has been defined", implTypeID)
+	}
+
+	if implType, isKnown := c.StaticType(implTypeID); isKnown && implType.Implements(ifaceType) == false {
+		return fmt.Errorf("goldi: can not bind type %q: %v does not implement %v", implTypeID, implType, ifaceType)
+	}
+
+	c.bindings[ifaceType] = implTypeID
+	return nil
+}
+
+// GetByType retrieves the type that has been bound to the interface target points to and stores it in target:
+//     var writer io.Writer
+//     err := container.GetByType(&writer)
+//
+// GetByType returns an error if target is no pointer to an interface, no type has been bound to that interface
+// or if the bound type can not be generated.
+func (c *Container) GetByType(target interface{}) error {
+	ifaceType, err := boundInterfaceType(target)
+	if err != nil {
+		return fmt.Errorf("goldi: can not get type by interface: %s", err)
+	}
+
+	c.mutex.Lock()
+	typeID, isBound := c.bindings[ifaceType]
+	c.mutex.Unlock()
+	if isBound == false {
+		return fmt.Errorf("goldi: no type has been bound to %v", ifaceType)
+	}
+
+	return c.GetAssignable(typeID, target)
+}
+
+func boundInterfaceType(ifacePtr interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(ifacePtr)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%T is no pointer to an interface", ifacePtr)
+	}
+
+	return t.Elem(), nil
+}
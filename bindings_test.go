@@ -0,0 +1,80 @@
+package goldi_test
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type stuffDoer interface {
+	DoStuff() string
+}
+
+var _ = Describe("Container bindings", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.RegisterType("mock", NewMockType)
+		container.RegisterType("foo", NewFoo)
+	})
+
+	It("should retrieve the bound implementation via its interface", func() {
+		Expect(container.Bind((*stuffDoer)(nil), "mock")).To(Succeed())
+
+		var doer stuffDoer
+		Expect(container.GetByType(&doer)).To(Succeed())
+		Expect(doer).To(BeIdenticalTo(container.MustGet("mock")))
+		Expect(doer.DoStuff()).To(Equal("I did stuff"))
+	})
+
+	It("should be safe to bind types while other types are retrieved by their interface", func() {
+		Expect(container.Bind((*stuffDoer)(nil), "mock")).To(Succeed())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				var doer stuffDoer
+				Expect(container.GetByType(&doer)).To(Succeed())
+			}()
+			go func() {
+				defer wg.Done()
+				Expect(container.Bind((*interface{})(nil), "foo")).To(Succeed())
+			}()
+		}
+		wg.Wait()
+	})
+
+	It("should return an error if the implementation does not satisfy the interface", func() {
+		err := container.Bind((*stuffDoer)(nil), "foo")
+		Expect(err).To(MatchError(`goldi: can not bind type "foo": *goldi_test.Foo does not implement goldi_test.stuffDoer`))
+	})
+
+	It("should return an error if the implementation has not been registered", func() {
+		err := container.Bind((*stuffDoer)(nil), "unknown")
+		Expect(err).To(MatchError(`goldi: can not bind type "unknown": no such type has been defined`))
+	})
+
+	It("should return an error if no interface is given", func() {
+		Expect(container.Bind(new(MockType), "mock")).To(MatchError(`goldi: can not bind type "mock": *goldi_test.MockType is no pointer to an interface`))
+		Expect(container.Bind(nil, "mock")).To(HaveOccurred())
+	})
+
+	It("should return an error if no type has been bound to the interface", func() {
+		var stringer fmt.Stringer
+		Expect(container.GetByType(&stringer)).To(MatchError("goldi: no type has been bound to fmt.Stringer"))
+	})
+
+	It("should check the implementation when it is retrieved if its type is unknown", func() {
+		container.RegisterType("dynamic", func() interface{} { return NewFoo() })
+		Expect(container.Bind((*stuffDoer)(nil), "dynamic")).To(Succeed())
+
+		var doer stuffDoer
+		Expect(container.GetByType(&doer)).To(MatchError(ContainSubstring("is not assignable to goldi_test.stuffDoer")))
+	})
+})
@@ -0,0 +1,45 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Build eagerly generates all registered types in alphabetical order of their IDs.
+// In contrast to WarmUp, Build does not stop at the first type that can not be generated. Instead all errors are
+// aggregated into the returned MultiError. See BuildParallel for generating independent types concurrently.
+func (c *Container) Build() error {
+	_, err := c.build()
+	return err.ErrorOrNil()
+}
+
+// MustBuild behaves exactly like Build but panics with the aggregated MultiError if any type can not be generated.
+// The message of the error lists the IDs of all types that could not be generated.
+// This is useful to fail fast while an application is bootstrapped.
+func (c *Container) MustBuild() {
+	failed, err := c.build()
+	if len(failed) > 0 {
+		err.Message = fmt.Sprintf("goldi: could not build the types %q", failed)
+		panic(err)
+	}
+}
+
+// build generates all registered types and returns the IDs of the types that could not be generated.
+func (c *Container) build() ([]string, *MultiError) {
+	typeIDs := make([]string, 0, len(c.TypeRegistry))
+	for typeID := range c.TypeRegistry {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	var failed []string
+	err := NewMultiError("goldi: could not build all types")
+	for _, typeID := range typeIDs {
+		if _, _, generateErr := c.get(typeID); generateErr != nil {
+			failed = append(failed, typeID)
+			err.Add(c.handleError(typeID, generateErr))
+		}
+	}
+
+	return failed, err
+}
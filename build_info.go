@@ -0,0 +1,29 @@
+package goldi
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// BuildInfoTypeID is the ID of the type that is registered by Container.RegisterBuildInfo.
+const BuildInfoTypeID = "goldi.build_info"
+
+// RegisterBuildInfo registers version information as type with the ID BuildInfoTypeID so application code can
+// inject it like any other type (e.g. "@goldi.build_info"). If version is nil the *debug.BuildInfo of the running
+// binary is registered. Applications that set their own version information at build time (e.g. via -ldflags) can
+// pass an instance of their own version struct instead, which is registered as it is.
+//
+// RegisterBuildInfo returns an error if version is nil and the binary has been built without module support.
+// Like Register it panics if the container has been frozen.
+func (c *Container) RegisterBuildInfo(version interface{}) error {
+	if version == nil {
+		info, isAvailable := debug.ReadBuildInfo()
+		if isAvailable == false {
+			return fmt.Errorf("goldi: can not register type %q: the build information is not available", BuildInfoTypeID)
+		}
+		version = info
+	}
+
+	c.Register(BuildInfoTypeID, NewInstanceType(version))
+	return nil
+}
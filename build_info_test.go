@@ -0,0 +1,48 @@
+package goldi_test
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.RegisterBuildInfo", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should register the build information of the binary", func() {
+		Expect(container.RegisterBuildInfo(nil)).To(Succeed())
+
+		var info *debug.BuildInfo
+		Expect(container.GetAssignable(goldi.BuildInfoTypeID, &info)).To(Succeed())
+		Expect(info.GoVersion).To(Equal(runtime.Version()))
+	})
+
+	It("should register a custom version struct", func() {
+		type Version struct {
+			Version, Commit string
+		}
+
+		version := &Version{Version: "1.2.3", Commit: "abc123"}
+		Expect(container.RegisterBuildInfo(version)).To(Succeed())
+		Expect(container.MustGet(goldi.BuildInfoTypeID)).To(BeIdenticalTo(version))
+	})
+
+	It("should inject the build information into other types", func() {
+		type Application struct {
+			Version *debug.BuildInfo
+		}
+
+		Expect(container.RegisterBuildInfo(nil)).To(Succeed())
+		container.Register("app", goldi.NewStructType(Application{}, "@"+goldi.BuildInfoTypeID))
+
+		app := container.MustGet("app").(*Application)
+		Expect(app.Version).To(BeIdenticalTo(container.MustGet(goldi.BuildInfoTypeID)))
+	})
+})
@@ -0,0 +1,53 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Build", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("mock", goldi.NewType(NewMockType))
+		container.Register("service", goldi.NewType(NewTypeForServiceInjection, "@mock"))
+	})
+
+	It("should generate all registered types", func() {
+		Expect(container.Build()).To(Succeed())
+		Expect(container.UnusedTypes()).To(BeEmpty())
+	})
+
+	It("should aggregate all errors", func() {
+		container.Register("a", goldi.NewProxyType("unknown_1", "DoStuff"))
+		container.Register("b", goldi.NewProxyType("unknown_2", "DoStuff"))
+
+		err := container.Build()
+		Expect(err).To(MatchError(HavePrefix(`goldi: could not build all types: goldi: error while generating type "a": `)))
+		Expect(err.Error()).To(ContainSubstring(`; goldi: error while generating type "b": `))
+		Expect(container.MustGet("service")).NotTo(BeNil())
+	})
+
+	Describe("MustBuild", func() {
+		It("should not panic if all types can be generated", func() {
+			Expect(container.MustBuild).NotTo(Panic())
+		})
+
+		It("should panic with the IDs of all types that could not be generated", func() {
+			container.Register("a", goldi.NewProxyType("unknown_1", "DoStuff"))
+			container.Register("b", goldi.NewType(NewTypeForServiceInjection, "@a"))
+
+			defer func() {
+				err, isMultiErr := recover().(*goldi.MultiError)
+				Expect(isMultiErr).To(BeTrue())
+				Expect(err.Errors).To(HaveLen(2))
+				Expect(err).To(MatchError(HavePrefix(`goldi: could not build the types ["a" "b"]: goldi: error while generating type "a": `)))
+			}()
+
+			container.MustBuild()
+			Fail("MustBuild should have panicked")
+		})
+	})
+})
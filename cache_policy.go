@@ -0,0 +1,73 @@
+package goldi
+
+import (
+	"io"
+	"time"
+)
+
+// A CachePolicy can be implemented by a TypeFactory to limit how long the container caches the instances it
+// generates. By default every type is generated once and then cached for the whole lifetime of the container.
+// If the TypeFactory of a type implements CachePolicy the container asks it on each request whether the cached
+// instance has expired and generates the type again if it did. Expired instances that implement io.Closer are
+// closed once the type has been generated again.
+//
+// Note that types which have already been generated using an expired instance keep that instance. Types that
+// must always use the current instance should therefore retrieve it from the container when they need it.
+// See NewTTLType for an implementation.
+type CachePolicy interface {
+	// IsExpired returns true if an instance that has been generated at generatedAt must not be used at the time now.
+	IsExpired(generatedAt, now time.Time) bool
+}
+
+// cachePolicy returns the CachePolicy of the given type factory. Other decorators like tags or configurators
+// do not hide the cache policy of the type they decorate.
+func cachePolicy(factory TypeFactory) (CachePolicy, bool) {
+	for _, f := range decorationChain(factory) {
+		if policy, hasPolicy := f.(CachePolicy); hasPolicy {
+			return policy, true
+		}
+	}
+
+	return nil, false
+}
+
+// now returns the current time of the Clock of the container.
+func (c *Container) now() time.Time {
+	if c.Clock == nil {
+		return time.Now()
+	}
+
+	return c.Clock()
+}
+
+// isExpired returns true if the cached instance of the given type has expired according to the cache policy of its
+// type factory. The caller must hold c.mutex.
+func (c *Container) isExpired(typeID string, factory TypeFactory) bool {
+	policy, hasPolicy := cachePolicy(factory)
+	if hasPolicy == false {
+		return false
+	}
+
+	return policy.IsExpired(c.cachedAt[typeID], c.now())
+}
+
+// setCachedAt records when the instance of the given type has been cached. The caller must hold c.mutex.
+func (c *Container) setCachedAt(typeID string, factory TypeFactory) {
+	if _, hasPolicy := cachePolicy(factory); hasPolicy == false {
+		return
+	}
+
+	if c.cachedAt == nil {
+		c.cachedAt = map[string]time.Time{}
+	}
+
+	c.cachedAt[typeID] = c.now()
+}
+
+// closeExpired closes the given expired instance if it implements io.Closer. Errors are ignored because the instance
+// has already been removed from the container and nobody could handle them.
+func closeExpired(instance interface{}) {
+	if closer, isCloser := instance.(io.Closer); isCloser {
+		_ = closer.Close()
+	}
+}
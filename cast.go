@@ -0,0 +1,76 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var castTypes = map[string]reflect.Type{
+	"bool":    reflect.TypeOf(false),
+	"string":  reflect.TypeOf(""),
+	"int":     reflect.TypeOf(int(0)),
+	"int8":    reflect.TypeOf(int8(0)),
+	"int16":   reflect.TypeOf(int16(0)),
+	"int32":   reflect.TypeOf(int32(0)),
+	"int64":   reflect.TypeOf(int64(0)),
+	"uint":    reflect.TypeOf(uint(0)),
+	"uint8":   reflect.TypeOf(uint8(0)),
+	"uint16":  reflect.TypeOf(uint16(0)),
+	"uint32":  reflect.TypeOf(uint32(0)),
+	"uint64":  reflect.TypeOf(uint64(0)),
+	"float32": reflect.TypeOf(float32(0)),
+	"float64": reflect.TypeOf(float64(0)),
+}
+
+// A CastArgument wraps a factory argument and converts its resolved value into a specific type.
+// Usually arguments are converted into the type the factory expects. Casts are necessary if this type is ambiguous
+// like for interface{} or variadic ...interface{} arguments where a numeric parameter would otherwise always be an int.
+type CastArgument struct {
+	Argument interface{}
+	TypeName string
+}
+
+// Cast wraps the given argument so its resolved value is converted into the built-in type with the given name.
+// Supported types are bool, string and all integer and float types (e.g. "int64" or "float32").
+//     goldi.NewType(NewRateLimiter, goldi.Cast("%requests_per_second%", "int64"))
+func Cast(argument interface{}, typeName string) *CastArgument {
+	return &CastArgument{Argument: argument, TypeName: typeName}
+}
+
+// CastType returns the type that is used for casts with the given type name.
+// The second return value is false if the type name is not supported.
+func CastType(typeName string) (reflect.Type, bool) {
+	t, isKnown := castTypes[typeName]
+	return t, isKnown
+}
+
+// resolveCast resolves the wrapped argument of the cast and converts it into the type of the cast.
+func (r *ParameterResolver) resolveCast(cast *CastArgument, expectedType reflect.Type) (reflect.Value, ResolutionKind, error) {
+	castType, isKnown := CastType(cast.TypeName)
+	if isKnown == false {
+		return reflect.Value{}, LiteralResolution, fmt.Errorf("can not cast %v to unknown type %q", cast.Argument, cast.TypeName)
+	}
+
+	value, kind, err := r.resolve(reflect.ValueOf(cast.Argument), castType)
+	if err != nil {
+		return reflect.Value{}, kind, err
+	}
+
+	var resolved interface{}
+	if value.IsValid() {
+		resolved = value.Interface()
+	}
+
+	converted, err := coerce(resolved, castType)
+	if err != nil {
+		return reflect.Value{}, kind, fmt.Errorf("can not cast %v to %s: %s", cast.Argument, cast.TypeName, err)
+	}
+
+	if castType.AssignableTo(expectedType) == false {
+		return reflect.Value{}, kind, fmt.Errorf("the cast of %v (type %v) is not assignable to the expected type %v", cast.Argument, castType, expectedType)
+	}
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(converted)
+	return result, kind, nil
+}
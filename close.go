@@ -0,0 +1,41 @@
+package goldi
+
+import (
+	"fmt"
+	"io"
+)
+
+// Close closes all generated types of this container that implement io.Closer in the reverse order of their
+// generation, so types are closed before the types they depend on. Types of the fallback container are not closed
+// since the fallback may be shared by several containers.
+//
+// All errors returned by the closers are aggregated into the returned MultiError.
+// Close is safe to be called multiple times: all calls after the first one are no-ops and return nil.
+func (c *Container) Close() error {
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil
+	}
+
+	c.closed = true
+	var closers []io.Closer
+	var typeIDs []string
+	for i := len(c.generated) - 1; i >= 0; i-- {
+		typeID := c.generated[i]
+		if closer, isCloser := c.typeCache[typeID].(io.Closer); isCloser {
+			closers = append(closers, closer)
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+	c.mutex.Unlock()
+
+	err := NewMultiError("goldi: could not close all types")
+	for i, closer := range closers {
+		if closeErr := closer.Close(); closeErr != nil {
+			err.Add(fmt.Errorf("could not close type %q: %w", typeIDs[i], closeErr))
+		}
+	}
+
+	return err.ErrorOrNil()
+}
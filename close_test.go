@@ -0,0 +1,83 @@
+package goldi_test
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Close", func() {
+	var (
+		container *goldi.Container
+		mutex     sync.Mutex
+		closed    []string
+	)
+
+	newRecorder := func(name string, err error) func() *closeRecorder {
+		return func() *closeRecorder {
+			return &closeRecorder{mutex: &mutex, closed: &closed, name: name, err: err}
+		}
+	}
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		closed = nil
+	})
+
+	It("should close all generated types in reverse order", func() {
+		container.Register("database", goldi.NewType(newRecorder("database", nil)))
+		container.Register("repository", goldi.NewType(func(*closeRecorder) *closeRecorder {
+			return newRecorder("repository", nil)()
+		}, "@database"))
+		container.Register("unused", goldi.NewType(newRecorder("unused", nil)))
+		container.Register("mock", goldi.NewType(NewMockType))
+
+		container.MustGet("repository")
+		container.MustGet("mock")
+
+		Expect(container.Close()).To(Succeed())
+		Expect(closed).To(Equal([]string{"repository", "database"}))
+	})
+
+	It("should aggregate all errors", func() {
+		errFirst, errSecond := errors.New("first failed"), errors.New("second failed")
+		container.Register("first", goldi.NewType(newRecorder("first", errFirst)))
+		container.Register("second", goldi.NewType(newRecorder("second", errSecond)))
+		container.Register("third", goldi.NewType(newRecorder("third", nil)))
+		container.MustGet("first")
+		container.MustGet("second")
+		container.MustGet("third")
+
+		err := container.Close()
+		Expect(err).To(MatchError(`goldi: could not close all types: could not close type "second": second failed; could not close type "first": first failed`))
+		Expect(errors.Is(err, errFirst)).To(BeTrue())
+		Expect(errors.Is(err, errSecond)).To(BeTrue())
+		Expect(closed).To(Equal([]string{"third", "second", "first"}))
+	})
+
+	It("should close types that have been generated again only once", func() {
+		container.Register("database", goldi.NewType(newRecorder("database", nil)))
+		container.Register("repository", goldi.NewType(func(*closeRecorder) *closeRecorder {
+			return newRecorder("repository", nil)()
+		}, "@database"))
+
+		container.MustGet("repository")
+		container.Replace("database", newRecorder("replaced database", nil)())
+		container.MustGet("repository")
+
+		Expect(container.Close()).To(Succeed())
+		Expect(closed).To(Equal([]string{"repository", "replaced database"}))
+	})
+
+	It("should do nothing if it is called again", func() {
+		container.Register("first", goldi.NewType(newRecorder("first", errors.New("failed"))))
+		container.MustGet("first")
+
+		Expect(container.Close()).NotTo(Succeed())
+		Expect(func() { Expect(container.Close()).To(Succeed()) }).NotTo(Panic())
+		Expect(closed).To(Equal([]string{"first"}))
+	})
+})
@@ -0,0 +1,162 @@
+package goldi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// coerce converts the given value into a value of the expected type.
+// Assignable values are used as they are. Strings are parsed into booleans, numbers and durations
+// and numeric values are converted into other numeric types as long as no information is lost.
+// Lists are converted element wise into slices of the expected type.
+func coerce(value interface{}, expectedType reflect.Type) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.IsValid() == false {
+		return reflect.Zero(expectedType), nil
+	}
+
+	result := reflect.New(expectedType).Elem()
+	if v.Type().AssignableTo(expectedType) {
+		result.Set(v)
+		return result, nil
+	}
+
+	if s, isString := value.(string); isString {
+		return result, coerceString(s, result)
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && expectedType.Kind() == reflect.Slice {
+		return coerceSlice(v, expectedType)
+	}
+
+	if isNumeric(v.Kind()) && isNumeric(expectedType.Kind()) {
+		isFloat := v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+		if isFloat && expectedType.Kind() != reflect.Float32 && expectedType.Kind() != reflect.Float64 {
+			if f := v.Float(); f != math.Trunc(f) {
+				return result, fmt.Errorf("can not convert %v (type %T) to %v without losing precision", value, value, expectedType)
+			}
+		}
+
+		if overflows(v, result) {
+			return result, fmt.Errorf("can not convert %v (type %T) to %v: value out of range", value, value, expectedType)
+		}
+
+		result.Set(v.Convert(expectedType))
+		return result, nil
+	}
+
+	return result, fmt.Errorf("can not convert %v (type %T) to %v", value, value, expectedType)
+}
+
+// coerceSlice converts each element of the given list into the element type of the expected slice type.
+// Empty lists result in empty but non-nil slices.
+func coerceSlice(list reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	result := reflect.MakeSlice(expectedType, list.Len(), list.Len())
+	for i := 0; i < list.Len(); i++ {
+		element, err := coerce(list.Index(i).Interface(), expectedType.Elem())
+		if err != nil {
+			return reflect.New(expectedType).Elem(), fmt.Errorf("can not convert element %d: %s", i, err)
+		}
+
+		result.Index(i).Set(element)
+	}
+
+	return result, nil
+}
+
+func coerceString(s string, result reflect.Value) error {
+	var err error
+	switch {
+	case result.Type() == durationType:
+		var d time.Duration
+		d, err = time.ParseDuration(s)
+		result.SetInt(int64(d))
+	case result.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		result.SetBool(b)
+	case result.Kind() >= reflect.Int && result.Kind() <= reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, result.Type().Bits())
+		result.SetInt(i)
+	case result.Kind() >= reflect.Uint && result.Kind() <= reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, result.Type().Bits())
+		result.SetUint(u)
+	case result.Kind() == reflect.Float32 || result.Kind() == reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, result.Type().Bits())
+		result.SetFloat(f)
+	default:
+		return fmt.Errorf("can not convert %q to %v", s, result.Type())
+	}
+
+	if err != nil {
+		return fmt.Errorf("can not convert %q to %v: %s", s, result.Type(), err)
+	}
+
+	return nil
+}
+
+// isLosslessConversion returns true if values of the given type are not assignable to the expected type but can be
+// converted into it via reflect.Value.Convert without losing information. This is the case for types with the same
+// kind like a named string type and string. Conversions between different kinds (e.g. int to string or float64 to
+// int) are never considered lossless.
+func isLosslessConversion(t, expectedType reflect.Type) bool {
+	if t.AssignableTo(expectedType) || t.ConvertibleTo(expectedType) == false {
+		return false
+	}
+
+	return t.Kind() == expectedType.Kind() && t.Kind() != reflect.Interface
+}
+
+// overflows returns true if the numeric value v can not be represented by the numeric type of result.
+// Negative values never fit into unsigned types.
+func overflows(v, result reflect.Value) bool {
+	switch {
+	case isSigned(v.Kind()):
+		i := v.Int()
+		switch {
+		case isSigned(result.Kind()):
+			return result.OverflowInt(i)
+		case isUnsigned(result.Kind()):
+			return i < 0 || result.OverflowUint(uint64(i))
+		}
+		return result.OverflowFloat(float64(i))
+	case isUnsigned(v.Kind()):
+		u := v.Uint()
+		switch {
+		case isSigned(result.Kind()):
+			return u > math.MaxInt64 || result.OverflowInt(int64(u))
+		case isUnsigned(result.Kind()):
+			return result.OverflowUint(u)
+		}
+		return result.OverflowFloat(float64(u))
+	}
+
+	f := v.Float()
+	switch {
+	case isSigned(result.Kind()):
+		return f < math.MinInt64 || f >= math.MaxInt64 || result.OverflowInt(int64(f))
+	case isUnsigned(result.Kind()):
+		return f < 0 || f >= math.MaxUint64 || result.OverflowUint(uint64(f))
+	}
+	return result.OverflowFloat(f)
+}
+
+func isSigned(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUnsigned(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumeric(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
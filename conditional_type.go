@@ -0,0 +1,41 @@
+package goldi
+
+import "fmt"
+
+type conditionalType struct {
+	predicate       func(*Container) bool
+	ifTrue, ifFalse TypeFactory
+}
+
+// NewConditionalType creates a new TypeFactory that delegates to ifTrue if the given predicate returns true and to
+// ifFalse otherwise. The predicate receives the container so it can decide based on parameters (e.g. feature flags).
+//
+// The predicate is evaluated each time the type is generated. Since the container caches all generated types
+// this happens only once when the type is retrieved via the container, but each time if the factory is used
+// as inline type factory or if its Generate function is called directly.
+//
+// This function will return an invalid type if the predicate or any of the factories is nil.
+func NewConditionalType(predicate func(*Container) bool, ifTrue, ifFalse TypeFactory) TypeFactory {
+	if predicate == nil {
+		return newInvalidType(fmt.Errorf("the predicate of a conditional type must not be nil"))
+	}
+
+	if ifTrue == nil || ifFalse == nil {
+		return newInvalidType(fmt.Errorf("the type factories of a conditional type must not be nil"))
+	}
+
+	return &conditionalType{predicate, ifTrue, ifFalse}
+}
+
+// Arguments returns both type factories since it can not be known which one will be used.
+func (t *conditionalType) Arguments() []interface{} {
+	return []interface{}{t.ifTrue, t.ifFalse}
+}
+
+func (t *conditionalType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	if t.predicate(resolver.Container) {
+		return t.ifTrue.Generate(resolver)
+	}
+
+	return t.ifFalse.Generate(resolver)
+}
@@ -0,0 +1,80 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("conditionalType", func() {
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+		enabled   bool
+		factory   goldi.TypeFactory
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{"new_cache": true})
+		resolver = goldi.NewParameterResolver(container)
+		enabled = true
+		factory = goldi.NewConditionalType(
+			func(*goldi.Container) bool { return enabled },
+			goldi.NewStructType(Foo{}, "new"),
+			goldi.NewStructType(Foo{}, "old"),
+		)
+	})
+
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewConditionalType(func(*goldi.Container) bool { return true }, goldi.NewStructType(Foo{}), goldi.NewStructType(Bar{}))
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	It("should evaluate the predicate each time the type is generated", func() {
+		generated, err := factory.Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("new"))
+
+		enabled = false
+		generated, err = factory.Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("old"))
+	})
+
+	It("should evaluate the predicate only once if the type is retrieved via the container", func() {
+		container.Register("cache", factory)
+		Expect(container.MustGet("cache").(*Foo).Value).To(Equal("new"))
+
+		enabled = false
+		Expect(container.MustGet("cache").(*Foo).Value).To(Equal("new"))
+	})
+
+	It("should pass the container to the predicate", func() {
+		container.Register("cache", goldi.NewConditionalType(
+			func(c *goldi.Container) bool { return c.Config["new_cache"] == true },
+			goldi.NewStructType(Foo{}, "new"),
+			goldi.NewStructType(Foo{}, "old"),
+		))
+		Expect(container.MustGet("cache").(*Foo).Value).To(Equal("new"))
+	})
+
+	It("should return the arguments of both type factories", func() {
+		ifTrue, ifFalse := goldi.NewStructType(Foo{}, "@foo"), goldi.NewStructType(Foo{}, "@bar")
+		factory = goldi.NewConditionalType(func(*goldi.Container) bool { return true }, ifTrue, ifFalse)
+		Expect(factory.Arguments()).To(Equal([]interface{}{ifTrue, ifFalse}))
+	})
+
+	It("should return an invalid type if the predicate is nil", func() {
+		factory = goldi.NewConditionalType(nil, goldi.NewStructType(Foo{}), goldi.NewStructType(Foo{}))
+		Expect(goldi.IsValid(factory)).To(BeFalse())
+		Expect(factory).To(MatchError("the predicate of a conditional type must not be nil"))
+	})
+
+	It("should return an invalid type if any type factory is nil", func() {
+		factory = goldi.NewConditionalType(func(*goldi.Container) bool { return true }, goldi.NewStructType(Foo{}), nil)
+		Expect(goldi.IsValid(factory)).To(BeFalse())
+		Expect(factory).To(MatchError("the type factories of a conditional type must not be nil"))
+	})
+})
@@ -57,7 +57,7 @@ func (t *configuredType) Generate(parameterResolver *ParameterResolver) (interfa
 		return nil, fmt.Errorf("can not generate configured type: %s", err)
 	}
 
-	if err = t.Configure(embedded, parameterResolver.Container); err != nil {
+	if err = t.configure(embedded, parameterResolver); err != nil {
 		return nil, fmt.Errorf("can not configure type: %s", err)
 	}
 
@@ -1,6 +1,16 @@
 package goldi
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Container is the dependency injection container that can be used by your application to define and get types.
 //
@@ -13,7 +23,55 @@ type Container struct {
 	Config   map[string]interface{}
 	Resolver *ParameterResolver
 
-	typeCache map[string]interface{}
+	// RejectNilTypes can be set to true to let Get return an error if a type factory generated nil.
+	// This is disabled by default since some applications register types that are intentionally nil.
+	RejectNilTypes bool
+
+	// RejectDuplicateTypes can be set to true to let the Register functions of the container panic if a different
+	// type has already been registered with the same type ID. Registering an equal type again is allowed
+	// (see FactoriesEqual). By default the existing type is overwritten silently.
+	// Note that types which are registered on the TypeRegistry directly are not checked.
+	RejectDuplicateTypes bool
+
+	// StrictInterfaceReturns can be set to true to let the Register functions of the container panic if the factory
+	// function of a type returns a concrete type like a pointer to a struct instead of an interface. This can be used to
+	// enforce programming against interfaces as a team policy. It is disabled by default.
+	// Note that types which are registered on the TypeRegistry directly are not checked.
+	StrictInterfaceReturns bool
+
+	// CollectStats can be set to true to count how often each type has been generated and retrieved from the
+	// type cache. The counters are available via Container.Stats. This is disabled by default.
+	CollectStats bool
+
+	// Clock returns the current time that is used to determine whether the cached instances of types with a
+	// CachePolicy have expired. It defaults to time.Now and can be replaced in tests.
+	Clock func() time.Time
+
+	// OnError is called with the type ID and the error whenever a requested type can not be generated or resolved.
+	// It is called once with the error that is returned to the caller and not for each failed dependency.
+	// It can be used to log or count wiring failures in a central place and does not alter the returned error.
+	OnError func(typeID string, err error)
+
+	mutex          sync.Mutex // protects the typeCache, requestedTypes, bindings and registrations via GetOrRegister
+	registerMutex  sync.Mutex // serializes GetOrRegister
+	typeCache      map[string]interface{}
+	requestedTypes StringSet
+	fallback       *Container
+	bindings       map[reflect.Type]string
+	interfaces     map[string]reflect.Type
+	scopeMutex     sync.Mutex // protects the scopes
+	scopes         map[context.Context]*scope
+	stats          map[string]*TypeStats
+	defaults       map[string]interface{}
+	cachedAt       map[string]time.Time
+	autoConfigs    []autoConfigurator
+	generated      []string // the IDs of the cached types in the order in which they have been generated
+	closed         bool     // see Container.Close
+	generating     map[string]*generation
+
+	parametersMutex   sync.RWMutex  // protects the Config and the defaults when they are modified via SetParameter
+	parametersVersion atomic.Uint64 // incremented by SetParameter, see ParameterResolver.CacheParameters
+	frozen            atomic.Bool   // see Container.Freeze
 }
 
 // NewContainer creates a new container instance using the provided arguments
@@ -22,6 +80,10 @@ func NewContainer(registry TypeRegistry, config map[string]interface{}) *Contain
 		TypeRegistry: registry,
 		Config:       config,
 		typeCache:    map[string]interface{}{},
+
+		requestedTypes: StringSet{},
+		bindings:       map[reflect.Type]string{},
+		interfaces:     defaultInterfaces(),
 	}
 
 	c.Resolver = NewParameterResolver(c)
@@ -40,8 +102,42 @@ func (c *Container) MustGet(typeID string) interface{} {
 	return t
 }
 
+// GetAssignable retrieves a previously defined type just like Get and stores it in the value pointed to by target.
+// This saves you the type assertion after calling Get:
+//     var logger LoggerInterface
+//     err := container.GetAssignable("logger", &logger)
+//
+// GetAssignable returns an error if target is no pointer, the type can not be retrieved or
+// if it is not assignable to the type target points to.
+func (c *Container) GetAssignable(typeID string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return fmt.Errorf("goldi: can not get type %q: target must be a non-nil pointer but is %T", typeID, target)
+	}
+
+	instance, err := c.Get(typeID)
+	if err != nil {
+		return err
+	}
+
+	expectedType := targetValue.Elem().Type()
+	if instance == nil {
+		targetValue.Elem().Set(reflect.Zero(expectedType))
+		return nil
+	}
+
+	if reflect.TypeOf(instance).AssignableTo(expectedType) == false {
+		return newTypeReferenceError(typeID, instance, "goldi: type %q (type %T) is not assignable to %v", typeID, instance, expectedType)
+	}
+
+	targetValue.Elem().Set(reflect.ValueOf(instance))
+	return nil
+}
+
 // Get retrieves a previously defined type or an error.
 // If the requested typeID has not been registered before or can not be generated Get will return an error.
+// Get is safe for concurrent use: if several goroutines request a type that has not been generated yet, the type
+// is generated only once and all callers receive the same instance.
 //
 // For your dependency injection to work properly it is important that you do only try to assert interface types
 // when you use Get(..). Otherwise it might be impossible to assert the correct type when you change the underlying type
@@ -50,34 +146,497 @@ func (c *Container) MustGet(typeID string) interface{} {
 //
 // See also Container.MustGet
 func (c *Container) Get(typeID string) (interface{}, error) {
-	instance, isDefined, err := c.get(typeID)
-	if err != nil {
-		return nil, err
+	return c.Resolver.get(typeID)
+}
+
+// GetWithContext retrieves a type just like Get but makes the given context available to the factories of the type
+// and of all types it depends on that have not been generated yet (see ContextArgument).
+// This can be used to pass request scoped values to types that are generated lazily on their first use.
+//
+// Keep in mind that types are only generated once. The context of the first call that generates a type is the
+// context that the type receives. Later calls with another context return the already generated type.
+func (c *Container) GetWithContext(ctx context.Context, typeID string) (interface{}, error) {
+	return c.Resolver.withContext(ctx).get(typeID)
+}
+
+// GetTimed retrieves a type just like Get and additionally returns how long it took to retrieve it.
+// This includes the generation of all dependencies that had not been generated yet, so it can be used to identify
+// slow types during development. Types that have already been cached are returned almost immediately.
+func (c *Container) GetTimed(typeID string) (interface{}, time.Duration, error) {
+	start := time.Now()
+	instance, err := c.Get(typeID)
+	return instance, time.Since(start), err
+}
+
+// GetMatching retrieves all types whose IDs match the given pattern.
+// The pattern syntax is the same as in path.Match, so "handler.*" matches all types that start with "handler.".
+// This can be used to discover plugins by a naming convention.
+//
+// All types that could be generated are returned even if an error occurs.
+// The returned MultiError contains the errors of all types that could not be generated.
+func (c *Container) GetMatching(pattern string) (map[string]interface{}, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("goldi: invalid pattern %q: %s", pattern, err)
 	}
 
-	if isDefined == false {
-		return nil, newUnknownTypeReferenceError(typeID, "no such type has been defined")
+	typeIDs := make([]string, 0, len(c.TypeRegistry))
+	for typeID := range c.TypeRegistry {
+		if isMatch, _ := path.Match(pattern, typeID); isMatch {
+			typeIDs = append(typeIDs, typeID)
+		}
 	}
+	sort.Strings(typeIDs)
 
-	return instance, nil
+	instances := map[string]interface{}{}
+	errs := NewMultiError(fmt.Sprintf("goldi: could not get all types matching %q", pattern))
+	for _, typeID := range typeIDs {
+		instance, err := c.Get(typeID)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+
+		instances[typeID] = instance
+	}
+
+	return instances, errs.ErrorOrNil()
 }
 
 func (c *Container) get(typeID string) (interface{}, bool, error) {
+	return c.generate(typeID, c.Resolver)
+}
+
+// generate returns the cached instance of the given type or generates it using the given resolver.
+// Concurrent calls for the same type wait for the first call to finish so each type is generated only once.
+func (c *Container) generate(typeID string, resolver *ParameterResolver) (interface{}, bool, error) {
+	c.mutex.Lock()
+	c.requestedTypes.Set(typeID)
 	t, isCached := c.typeCache[typeID]
+	generator, isDefined := c.TypeRegistry[typeID]
+	if isCached && c.isExpired(typeID, generator) {
+		expired, _ := c.evict(typeID)
+		defer closeExpired(expired)
+		isCached = false
+	}
 	if isCached {
+		c.recordStats(typeID, false)
+		c.mutex.Unlock()
 		return t, true, nil
 	}
 
-	generator, isDefined := c.TypeRegistry[typeID]
 	if isDefined == false {
+		c.mutex.Unlock()
+		if c.fallback != nil {
+			fallbackResolver := c.fallback.Resolver.withContext(resolver.ctx)
+			fallbackResolver.generating = resolver.generating
+			return c.fallback.generate(typeID, fallbackResolver)
+		}
+
 		return nil, false, nil
 	}
 
-	instance, err := generator.Generate(c.Resolver)
+	if g, isGenerating := c.generating[typeID]; isGenerating {
+		c.mutex.Unlock()
+		if resolver.isGenerating(typeID) {
+			return nil, false, fmt.Errorf("goldi: error while generating type %q: circular reference %s", typeID, strings.Join(append(resolver.generating, typeID), " -> "))
+		}
+
+		<-g.done
+		if g.err != nil {
+			return nil, false, g.err
+		}
+
+		c.mutex.Lock()
+		c.recordStats(typeID, false)
+		c.mutex.Unlock()
+		return g.instance, true, nil
+	}
+
+	// the error is overwritten by the result of the type factory and only remains if the factory panics
+	g := &generation{done: make(chan struct{}), err: fmt.Errorf("goldi: error while generating type %q: the type factory panicked", typeID)}
+	if c.generating == nil {
+		c.generating = map[string]*generation{}
+	}
+	c.generating[typeID] = g
+	c.mutex.Unlock()
+
+	defer c.finishGeneration(typeID, g)
+	g.instance, g.err = c.generateInstance(typeID, generator, resolver.withGenerating(typeID))
+	if g.err != nil {
+		return nil, false, g.err
+	}
+
+	return g.instance, true, nil
+}
+
+// A generation is a type that is currently generated by the container. It is used to let concurrent requests for the
+// same type wait for the result instead of calling the type factory again.
+type generation struct {
+	done     chan struct{}
+	instance interface{}
+	err      error
+}
+
+// generateInstance uses the given type factory to generate a new instance of the type with the given ID.
+func (c *Container) generateInstance(typeID string, generator TypeFactory, resolver *ParameterResolver) (interface{}, error) {
+	instance, err := generator.Generate(resolver)
 	if err != nil {
-		return nil, false, fmt.Errorf("goldi: error while generating type %q: %s", typeID, err)
+		return nil, fmt.Errorf("goldi: error while generating type %q: %w", typeID, withConsumer(err, typeID))
+	}
+
+	if c.RejectNilTypes && isNil(instance) {
+		return nil, fmt.Errorf("goldi: error while generating type %q: the type factory returned nil", typeID)
+	}
+
+	if err = c.autoConfigure(instance); err != nil {
+		return nil, fmt.Errorf("goldi: error while generating type %q: %w", typeID, err)
+	}
+
+	return instance, nil
+}
+
+// finishGeneration caches the result of the given generation if it was successful and releases all callers that
+// are waiting for it.
+func (c *Container) finishGeneration(typeID string, g *generation) {
+	c.mutex.Lock()
+	delete(c.generating, typeID)
+	if g.err == nil {
+		c.typeCache[typeID] = g.instance
+		c.setCachedAt(typeID, c.TypeRegistry[typeID])
+		c.generated = append(c.generated, typeID)
+		c.recordStats(typeID, true)
+	}
+	c.mutex.Unlock()
+	close(g.done)
+}
+
+// evict removes the cached instance of the given type and returns it. The caller must hold c.mutex.
+func (c *Container) evict(typeID string) (interface{}, bool) {
+	instance, isCached := c.typeCache[typeID]
+	if isCached == false {
+		return nil, false
+	}
+
+	delete(c.typeCache, typeID)
+	for i, generatedID := range c.generated {
+		if generatedID == typeID {
+			c.generated = append(c.generated[:i], c.generated[i+1:]...)
+			break
+		}
 	}
 
-	c.typeCache[typeID] = instance
-	return instance, true, nil
+	return instance, true
+}
+
+// handleError passes the given error to the OnError hook if it has been set and returns the error unchanged.
+// It must only be called by the outermost call that retrieves a type so the hook is called once per failed
+// request and not for the dependencies that failed along the way or for errors that are handled internally.
+func (c *Container) handleError(typeID string, err error) error {
+	if c.OnError != nil {
+		c.OnError(typeID, err)
+	}
+
+	return err
+}
+
+// GetOrRegister retrieves the type with the given ID just like Get. If no such type has been registered yet,
+// the TypeFactory returned by f is registered under the given ID first. This is useful to cache services that are
+// computed on demand under dynamic type IDs.
+//
+// GetOrRegister is safe for concurrent use. It calls f at most once per type ID and all concurrent callers receive
+// the same instance (see Get).
+// Note that this does not make other modifications of the type registry safe for concurrent use.
+func (c *Container) GetOrRegister(typeID string, f func() TypeFactory) (interface{}, error) {
+	c.registerMutex.Lock()
+	c.mutex.Lock()
+	_, isDefined := c.TypeRegistry[typeID]
+	c.mutex.Unlock()
+
+	if isDefined == false {
+		if err := c.checkNotFrozen("register", typeID); err != nil {
+			c.registerMutex.Unlock()
+			return nil, err
+		}
+
+		factory := f()
+		if factory == nil {
+			c.registerMutex.Unlock()
+			return nil, fmt.Errorf("goldi: can not register type %q: the factory builder returned nil", typeID)
+		}
+
+		if err := c.checkInterfaceReturn(typeID, factory); err != nil {
+			c.registerMutex.Unlock()
+			return nil, err
+		}
+
+		c.mutex.Lock()
+		c.TypeRegistry.Register(typeID, factory)
+		c.mutex.Unlock()
+	}
+	c.registerMutex.Unlock()
+
+	return c.Get(typeID)
+}
+
+// SetFallback configures another container that is consulted whenever a requested type has not been defined in this container.
+// The fallback container generates and caches these types itself, so several containers can share the same fallback.
+// Passing nil removes the fallback.
+//
+// SetFallback returns an error if the given container would create a cycle of fallback containers.
+func (c *Container) SetFallback(other *Container) error {
+	for f := other; f != nil; f = f.fallback {
+		if f == c {
+			return fmt.Errorf("goldi: can not set fallback container: this would create a fallback cycle")
+		}
+	}
+
+	c.fallback = other
+	return nil
+}
+
+// UnusedTypes returns the alphabetically sorted IDs of all registered types that have never been requested from this container,
+// neither directly via Get nor indirectly as a dependency of another type.
+//
+// This can be used to detect obsolete type definitions. Keep in mind that the types are generated lazily,
+// so types which are only needed by code paths that have not been executed yet are reported as unused as well.
+func (c *Container) UnusedTypes() []string {
+	c.mutex.Lock()
+	var unused []string
+	for typeID := range c.TypeRegistry {
+		if c.requestedTypes.Contains(typeID) == false {
+			unused = append(unused, typeID)
+		}
+	}
+	c.mutex.Unlock()
+
+	sort.Strings(unused)
+	return unused
+}
+
+// WarmUp eagerly generates the types with the given IDs and thereby all types they depend on.
+// All other types are still generated lazily. This can be used to prepare latency critical code paths
+// while keeping rarely used types lazy.
+//
+// WarmUp stops at the first type that can not be generated and returns its error.
+func (c *Container) WarmUp(typeIDs ...string) error {
+	for _, typeID := range typeIDs {
+		if _, err := c.Get(typeID); err != nil {
+			return fmt.Errorf("goldi: could not warm up type %q: %s", typeID, err)
+		}
+	}
+
+	return nil
+}
+
+// Each calls fn for every type that has already been generated by this container.
+// The types are visited in alphabetical order of their IDs. Each never triggers the generation of new types,
+// which makes it suitable for bulk operations on live services like flushing caches or collecting metrics.
+func (c *Container) Each(fn func(typeID string, instance interface{})) {
+	c.mutex.Lock()
+	typeIDs := make([]string, 0, len(c.typeCache))
+	instances := make(map[string]interface{}, len(c.typeCache))
+	for typeID, instance := range c.typeCache {
+		typeIDs = append(typeIDs, typeID)
+		instances[typeID] = instance
+	}
+	c.mutex.Unlock()
+
+	sort.Strings(typeIDs)
+	for _, typeID := range typeIDs {
+		fn(typeID, instances[typeID])
+	}
+}
+
+// isNil returns true if the given instance is nil or a typed nil value (e.g. a nil pointer).
+func isNil(instance interface{}) bool {
+	if instance == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(instance)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Func, reflect.Map, reflect.Slice, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// GetParameter stores the value of the parameter with the given name in the value pointed to by target.
+// The configured value is converted to the type of target if necessary, so for instance the string "5s"
+// can be read into a time.Duration and the string "42" into an int.
+//
+// GetParameter returns an error if target is no pointer, if the parameter has not been defined or
+// if its value can not be converted to the type of target.
+func (c *Container) GetParameter(name string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return fmt.Errorf("goldi: can not get parameter %q: target must be a non-nil pointer but is %T", name, target)
+	}
+
+	if _, isDefined := c.parameter(name); isDefined == false {
+		return fmt.Errorf("goldi: can not get parameter %q: no such parameter has been defined", name)
+	}
+
+	value, err := c.Resolver.Resolve(reflect.ValueOf("%"+name+"%"), targetValue.Elem().Type())
+	if err != nil {
+		return fmt.Errorf("goldi: can not get parameter %q: %s", name, err)
+	}
+
+	targetValue.Elem().Set(value)
+	return nil
+}
+
+// Register behaves exactly like TypeRegistry.Register but panics if RejectDuplicateTypes is enabled
+// and a different type has already been registered with the given typeID, if StrictInterfaceReturns is enabled and
+// the factory returns a concrete type or if the container has been frozen.
+func (c *Container) Register(typeID string, typeDef TypeFactory) {
+	if err := c.checkNotFrozen("register", typeID); err != nil {
+		panic(err)
+	}
+
+	if err := c.checkInterfaceReturn(typeID, typeDef); err != nil {
+		panic(err)
+	}
+
+	if existing, isDefined := c.TypeRegistry[typeID]; isDefined && c.RejectDuplicateTypes && FactoriesEqual(existing, typeDef) == false {
+		panic(fmt.Errorf("goldi: type %q has already been registered", typeID))
+	}
+
+	c.TypeRegistry.Register(typeID, typeDef)
+}
+
+// checkInterfaceReturn returns an error if StrictInterfaceReturns is enabled and the factory function of the given
+// type factory returns a concrete type. Type factories without factory function (e.g. struct types) are not checked.
+func (c *Container) checkInterfaceReturn(typeID string, typeDef TypeFactory) error {
+	if c.StrictInterfaceReturns == false {
+		return nil
+	}
+
+	var factoryType reflect.Type
+	switch f := undecorated(typeDef).(type) {
+	case *typeFactory:
+		factoryType = f.factoryType
+	case *singletonFuncType:
+		factoryType = f.function.factoryType
+	default:
+		return nil
+	}
+
+	if returnType := factoryType.Out(0); returnType.Kind() != reflect.Interface {
+		return fmt.Errorf("goldi: can not register type %q: the factory returns the concrete type %v but StrictInterfaceReturns requires an interface type", typeID, returnType)
+	}
+
+	return nil
+}
+
+// RegisterAll behaves exactly like TypeRegistry.RegisterAll but uses Container.Register for each type.
+func (c *Container) RegisterAll(factories map[string]TypeFactory) {
+	for typeID, typeDef := range factories {
+		c.Register(typeID, typeDef)
+	}
+}
+
+// RegisterAllStrict behaves exactly like TypeRegistry.RegisterAllStrict but returns an error instead of registering
+// any type if the container has been frozen or if StrictInterfaceReturns is enabled and a factory returns a concrete
+// type. Type IDs that have already been registered are always rejected regardless of RejectDuplicateTypes.
+func (c *Container) RegisterAllStrict(factories map[string]TypeFactory) error {
+	if c.IsFrozen() {
+		return fmt.Errorf("goldi: can not register types: the container has been frozen")
+	}
+
+	typeIDs := make([]string, 0, len(factories))
+	for typeID := range factories {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	errs := NewMultiError("goldi: could not register types")
+	for _, typeID := range typeIDs {
+		if err := c.checkInterfaceReturn(typeID, factories[typeID]); err != nil {
+			errs.Add(err)
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.TypeRegistry.RegisterAllStrict(factories)
+}
+
+// RegisterType behaves exactly like TypeRegistry.RegisterType but uses Container.Register.
+func (c *Container) RegisterType(typeID string, factory interface{}, arguments ...interface{}) {
+	c.Register(typeID, newTypeFactory(typeID, factory, arguments))
+}
+
+// RegisterWithTags behaves exactly like TypeRegistry.RegisterWithTags but uses Container.Register.
+func (c *Container) RegisterWithTags(typeID string, typeDef TypeFactory, tags ...string) {
+	c.Register(typeID, NewTaggedType(typeDef, tags...))
+}
+
+// RegisterWithDeps behaves exactly like TypeRegistry.RegisterWithDeps but uses Container.Register.
+func (c *Container) RegisterWithDeps(typeID string, typeDef TypeFactory, dependencies ...string) {
+	c.Register(typeID, NewDeclaredDependenciesType(typeDef, dependencies...))
+}
+
+// InjectInstance behaves exactly like TypeRegistry.InjectInstance but uses Container.Register.
+func (c *Container) InjectInstance(typeID string, instance interface{}) {
+	c.Register(typeID, NewInstanceType(instance))
+}
+
+// Override replaces the TypeFactory of an already registered type and removes any cached instance of it
+// so the next call to Get will use the new factory. Like Replace it also removes all cached types that directly or
+// indirectly depend on that type, so they are generated again using the new factory.
+// Override returns an error if no type has been registered with the given typeID.
+//
+// Note that instances which have already been retrieved from the container keep using the old instance.
+func (c *Container) Override(typeID string, factory TypeFactory) error {
+	if err := c.checkNotFrozen("override", typeID); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, isDefined := c.TypeRegistry[typeID]; isDefined == false {
+		return newUnknownTypeReferenceError(typeID, "can not override type %q: no such type has been defined", typeID)
+	}
+
+	c.TypeRegistry.Register(typeID, factory)
+	c.invalidate(typeID, StringSet{})
+	return nil
+}
+
+// Replace pins the given instance for the type with the given ID. The registered factory (and its arguments) is
+// replaced by the instance and all cached types that directly or indirectly depend on that type are removed from the
+// cache, so they are generated again using the replacement the next time they are requested.
+// This is the simplest way to inject test doubles into a container.
+//
+// Note that instances which have already been retrieved from the container keep using the replaced type.
+// Replace panics if the container has been frozen.
+func (c *Container) Replace(typeID string, instance interface{}) {
+	if err := c.checkNotFrozen("replace", typeID); err != nil {
+		panic(err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.TypeRegistry.Register(typeID, NewInstanceType(instance))
+	c.invalidate(typeID, StringSet{})
+}
+
+// invalidate removes the type with the given ID and all types that depend on it from the type cache.
+func (c *Container) invalidate(typeID string, invalidated StringSet) {
+	invalidated.Set(typeID)
+	c.evict(typeID)
+
+	for dependentID, factory := range c.TypeRegistry {
+		if invalidated.Contains(dependentID) == false && c.dependencies(factory).Contains(typeID) {
+			c.invalidate(dependentID, invalidated)
+		}
+	}
 }
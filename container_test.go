@@ -1,7 +1,12 @@
 package goldi_test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/fgrosse/goldi"
 	. "github.com/onsi/ginkgo/v2"
@@ -99,6 +104,39 @@ var _ = Describe("Container", func() {
 		Expect(firstResult == thirdResult).To(BeTrue())
 	})
 
+	It("should generate each type only once if it is requested concurrently", func() {
+		var calls int32
+		container.Register("slow_type", goldi.NewType(func() *MockType {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return &MockType{}
+		}))
+
+		var wg sync.WaitGroup
+		results := make([]interface{}, 8)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = container.MustGet("slow_type")
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(1))
+		for _, result := range results {
+			Expect(result).To(BeIdenticalTo(results[0]))
+		}
+	})
+
+	It("should return an error if types reference each other", func() {
+		container.Register("a", goldi.NewType(NewTypeForServiceInjection, "@b"))
+		container.Register("b", goldi.NewType(NewTypeForServiceInjection, "@a"))
+
+		_, err := container.Get("a")
+		Expect(err).To(MatchError(ContainSubstring(`goldi: error while generating type "a": circular reference a -> b -> a`)))
+	})
+
 	It("should pass static parameters as arguments when generating types", func() {
 		typeID := "test_type"
 		typeDef := goldi.NewType(NewMockTypeWithArgs, "parameter1", true)
@@ -142,6 +180,25 @@ var _ = Describe("Container", func() {
 		Expect(generatedMock.InjectedType).To(BeAssignableToTypeOf(&MockType{}))
 	})
 
+	It("should resolve references to types that are registered after the referencing type", func() {
+		container.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@injected_type"))
+		container.Register("alias", goldi.NewAliasType("injected_type"))
+		container.Register("injected_type", goldi.NewType(NewMockType))
+
+		generatedMock := container.MustGet("main_type").(*TypeForServiceInjection)
+		Expect(generatedMock.InjectedType).To(BeIdenticalTo(container.MustGet("injected_type")))
+		Expect(container.MustGet("alias")).To(BeIdenticalTo(container.MustGet("injected_type")))
+	})
+
+	It("should use the latest registration of a referenced type", func() {
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@injected_type"))
+		registry.Register("injected_type", goldi.NewType(NewMockTypeWithArgs, "first", false))
+		registry.Register("injected_type", goldi.NewType(NewMockTypeWithArgs, "second", true))
+
+		generatedMock := container.MustGet("main_type").(*TypeForServiceInjection)
+		Expect(generatedMock.InjectedType.StringParameter).To(Equal("second"))
+	})
+
 	It("should inject the same instance when it is used by different services", func() {
 		registry.RegisterType("foo", NewMockType)
 		registry.RegisterType("type1", NewTypeForServiceInjection, "@foo")
@@ -168,4 +225,657 @@ var _ = Describe("Container", func() {
 		generatedMock := generatedType.(*TypeForServiceInjection)
 		Expect(generatedMock.InjectedType).To(BeNil())
 	})
+
+	It("should inject nil pointers into struct fields of optional types that are not defined", func() {
+		registry.Register("main_type", goldi.NewStructType(TypeForServiceInjection{}, "@?optional_type"))
+
+		generatedMock := container.MustGet("main_type").(*TypeForServiceInjection)
+		Expect(generatedMock.InjectedType).To(BeNil())
+	})
+
+	It("should inject optional types if they are defined", func() {
+		registry.Register("optional_type", goldi.NewType(NewMockType))
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@?optional_type"))
+
+		generatedMock := container.MustGet("main_type").(*TypeForServiceInjection)
+		Expect(generatedMock.InjectedType).To(BeIdenticalTo(container.MustGet("optional_type")))
+	})
+
+	It("should return an error when required types are not defined", func() {
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@required_type"))
+
+		_, err := container.Get("main_type")
+		Expect(err).To(MatchError(`goldi: error while generating type "main_type": the referenced type "@required_type" has not been defined`))
+	})
+
+	Context("when a type factory returns nil", func() {
+		BeforeEach(func() {
+			registry.RegisterType("nil_type", func() *MockType { return nil })
+		})
+
+		It("should return the nil type by default", func() {
+			t, err := container.Get("nil_type")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(BeNil())
+		})
+
+		It("should return an error if RejectNilTypes is enabled", func() {
+			container.RejectNilTypes = true
+			_, err := container.Get("nil_type")
+			Expect(err).To(MatchError(`goldi: error while generating type "nil_type": the type factory returned nil`))
+		})
+	})
+
+	Describe("SetFallback", func() {
+		var fallback *goldi.Container
+
+		BeforeEach(func() {
+			fallback = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			Expect(container.SetFallback(fallback)).To(Succeed())
+		})
+
+		It("should get types from the fallback container if they are not defined", func() {
+			fallback.RegisterType("foo", NewMockType)
+			Expect(container.MustGet("foo")).To(BeIdenticalTo(fallback.MustGet("foo")))
+		})
+
+		It("should resolve type references using the fallback container", func() {
+			fallback.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+
+			bar := container.MustGet("bar").(*TypeForServiceInjection)
+			Expect(bar.InjectedType).To(BeIdenticalTo(fallback.MustGet("foo")))
+		})
+
+		It("should prefer local types", func() {
+			fallback.RegisterType("foo", NewMockTypeWithArgs, "fallback", false)
+			registry.RegisterType("foo", NewMockTypeWithArgs, "local", false)
+			Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("local"))
+		})
+
+		It("should return an error if the type is not defined in any container", func() {
+			_, err := container.Get("foo")
+			Expect(err).To(BeAssignableToTypeOf(goldi.UnknownTypeReferenceError{}))
+		})
+
+		It("should return an error on fallback cycles", func() {
+			other := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			Expect(other.SetFallback(container)).To(Succeed())
+			Expect(fallback.SetFallback(other)).To(MatchError("goldi: can not set fallback container: this would create a fallback cycle"))
+			Expect(container.SetFallback(container)).To(HaveOccurred())
+		})
+	})
+
+	Describe("UnusedTypes", func() {
+		It("should return all types that have never been requested", func() {
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			registry.RegisterType("baz", NewMockType)
+			registry.RegisterType("qux", NewMockType)
+			Expect(container.UnusedTypes()).To(Equal([]string{"bar", "baz", "foo", "qux"}))
+
+			container.MustGet("bar")
+			Expect(container.UnusedTypes()).To(Equal([]string{"baz", "qux"}))
+		})
+
+		It("should be safe to call while types are requested concurrently", func() {
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewMockType)
+
+			var wg sync.WaitGroup
+			for _, typeID := range []string{"foo", "bar", "foo", "bar"} {
+				wg.Add(2)
+				go func(typeID string) {
+					defer wg.Done()
+					container.MustGet(typeID)
+				}(typeID)
+				go func() {
+					defer wg.Done()
+					container.UnusedTypes()
+				}()
+			}
+			wg.Wait()
+
+			Expect(container.UnusedTypes()).To(BeEmpty())
+		})
+	})
+
+	Describe("GetTimed", func() {
+		It("should return the duration of the generation", func() {
+			registry.Register("slow", goldi.NewType(func() *MockType {
+				time.Sleep(20 * time.Millisecond)
+				return new(MockType)
+			}))
+
+			instance, duration, err := container.GetTimed("slow")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(BeIdenticalTo(container.MustGet("slow")))
+			Expect(duration).To(BeNumerically(">=", 20*time.Millisecond))
+		})
+
+		It("should return almost immediately if the type has been cached", func() {
+			registry.Register("slow", goldi.NewType(func() *MockType {
+				time.Sleep(20 * time.Millisecond)
+				return new(MockType)
+			}))
+			container.MustGet("slow")
+
+			_, duration, err := container.GetTimed("slow")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(duration).To(BeNumerically("<", 20*time.Millisecond))
+		})
+
+		It("should return the error of Get", func() {
+			_, _, err := container.GetTimed("foo")
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+	})
+
+	Describe("GetMatching", func() {
+		BeforeEach(func() {
+			registry.RegisterType("handler.foo", NewMockTypeWithArgs, "foo", true)
+			registry.RegisterType("handler.bar", NewMockTypeWithArgs, "bar", true)
+			registry.RegisterType("handler.baz.qux", NewMockTypeWithArgs, "qux", true)
+			registry.RegisterType("other", NewMockType)
+		})
+
+		It("should return all types whose IDs match the pattern", func() {
+			types, err := container.GetMatching("handler.*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(types).To(HaveLen(3))
+			Expect(types).To(HaveKeyWithValue("handler.foo", BeIdenticalTo(container.MustGet("handler.foo"))))
+			Expect(types).To(HaveKey("handler.bar"))
+			Expect(types).To(HaveKey("handler.baz.qux"))
+		})
+
+		It("should return an empty map if no type matches", func() {
+			Expect(container.GetMatching("nope.*")).To(BeEmpty())
+		})
+
+		It("should report the errors of each type that could not be generated", func() {
+			registry.Register("handler.broken", goldi.NewProxyType("unknown", "DoStuff"))
+
+			types, err := container.GetMatching("handler.*")
+			Expect(err).To(MatchError(HavePrefix(`goldi: could not get all types matching "handler.*": goldi: error while generating type "handler.broken"`)))
+			Expect(types).To(HaveLen(3))
+		})
+
+		It("should return an error if the pattern is invalid", func() {
+			_, err := container.GetMatching("handler.[")
+			Expect(err).To(MatchError(`goldi: invalid pattern "handler.[": syntax error in pattern`))
+		})
+	})
+
+	Describe("WarmUp", func() {
+		It("should generate the given types and their dependencies only", func() {
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			registry.RegisterType("baz", NewMockType)
+			registry.RegisterType("qux", NewTypeForServiceInjection, "@baz")
+
+			Expect(container.WarmUp("bar")).To(Succeed())
+
+			var generated []string
+			container.Each(func(typeID string, _ interface{}) {
+				generated = append(generated, typeID)
+			})
+			Expect(generated).To(Equal([]string{"bar", "foo"}))
+		})
+
+		It("should return the first error including the type that has been warmed up", func() {
+			registry.RegisterType("foo", NewTypeForServiceInjection, "@missing")
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			registry.RegisterType("baz", NewMockType)
+
+			err := container.WarmUp("bar", "baz")
+			Expect(err).To(MatchError(`goldi: could not warm up type "bar": ` +
+				`goldi: error while generating type "bar": ` +
+				`goldi: error while generating type "foo": ` +
+				`the referenced type "@missing" has not been defined`,
+			))
+			Expect(container.UnusedTypes()).To(Equal([]string{"baz"}))
+		})
+	})
+
+	Describe("GetOrRegister", func() {
+		It("should only call the factory builder if the type has not been registered yet", func() {
+			calls := 0
+			builder := func() goldi.TypeFactory {
+				calls++
+				return goldi.NewType(NewMockType)
+			}
+
+			first, err := container.GetOrRegister("foo", builder)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := container.GetOrRegister("foo", builder)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(calls).To(Equal(1))
+			Expect(first).To(BeAssignableToTypeOf(&MockType{}))
+			Expect(second).To(BeIdenticalTo(first))
+		})
+
+		It("should not call the factory builder for types that have already been registered", func() {
+			registry.RegisterType("foo", NewMockType)
+			instance, err := container.GetOrRegister("foo", func() goldi.TypeFactory {
+				Fail("the factory builder should not be called")
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(BeIdenticalTo(container.MustGet("foo")))
+		})
+
+		It("should call the factory builder only once when used concurrently", func() {
+			var calls int32
+			wg := new(sync.WaitGroup)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := container.GetOrRegister("foo", func() goldi.TypeFactory {
+						atomic.AddInt32(&calls, 1)
+						return goldi.NewType(NewMockType)
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+
+			wg.Wait()
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		It("should return the same instance to all concurrent callers", func() {
+			var generated int32
+			instances := make([]interface{}, 8)
+			wg := new(sync.WaitGroup)
+			for i := range instances {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					instance, err := container.GetOrRegister("foo", func() goldi.TypeFactory {
+						return goldi.NewType(func() *MockType {
+							atomic.AddInt32(&generated, 1)
+							time.Sleep(10 * time.Millisecond)
+							return &MockType{}
+						})
+					})
+					Expect(err).NotTo(HaveOccurred())
+					instances[i] = instance
+				}(i)
+			}
+
+			wg.Wait()
+			Expect(atomic.LoadInt32(&generated)).To(Equal(int32(1)))
+			for _, instance := range instances {
+				Expect(instance).To(BeIdenticalTo(instances[0]))
+			}
+		})
+
+		It("should return an error if the factory builder returns nil", func() {
+			_, err := container.GetOrRegister("foo", func() goldi.TypeFactory { return nil })
+			Expect(err).To(MatchError(`goldi: can not register type "foo": the factory builder returned nil`))
+		})
+	})
+
+	Describe("Each", func() {
+		It("should visit all generated types in alphabetical order", func() {
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewMockType)
+			registry.RegisterType("baz", NewMockType)
+			container.MustGet("foo")
+			container.MustGet("bar")
+
+			var visited []string
+			container.Each(func(typeID string, instance interface{}) {
+				visited = append(visited, typeID)
+				Expect(instance).To(BeIdenticalTo(container.MustGet(typeID)))
+			})
+
+			Expect(visited).To(Equal([]string{"bar", "foo"}))
+			Expect(container.UnusedTypes()).To(Equal([]string{"baz"}))
+		})
+	})
+
+	Describe("GetAssignable", func() {
+		BeforeEach(func() {
+			registry.RegisterType("foo", NewMockTypeWithArgs, "foo", true)
+		})
+
+		It("should store the type in the target", func() {
+			var mock *MockType
+			Expect(container.GetAssignable("foo", &mock)).To(Succeed())
+			Expect(mock).To(BeIdenticalTo(container.MustGet("foo")))
+		})
+
+		It("should store the type in interface targets", func() {
+			var stringer interface{ DoStuff() string }
+			Expect(container.GetAssignable("foo", &stringer)).To(Succeed())
+			Expect(stringer.DoStuff()).To(Equal("I did stuff"))
+		})
+
+		It("should return an error if the type is not assignable to the target", func() {
+			var foo *Foo
+			err := container.GetAssignable("foo", &foo)
+			Expect(err).To(MatchError(`goldi: type "foo" (type *goldi_test.MockType) is not assignable to *goldi_test.Foo`))
+			Expect(err).To(BeAssignableToTypeOf(goldi.TypeReferenceError{}))
+			Expect(foo).To(BeNil())
+		})
+
+		It("should return an error if the type has not been defined", func() {
+			var mock *MockType
+			Expect(container.GetAssignable("bar", &mock)).To(BeAssignableToTypeOf(goldi.UnknownTypeReferenceError{}))
+		})
+
+		It("should return an error if the target is no pointer", func() {
+			var mock *MockType
+			Expect(container.GetAssignable("foo", mock)).To(MatchError(`goldi: can not get type "foo": target must be a non-nil pointer but is *goldi_test.MockType`))
+		})
+	})
+
+	Describe("GetParameter", func() {
+		It("should read int parameters", func() {
+			config["retries"] = 3
+			config["timeout"] = "42"
+			config["max"] = 10.0
+
+			var retries, timeout, max int
+			Expect(container.GetParameter("retries", &retries)).To(Succeed())
+			Expect(container.GetParameter("timeout", &timeout)).To(Succeed())
+			Expect(container.GetParameter("max", &max)).To(Succeed())
+			Expect(retries).To(Equal(3))
+			Expect(timeout).To(Equal(42))
+			Expect(max).To(Equal(10))
+		})
+
+		It("should read duration parameters", func() {
+			config["timeout"] = "1m30s"
+
+			var timeout time.Duration
+			Expect(container.GetParameter("timeout", &timeout)).To(Succeed())
+			Expect(timeout).To(Equal(90 * time.Second))
+		})
+
+		It("should return an error if the parameter has not been defined", func() {
+			var timeout time.Duration
+			Expect(container.GetParameter("timeout", &timeout)).To(MatchError(`goldi: can not get parameter "timeout": no such parameter has been defined`))
+		})
+
+		It("should return an error if the parameter can not be converted", func() {
+			config["timeout"] = "forever"
+			config["ratio"] = 0.5
+
+			var timeout time.Duration
+			err := container.GetParameter("timeout", &timeout)
+			Expect(err).To(MatchError(ContainSubstring(`goldi: can not get parameter "timeout": invalid value of parameter "timeout": can not convert "forever" to time.Duration`)))
+
+			var ratio int
+			err = container.GetParameter("ratio", &ratio)
+			Expect(err).To(MatchError(`goldi: can not get parameter "ratio": invalid value of parameter "ratio": can not convert 0.5 (type float64) to int without losing precision`))
+		})
+
+		It("should return an error if the parameter does not fit into the target", func() {
+			config["small"] = 300
+			config["negative"] = -1
+			config["huge"] = 1e20
+
+			var small int8
+			Expect(container.GetParameter("small", &small)).To(MatchError(`goldi: can not get parameter "small": invalid value of parameter "small": can not convert 300 (type int) to int8: value out of range`))
+
+			var unsigned uint
+			Expect(container.GetParameter("negative", &unsigned)).To(MatchError(`goldi: can not get parameter "negative": invalid value of parameter "negative": can not convert -1 (type int) to uint: value out of range`))
+
+			var huge int64
+			Expect(container.GetParameter("huge", &huge)).To(MatchError(`goldi: can not get parameter "huge": invalid value of parameter "huge": can not convert 1e+20 (type float64) to int64: value out of range`))
+
+			var fits uint8
+			config["fits"] = 255.0
+			Expect(container.GetParameter("fits", &fits)).To(Succeed())
+			Expect(fits).To(Equal(uint8(255)))
+		})
+
+		It("should return an error if the target is no pointer", func() {
+			config["retries"] = 3
+			Expect(container.GetParameter("retries", 3)).To(MatchError(`goldi: can not get parameter "retries": target must be a non-nil pointer but is int`))
+		})
+	})
+
+	Describe("registering duplicate types", func() {
+		It("should overwrite the existing type by default", func() {
+			container.RegisterType("foo", NewMockTypeWithArgs, "first", true)
+			container.RegisterType("foo", NewMockTypeWithArgs, "second", true)
+			Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("second"))
+		})
+
+		Context("when RejectDuplicateTypes is enabled", func() {
+			BeforeEach(func() {
+				container.RejectDuplicateTypes = true
+				container.RegisterType("foo", NewMockType)
+			})
+
+			It("should panic if a type is registered twice", func() {
+				expectedErr := fmt.Errorf(`goldi: type "foo" has already been registered`)
+				Expect(func() { container.Register("foo", goldi.NewType(NewFoo)) }).To(PanicWith(expectedErr))
+				Expect(func() { container.RegisterType("foo", NewMockTypeWithArgs, "bar", true) }).To(PanicWith(expectedErr))
+				Expect(func() { container.InjectInstance("foo", new(MockType)) }).To(PanicWith(expectedErr))
+				Expect(func() {
+					container.RegisterAll(map[string]goldi.TypeFactory{"foo": goldi.NewStructType(MockType{})})
+				}).To(PanicWith(expectedErr))
+			})
+
+			It("should allow registering an equal type again", func() {
+				Expect(func() { container.Register("foo", goldi.NewType(NewMockType)) }).NotTo(Panic())
+				Expect(func() { container.RegisterType("foo", NewMockType) }).NotTo(Panic())
+				Expect(container.MustGet("foo")).To(BeAssignableToTypeOf(&MockType{}))
+			})
+
+			It("should still register new types", func() {
+				container.RegisterType("bar", NewMockType)
+				container.InjectInstance("baz", new(MockType))
+				Expect(container.TypeRegistry).To(HaveLen(3))
+			})
+		})
+	})
+
+	Describe("RegisterAllStrict", func() {
+		It("should register all factories if there are no conflicts", func() {
+			Expect(container.RegisterAllStrict(map[string]goldi.TypeFactory{
+				"foo": goldi.NewType(NewMockType),
+				"bar": goldi.NewType(NewFoo),
+			})).To(Succeed())
+			Expect(container.TypeRegistry).To(HaveLen(2))
+		})
+
+		It("should reject type IDs that have already been registered", func() {
+			container.RegisterType("foo", NewMockType)
+			err := container.RegisterAllStrict(map[string]goldi.TypeFactory{"foo": goldi.NewType(NewMockType), "bar": goldi.NewType(NewFoo)})
+			Expect(err).To(MatchError(`goldi: could not register types: type "foo" has already been registered`))
+			Expect(container.TypeRegistry).NotTo(HaveKey("bar"))
+		})
+
+		It("should return an error if the container has been frozen", func() {
+			container.Freeze()
+			err := container.RegisterAllStrict(map[string]goldi.TypeFactory{"foo": goldi.NewType(NewMockType)})
+			Expect(err).To(MatchError(`goldi: can not register types: the container has been frozen`))
+			Expect(container.TypeRegistry).To(BeEmpty())
+		})
+
+		It("should reject factories that return concrete types if StrictInterfaceReturns is enabled", func() {
+			container.StrictInterfaceReturns = true
+			err := container.RegisterAllStrict(map[string]goldi.TypeFactory{
+				"foo":    goldi.NewType(NewMockType),
+				"reader": goldi.NewType(func() io.Reader { return &bytes.Buffer{} }),
+			})
+			Expect(err).To(MatchError(ContainSubstring(`goldi: can not register type "foo": the factory returns the concrete type *goldi_test.MockType`)))
+			Expect(container.TypeRegistry).To(BeEmpty())
+		})
+	})
+
+	Describe("StrictInterfaceReturns", func() {
+		It("should accept factories that return a concrete type by default", func() {
+			Expect(func() { container.RegisterType("foo", NewMockType) }).NotTo(Panic())
+		})
+
+		Context("when StrictInterfaceReturns is enabled", func() {
+			BeforeEach(func() {
+				container.StrictInterfaceReturns = true
+			})
+
+			It("should panic if the factory returns a concrete type", func() {
+				expectedErr := fmt.Errorf(`goldi: can not register type "foo": the factory returns the concrete type *goldi_test.MockType but StrictInterfaceReturns requires an interface type`)
+				Expect(func() { container.RegisterType("foo", NewMockType) }).To(PanicWith(expectedErr))
+				Expect(func() { container.RegisterWithTags("foo", goldi.NewType(NewMockType), "tag") }).To(PanicWith(expectedErr))
+				Expect(container.TypeRegistry).To(BeEmpty())
+			})
+
+			It("should accept factories that return an interface", func() {
+				container.RegisterType("foo", func() io.Reader { return &bytes.Buffer{} })
+				Expect(container.MustGet("foo")).To(BeAssignableToTypeOf(&bytes.Buffer{}))
+			})
+
+			It("should not check types without factory function", func() {
+				Expect(func() { container.Register("foo", goldi.NewStructType(MockType{})) }).NotTo(Panic())
+			})
+
+			It("should return an error from GetOrRegister", func() {
+				_, err := container.GetOrRegister("foo", func() goldi.TypeFactory { return goldi.NewType(NewMockType) })
+				Expect(err).To(MatchError(ContainSubstring("StrictInterfaceReturns requires an interface type")))
+			})
+		})
+	})
+
+	Describe("Override", func() {
+		It("should replace the type factory and the cached instance", func() {
+			registry.RegisterType("foo", NewMockTypeWithArgs, "old", false)
+			Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("old"))
+
+			Expect(container.Override("foo", goldi.NewType(NewMockTypeWithArgs, "new", true))).To(Succeed())
+			Expect(container.MustGet("foo").(*MockType).StringParameter).To(Equal("new"))
+		})
+
+		It("should regenerate all cached dependents using the new factory", func() {
+			registry.RegisterType("foo", NewMockTypeWithArgs, "old", false)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			Expect(container.MustGet("bar").(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("old"))
+
+			Expect(container.Override("foo", goldi.NewType(NewMockTypeWithArgs, "new", true))).To(Succeed())
+			Expect(container.MustGet("bar").(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("new"))
+		})
+
+		It("should be safe to override types while they are retrieved", func() {
+			registry.RegisterType("foo", NewMockType)
+			wg := new(sync.WaitGroup)
+			for i := 0; i < 10; i++ {
+				wg.Add(2)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(container.Override("foo", goldi.NewType(NewMockType))).To(Succeed())
+				}()
+				go func() {
+					defer wg.Done()
+					container.MustGet("foo")
+				}()
+			}
+			wg.Wait()
+		})
+
+		It("should return an error if the type has not been registered", func() {
+			err := container.Override("foo", goldi.NewType(NewMockType))
+			Expect(err).To(MatchError(`can not override type "foo": no such type has been defined`))
+			Expect(err).To(BeAssignableToTypeOf(goldi.UnknownTypeReferenceError{}))
+			Expect(registry).NotTo(HaveKey("foo"))
+		})
+	})
+
+	Describe("Replace", func() {
+		It("should use the replacement instead of the factory", func() {
+			registry.RegisterType("foo", NewMockTypeWithArgs, "%missing_parameter%", false)
+			replacement := &MockType{StringParameter: "replacement"}
+
+			container.Replace("foo", replacement)
+			Expect(container.MustGet("foo")).To(BeIdenticalTo(replacement))
+		})
+
+		It("should regenerate all cached dependents using the replacement", func() {
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			registry.Register("baz", goldi.NewAliasType("bar"))
+			registry.RegisterType("independent", NewMockType)
+			Expect(container.MustGet("baz").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(container.MustGet("foo")))
+			independent := container.MustGet("independent")
+
+			replacement := &MockType{StringParameter: "replacement"}
+			container.Replace("foo", replacement)
+
+			Expect(container.MustGet("bar").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(replacement))
+			Expect(container.MustGet("baz").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(replacement))
+			Expect(container.MustGet("independent")).To(BeIdenticalTo(independent))
+		})
+	})
+
+	Describe("OnError", func() {
+		var (
+			failedTypeIDs []string
+			failures      []error
+		)
+
+		BeforeEach(func() {
+			failedTypeIDs, failures = nil, nil
+			container.OnError = func(typeID string, err error) {
+				failedTypeIDs = append(failedTypeIDs, typeID)
+				failures = append(failures, err)
+			}
+		})
+
+		It("should be called with the type ID and the error if a type can not be generated", func() {
+			registry.RegisterType("foo", NewTypeForServiceInjection, "@missing")
+
+			_, err := container.Get("foo")
+			Expect(err).To(HaveOccurred())
+			Expect(failedTypeIDs).To(Equal([]string{"foo"}))
+			Expect(failures).To(Equal([]error{err}))
+		})
+
+		It("should be called if a type has not been defined", func() {
+			_, err := container.Get("foo")
+			Expect(err).To(MatchError("no such type has been defined"))
+			Expect(failedTypeIDs).To(Equal([]string{"foo"}))
+			Expect(failures).To(Equal([]error{err}))
+		})
+
+		It("should be called once for the requested type if one of its dependencies could not be generated", func() {
+			registry.RegisterType("foo", NewTypeForServiceInjection, "@missing")
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@foo")
+			registry.Register("baz", goldi.NewAliasType("bar"))
+
+			_, err := container.Get("baz")
+			Expect(err).To(HaveOccurred())
+			Expect(failedTypeIDs).To(Equal([]string{"baz"}))
+			Expect(failures).To(Equal([]error{err}))
+		})
+
+		It("should not be called for alternatives of a fallback chain that could not be generated", func() {
+			registry.RegisterType("broken", NewTypeForServiceInjection, "@missing")
+			registry.RegisterType("foo", NewMockType)
+			registry.RegisterType("bar", NewTypeForServiceInjection, "@broken|@foo")
+
+			Expect(container.MustGet("bar")).NotTo(BeNil())
+			Expect(failedTypeIDs).To(BeEmpty())
+		})
+
+		It("should not be called if the type could be generated", func() {
+			registry.RegisterType("foo", NewMockType)
+			Expect(container.MustGet("foo")).NotTo(BeNil())
+			Expect(failedTypeIDs).To(BeEmpty())
+		})
+
+		It("should be optional", func() {
+			container.OnError = nil
+			_, err := container.Get("foo")
+			Expect(err).To(MatchError("no such type has been defined"))
+		})
+	})
 })
@@ -0,0 +1,27 @@
+package goldi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type contextArgument struct{}
+
+// ContextArgument can be used as factory argument to pass the context of Container.GetWithContext to the factory.
+// If the type is retrieved without a context the factory receives context.Background():
+//
+//	container.Register("request_logger", goldi.NewType(NewRequestLogger, goldi.ContextArgument, "@logger"))
+var ContextArgument = contextArgument{}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func (r *ParameterResolver) resolveContext(expectedType reflect.Type) (reflect.Value, error) {
+	if contextType.AssignableTo(expectedType) == false {
+		return reflect.Value{}, fmt.Errorf("the context argument can not be passed as %v", expectedType)
+	}
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(reflect.ValueOf(r.Context()))
+	return result, nil
+}
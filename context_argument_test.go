@@ -0,0 +1,106 @@
+package goldi_test
+
+import (
+	"context"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type contextKey string
+
+type TypeWithContext struct {
+	Ctx context.Context
+}
+
+func NewTypeWithContext(ctx context.Context) *TypeWithContext {
+	return &TypeWithContext{Ctx: ctx}
+}
+
+type contextConfigurator struct {
+	Ctx context.Context
+}
+
+func (c *contextConfigurator) Configure(*MockType) {}
+
+var _ = Describe("Container.GetWithContext", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("with_context", goldi.NewType(NewTypeWithContext, goldi.ContextArgument))
+	})
+
+	It("should pass the context to the factory", func() {
+		ctx := context.WithValue(context.Background(), contextKey("request_id"), "42")
+		t, err := container.GetWithContext(ctx, "with_context")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.(*TypeWithContext).Ctx.Value(contextKey("request_id"))).To(Equal("42"))
+	})
+
+	It("should pass the context to dependencies that are generated on first use", func() {
+		container.Register("dependent", goldi.NewType(func(t *TypeWithContext) *MockType { return &MockType{} }, "@with_context"))
+		container.Register("alias", goldi.NewAliasType("dependent"))
+
+		ctx := context.WithValue(context.Background(), contextKey("request_id"), "42")
+		_, err := container.GetWithContext(ctx, "alias")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(container.MustGet("with_context").(*TypeWithContext).Ctx).To(Equal(ctx))
+	})
+
+	It("should pass the context to configurators", func() {
+		container.Register("configurator", goldi.NewType(func(ctx context.Context) *contextConfigurator {
+			return &contextConfigurator{Ctx: ctx}
+		}, goldi.ContextArgument))
+		container.Register("configured", goldi.NewConfiguredType(goldi.NewType(NewMockType), "configurator", "Configure"))
+
+		ctx := context.WithValue(context.Background(), contextKey("request_id"), "42")
+		_, err := container.GetWithContext(ctx, "configured")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(container.MustGet("configurator").(*contextConfigurator).Ctx).To(Equal(ctx))
+	})
+
+	It("should pass the context to types that are retrieved via an injected ReadOnlyContainer", func() {
+		container.Register("locator", goldi.NewType(func(c goldi.ReadOnlyContainer) *MockType {
+			c.Get("with_context")
+			return &MockType{}
+		}))
+
+		ctx := context.WithValue(context.Background(), contextKey("request_id"), "42")
+		_, err := container.GetWithContext(ctx, "locator")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(container.MustGet("with_context").(*TypeWithContext).Ctx).To(Equal(ctx))
+	})
+
+	It("should use the context of the first call for generated types", func() {
+		first := context.WithValue(context.Background(), contextKey("request_id"), "first")
+		second := context.WithValue(context.Background(), contextKey("request_id"), "second")
+
+		container.GetWithContext(first, "with_context")
+		t, err := container.GetWithContext(second, "with_context")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.(*TypeWithContext).Ctx.Value(contextKey("request_id"))).To(Equal("first"))
+	})
+
+	It("should pass the background context if the type is retrieved without a context", func() {
+		Expect(container.MustGet("with_context").(*TypeWithContext).Ctx).To(Equal(context.Background()))
+	})
+
+	It("should pass the context to types of the fallback container", func() {
+		fallback := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		fallback.Register("fallback_type", goldi.NewType(NewTypeWithContext, goldi.ContextArgument))
+		Expect(container.SetFallback(fallback)).To(Succeed())
+
+		ctx := context.WithValue(context.Background(), contextKey("request_id"), "42")
+		t, err := container.GetWithContext(ctx, "fallback_type")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.(*TypeWithContext).Ctx).To(Equal(ctx))
+	})
+
+	It("should return an error if the context is passed to an incompatible argument", func() {
+		container.Register("invalid", goldi.NewType(NewMockTypeWithArgs, goldi.ContextArgument, true))
+		_, err := container.GetWithContext(context.Background(), "invalid")
+		Expect(err).To(MatchError(ContainSubstring("the context argument can not be passed as string")))
+	})
+})
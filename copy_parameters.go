@@ -0,0 +1,51 @@
+package goldi
+
+// CopyParameters returns a deep copy of all parameters of the container including the default values of parameters
+// that have not been configured (see SetDefaultParameter). Nested maps and slices are copied as well, so the
+// returned map can be modified or passed to other parts of the application without affecting the container.
+// Other values like pointers are copied as they are.
+func (c *Container) CopyParameters() map[string]interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return copyParameterMap(c.parameters())
+}
+
+func copyParameterMap(parameters map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(parameters))
+	for name, value := range parameters {
+		copied[name] = copyParameterValue(value)
+	}
+
+	return copied
+}
+
+// copyParameterValue copies the maps and slices that are used by parsed yaml and JSON parameters.
+func copyParameterValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyParameterMap(v)
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(v))
+		for key, element := range v {
+			copied[key] = copyParameterValue(element)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, element := range v {
+			copied[i] = copyParameterValue(element)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	case map[string]string:
+		copied := make(map[string]string, len(v))
+		for key, element := range v {
+			copied[key] = element
+		}
+		return copied
+	default:
+		return value
+	}
+}
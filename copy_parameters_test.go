@@ -0,0 +1,58 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.CopyParameters", func() {
+	var (
+		config    map[string]interface{}
+		container *goldi.Container
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{
+			"name":  "goldi",
+			"hosts": []interface{}{"a", "b"},
+			"tags":  []string{"x", "y"},
+			"database": map[string]interface{}{
+				"host":    "localhost",
+				"options": map[interface{}]interface{}{"ssl": true},
+			},
+		}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+	})
+
+	It("should return all parameters", func() {
+		container.SetDefaultParameter("port", 8080)
+		container.SetDefaultParameter("name", "default")
+
+		parameters := container.CopyParameters()
+		Expect(parameters).To(HaveLen(5))
+		Expect(parameters).To(HaveKeyWithValue("name", "goldi"))
+		Expect(parameters).To(HaveKeyWithValue("port", 8080))
+		Expect(parameters["database"]).To(Equal(config["database"]))
+	})
+
+	It("should not affect the container if the copy is modified", func() {
+		parameters := container.CopyParameters()
+		parameters["name"] = "changed"
+		parameters["new"] = "value"
+		parameters["hosts"].([]interface{})[0] = "changed"
+		parameters["tags"].([]string)[0] = "changed"
+		database := parameters["database"].(map[string]interface{})
+		database["host"] = "changed"
+		database["options"].(map[interface{}]interface{})["ssl"] = false
+
+		Expect(config).To(HaveKeyWithValue("name", "goldi"))
+		Expect(config).NotTo(HaveKey("new"))
+		Expect(config["hosts"]).To(Equal([]interface{}{"a", "b"}))
+		Expect(config["tags"]).To(Equal([]string{"x", "y"}))
+		Expect(config["database"]).To(Equal(map[string]interface{}{
+			"host":    "localhost",
+			"options": map[interface{}]interface{}{"ssl": true},
+		}))
+	})
+})
@@ -0,0 +1,54 @@
+package goldi
+
+import "fmt"
+
+type declaredDependenciesType struct {
+	embeddedType TypeFactory
+	dependencies []string
+}
+
+// NewDeclaredDependenciesType creates a new TypeFactory that decorates a given TypeFactory with the IDs of the types
+// it is expected to depend on. The declared dependencies do not change how the type is generated but they document
+// the intent of the registration. The validation.DeclaredDependenciesConstraint uses them to detect when the type
+// references of the arguments drift away from the declared dependencies.
+//
+// NewDeclaredDependenciesType will return an invalid type when embeddedType is nil and the embedded type itself
+// if it is invalid.
+func NewDeclaredDependenciesType(embeddedType TypeFactory, dependencies ...string) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new DeclaredDependenciesType with nil as embedded type"))
+	}
+
+	if IsValid(embeddedType) == false {
+		return embeddedType
+	}
+
+	return &declaredDependenciesType{embeddedType: embeddedType, dependencies: dependencies}
+}
+
+// DeclaredDependencies returns the declared dependencies of the given TypeFactory.
+// The second return value is false if neither the TypeFactory nor any type it decorates has been created by
+// NewDeclaredDependenciesType.
+func DeclaredDependencies(t TypeFactory) ([]string, bool) {
+	for _, factory := range decorationChain(t) {
+		if declared, isDeclared := factory.(*declaredDependenciesType); isDeclared {
+			return declared.dependencies, true
+		}
+	}
+
+	return nil, false
+}
+
+func (t *declaredDependenciesType) Arguments() []interface{} {
+	return t.embeddedType.Arguments()
+}
+
+func (t *declaredDependenciesType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
+	return t.embeddedType.Generate(parameterResolver)
+}
+
+// RegisterWithDeps registers the given TypeFactory together with the IDs of the types it is expected to depend on.
+// See NewDeclaredDependenciesType.
+func (r TypeRegistry) RegisterWithDeps(typeID string, typeDef TypeFactory, dependencies ...string) {
+	r.Register(typeID, NewDeclaredDependenciesType(typeDef, dependencies...))
+}
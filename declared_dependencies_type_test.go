@@ -0,0 +1,54 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("declaredDependenciesType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewDeclaredDependenciesType(goldi.NewType(NewMockType), "foo")
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewDeclaredDependenciesType", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewDeclaredDependenciesType(nil, "foo"))).To(BeFalse())
+		})
+
+		It("should return the embedded type if it is invalid", func() {
+			invalid := goldi.NewType(42)
+			Expect(goldi.NewDeclaredDependenciesType(invalid, "foo")).To(BeIdenticalTo(invalid))
+		})
+	})
+
+	Describe("Container.RegisterWithDeps", func() {
+		It("should generate the embedded type and keep the declared dependencies", func() {
+			container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			container.Register("mock", goldi.NewType(NewMockType))
+			container.RegisterWithDeps("service", goldi.NewType(NewTypeForServiceInjection, "@mock"), "mock")
+
+			service := container.MustGet("service").(*TypeForServiceInjection)
+			Expect(service.InjectedType).To(BeIdenticalTo(container.MustGet("mock")))
+
+			dependencies, isDeclared := goldi.DeclaredDependencies(container.TypeRegistry["service"])
+			Expect(isDeclared).To(BeTrue())
+			Expect(dependencies).To(Equal([]string{"mock"}))
+
+			_, isDeclared = goldi.DeclaredDependencies(container.TypeRegistry["mock"])
+			Expect(isDeclared).To(BeFalse())
+		})
+
+		It("should keep the declared dependencies of types that are decorated by other types", func() {
+			typeDef := goldi.NewTaggedType(goldi.NewDeclaredDependenciesType(goldi.NewType(NewTypeForServiceInjection, "@mock"), "mock"), "service")
+
+			dependencies, isDeclared := goldi.DeclaredDependencies(typeDef)
+			Expect(isDeclared).To(BeTrue())
+			Expect(dependencies).To(Equal([]string{"mock"}))
+			Expect(goldi.Tags(typeDef)).To(Equal([]string{"service"}))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package goldi
+
+// A decorator is a TypeFactory that wraps another TypeFactory to add behavior or metadata to it
+// (e.g. NewTaggedType, NewConfiguredType or NewTTLType).
+type decorator interface {
+	embedded() TypeFactory
+}
+
+// decorationChain returns the given type factory followed by all type factories it decorates.
+// The last element is the innermost type factory which is no decorator itself.
+func decorationChain(factory TypeFactory) []TypeFactory {
+	chain := []TypeFactory{factory}
+	for {
+		d, isDecorator := factory.(decorator)
+		if isDecorator == false {
+			return chain
+		}
+
+		factory = d.embedded()
+		chain = append(chain, factory)
+	}
+}
+
+// undecorated returns the innermost type factory that is decorated by the given type factory
+// or the type factory itself if it is no decorator.
+func undecorated(factory TypeFactory) TypeFactory {
+	chain := decorationChain(factory)
+	return chain[len(chain)-1]
+}
+
+func (t *taggedType) embedded() TypeFactory               { return t.embeddedType }
+func (t *declaredDependenciesType) embedded() TypeFactory { return t.embeddedType }
+func (t *configuredType) embedded() TypeFactory           { return t.embeddedType }
+func (t *retryType) embedded() TypeFactory                { return t.embeddedType }
+func (t *ttlType) embedded() TypeFactory                  { return t.embeddedType }
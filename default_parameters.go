@@ -0,0 +1,56 @@
+package goldi
+
+// SetDefaultParameter sets the default value of the parameter with the given name.
+// Defaults are used whenever a parameter has not been set in the container Config, so the configured value
+// always takes precedence over the default. This allows libraries to provide sensible defaults that can be
+// overridden by the application configuration.
+//
+// Like SetParameter this invalidates the parameter cache (see ParameterResolver.CacheParameters).
+func (c *Container) SetDefaultParameter(name string, value interface{}) {
+	c.parametersMutex.Lock()
+	if c.defaults == nil {
+		c.defaults = map[string]interface{}{}
+	}
+	c.defaults[name] = value
+	c.parametersMutex.Unlock()
+
+	c.parametersVersion.Add(1)
+}
+
+// HasParameter returns true if the parameter with the given name has been configured or has a default value.
+func (c *Container) HasParameter(name string) bool {
+	_, isDefined := c.parameter(name)
+	return isDefined
+}
+
+// parameter returns the configured value of the parameter with the given name or its default value if the
+// parameter has not been configured.
+func (c *Container) parameter(name string) (interface{}, bool) {
+	c.parametersMutex.RLock()
+	defer c.parametersMutex.RUnlock()
+
+	if value, isConfigured := c.Config[name]; isConfigured {
+		return value, true
+	}
+
+	value, hasDefault := c.defaults[name]
+	return value, hasDefault
+}
+
+// parameters returns a copy of all parameters including the default values of the parameters that have not been
+// configured.
+func (c *Container) parameters() map[string]interface{} {
+	c.parametersMutex.RLock()
+	defer c.parametersMutex.RUnlock()
+
+	parameters := make(map[string]interface{}, len(c.Config)+len(c.defaults))
+	for name, value := range c.defaults {
+		parameters[name] = value
+	}
+
+	for name, value := range c.Config {
+		parameters[name] = value
+	}
+
+	return parameters
+}
@@ -0,0 +1,96 @@
+package goldi_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.SetDefaultParameter", func() {
+	var (
+		config    map[string]interface{}
+		container *goldi.Container
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+		container.Register("mock", goldi.NewType(NewMockTypeWithArgs, "%name%", "%enabled%"))
+		container.SetDefaultParameter("name", "default name")
+		container.SetDefaultParameter("enabled", true)
+	})
+
+	It("should use the default if the parameter has not been configured", func() {
+		mock := container.MustGet("mock").(*MockType)
+		Expect(mock.StringParameter).To(Equal("default name"))
+		Expect(mock.BoolParameter).To(BeTrue())
+	})
+
+	It("should prefer configured parameters over defaults", func() {
+		config["name"] = "configured name"
+		container.SetParameter("enabled", false)
+
+		mock := container.MustGet("mock").(*MockType)
+		Expect(mock.StringParameter).To(Equal("configured name"))
+		Expect(mock.BoolParameter).To(BeFalse())
+	})
+
+	It("should use defaults in GetParameter", func() {
+		container.SetDefaultParameter("timeout", "5s")
+
+		var timeout time.Duration
+		Expect(container.GetParameter("timeout", &timeout)).To(Succeed())
+		Expect(timeout).To(Equal(5 * time.Second))
+		Expect(container.GetParameter("unknown", &timeout)).To(MatchError(`goldi: can not get parameter "unknown": no such parameter has been defined`))
+	})
+
+	It("should use defaults in template parameters", func() {
+		config["greeting"] = "tmpl:hello {{.name}}"
+
+		var greeting string
+		Expect(container.GetParameter("greeting", &greeting)).To(Succeed())
+		Expect(greeting).To(Equal("hello default name"))
+	})
+
+	It("should invalidate cached parameters", func() {
+		container.Resolver.CacheParameters = true
+		var name string
+		Expect(container.GetParameter("name", &name)).To(Succeed())
+		Expect(name).To(Equal("default name"))
+
+		container.SetDefaultParameter("name", "new default")
+		Expect(container.GetParameter("name", &name)).To(Succeed())
+		Expect(name).To(Equal("new default"))
+	})
+
+	It("should report parameters with defaults via HasParameter", func() {
+		config["configured"] = 42
+		Expect(container.HasParameter("name")).To(BeTrue())
+		Expect(container.HasParameter("configured")).To(BeTrue())
+		Expect(container.HasParameter("unknown")).To(BeFalse())
+	})
+
+	It("should be safe to set defaults while parameters are resolved", func() {
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				container.SetDefaultParameter(fmt.Sprintf("parameter_%d", i), i)
+			}(i)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				var name string
+				Expect(container.GetParameter("name", &name)).To(Succeed())
+			}()
+		}
+
+		wg.Wait()
+		Expect(container.HasParameter("parameter_9")).To(BeTrue())
+	})
+})
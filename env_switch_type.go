@@ -0,0 +1,54 @@
+package goldi
+
+import (
+	"fmt"
+	"os"
+)
+
+type envSwitchType struct {
+	envVar     string
+	value      string
+	typeID     string
+	isSelected bool
+}
+
+// NewEnvSwitchType creates a new TypeFactory which serves as alias to the type that has been selected by the value
+// of an environment variable. The keys of cases are the values of the environment variable and the values are the
+// IDs of the types that should be used. The type with the defaultID is used if the environment variable is not set or
+// if its value has no case. If defaultID is empty no type is selected in this case and generating the type fails.
+//
+// The environment variable is read when the type is created so it must be set before the types are registered.
+// This makes it easy to swap implementations per deployment:
+//     container.Register("mailer", goldi.NewEnvSwitchType("MAILER", map[string]string{
+//         "smtp": "smtp_mailer",
+//         "ses":  "ses_mailer",
+//     }, "log_mailer"))
+func NewEnvSwitchType(envVar string, cases map[string]string, defaultID string) TypeFactory {
+	t := &envSwitchType{envVar: envVar}
+	t.value, _ = os.LookupEnv(envVar)
+	if typeID, isDefined := cases[t.value]; isDefined {
+		t.typeID, t.isSelected = typeID, true
+	} else if defaultID != "" {
+		t.typeID, t.isSelected = defaultID, true
+	}
+
+	return t
+}
+
+// Arguments returns the reference to the type that has been selected by the environment variable.
+func (t *envSwitchType) Arguments() []interface{} {
+	if t.isSelected == false {
+		return []interface{}{}
+	}
+
+	return []interface{}{"@" + t.typeID}
+}
+
+// Generate retrieves the type that has been selected by the environment variable from the container.
+func (t *envSwitchType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	if t.isSelected == false {
+		return nil, fmt.Errorf("no type has been defined for the value %q of the environment variable %s", t.value, t.envVar)
+	}
+
+	return resolver.get(t.typeID)
+}
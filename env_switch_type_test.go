@@ -0,0 +1,84 @@
+package goldi_test
+
+import (
+	"os"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("envSwitchType", func() {
+	const envVar = "GOLDI_TEST_MAILER"
+
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+		cases     map[string]string
+	)
+
+	setEnv := func(value string) {
+		Expect(os.Setenv(envVar, value)).To(Succeed())
+		DeferCleanup(os.Unsetenv, envVar)
+	}
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+		container.Register("smtp_mailer", goldi.NewStructType(Foo{}, "smtp"))
+		container.Register("ses_mailer", goldi.NewStructType(Foo{}, "ses"))
+		container.Register("log_mailer", goldi.NewStructType(Foo{}, "log"))
+
+		cases = map[string]string{
+			"smtp": "smtp_mailer",
+			"ses":  "ses_mailer",
+		}
+	})
+
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewEnvSwitchType(envVar, cases, "log_mailer")
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	It("should select the type of the value of the environment variable", func() {
+		setEnv("smtp")
+		generated, err := goldi.NewEnvSwitchType(envVar, cases, "log_mailer").Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("smtp"))
+
+		setEnv("ses")
+		generated, err = goldi.NewEnvSwitchType(envVar, cases, "log_mailer").Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("ses"))
+	})
+
+	It("should use the default type if the environment variable is not set", func() {
+		Expect(os.Unsetenv(envVar)).To(Succeed())
+		generated, err := goldi.NewEnvSwitchType(envVar, cases, "log_mailer").Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("log"))
+	})
+
+	It("should use the default type if the value has no case", func() {
+		setEnv("sendmail")
+		generated, err := goldi.NewEnvSwitchType(envVar, cases, "log_mailer").Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("log"))
+	})
+
+	It("should return the reference to the selected type as argument", func() {
+		setEnv("ses")
+		Expect(goldi.NewEnvSwitchType(envVar, cases, "log_mailer").Arguments()).To(Equal([]interface{}{"@ses_mailer"}))
+	})
+
+	It("should return an error if no type has been selected", func() {
+		setEnv("sendmail")
+		t := goldi.NewEnvSwitchType(envVar, cases, "")
+		Expect(t.Arguments()).To(BeEmpty())
+
+		_, err := t.Generate(resolver)
+		Expect(err).To(MatchError(`no type has been defined for the value "sendmail" of the environment variable GOLDI_TEST_MAILER`))
+	})
+})
@@ -7,6 +7,14 @@ type TypeReferenceError struct {
 	error
 	TypeID       string
 	TypeInstance interface{}
+
+	// ConsumerTypeID is the ID of the type whose generation failed because of the reference to TypeID.
+	// It is empty if the error did not occur while the container generated a registered type.
+	ConsumerTypeID string
+
+	// ArgumentIndex is the zero based index of the factory argument (or struct field) of the consumer that
+	// references TypeID. It is -1 if the error is not related to a specific argument.
+	ArgumentIndex int
 }
 
 // The UnknownTypeReferenceError occurs if you try to get a type by an unknown type id (type has not been registered).
@@ -15,15 +23,37 @@ type UnknownTypeReferenceError struct {
 	TypeID string
 }
 
+// An InvalidArgumentError occurs if a factory argument resolves to no value at all (e.g. an untyped nil)
+// so it can not be passed to the factory.
+type InvalidArgumentError struct {
+	error
+
+	// ArgumentIndex is the zero based index of the invalid factory argument.
+	// Variadic arguments continue the count of the regular arguments.
+	ArgumentIndex int
+}
+
 // newTypeReferenceError creates a new TypeReferenceError
 func newTypeReferenceError(typeID string, typeInstance interface{}, message string, printfParameters ...interface{}) TypeReferenceError {
 	return TypeReferenceError{
-		error:        fmt.Errorf(message, printfParameters...),
-		TypeID:       typeID,
-		TypeInstance: typeInstance,
+		error:         fmt.Errorf(message, printfParameters...),
+		TypeID:        typeID,
+		TypeInstance:  typeInstance,
+		ArgumentIndex: -1,
 	}
 }
 
+// withConsumer adds the ID of the type that could not be generated to the given error if it is a TypeReferenceError.
+func withConsumer(err error, consumerTypeID string) error {
+	referenceErr, isReferenceErr := err.(TypeReferenceError)
+	if isReferenceErr == false || referenceErr.ConsumerTypeID != "" {
+		return err
+	}
+
+	referenceErr.ConsumerTypeID = consumerTypeID
+	return referenceErr
+}
+
 // newUnknownTypeReferenceError creates a new UnknownTypeReferenceError
 func newUnknownTypeReferenceError(typeID, message string, printfParameters ...interface{}) UnknownTypeReferenceError {
 	return UnknownTypeReferenceError{
@@ -31,3 +61,11 @@ func newUnknownTypeReferenceError(typeID, message string, printfParameters ...in
 		TypeID: typeID,
 	}
 }
+
+// newInvalidArgumentError creates a new InvalidArgumentError
+func newInvalidArgumentError(argumentIndex int, message string, printfParameters ...interface{}) InvalidArgumentError {
+	return InvalidArgumentError{
+		error:         fmt.Errorf(message, printfParameters...),
+		ArgumentIndex: argumentIndex,
+	}
+}
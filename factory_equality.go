@@ -0,0 +1,140 @@
+package goldi
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// FactoriesEqual returns true if both type factories would generate the same type in the same way.
+// Two factories are equal if they are of the same kind, use the same factory function (or struct type, instance,
+// referenced type etc.) and have equal arguments. Functions are only equal if they are the same top-level function
+// or method expression and inline type factories are compared recursively using FactoriesEqual. Instances of
+// NewInstanceType must be identical.
+//
+// This can be used to distinguish identical re-registrations of a type (e.g. because two modules register the same
+// shared dependency) from conflicting registrations. Note that closures and method values are never considered
+// equal since they may capture different state even if they share the same code.
+func FactoriesEqual(a, b TypeFactory) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	switch x := a.(type) {
+	case *typeFactory:
+		y := b.(*typeFactory)
+		return funcsEqual(x.factory, y.factory) && argumentsEqual(x.Arguments(), y.Arguments())
+	case *singletonFuncType:
+		return FactoriesEqual(x.function, b.(*singletonFuncType).function)
+	case *structType:
+		y := b.(*structType)
+		return x.structType == y.structType && argumentsEqual(x.Arguments(), y.Arguments())
+	case *instanceType:
+		y := b.(*instanceType)
+		if x.Instance != nil && reflect.TypeOf(x.Instance).Comparable() && reflect.TypeOf(x.Instance) == reflect.TypeOf(y.Instance) {
+			// pointers must reference the same instance
+			return x.Instance == y.Instance
+		}
+		return argumentEqual(x.Instance, y.Instance)
+	case *funcType:
+		return argumentEqual(x.function, b.(*funcType).function)
+	case *funcReferenceType:
+		y := b.(*funcReferenceType)
+		return x.typeID.String() == y.typeID.String() && x.lateBound == y.lateBound
+	case *proxyType:
+		y := b.(*proxyType)
+		return x.typeID.String() == y.typeID.String() && argumentsEqual(x.args, y.args)
+	case *aliasType:
+		return x.typeID == b.(*aliasType).typeID
+	case *platformSwitchType:
+		return *x == *b.(*platformSwitchType)
+	case *envSwitchType:
+		return *x == *b.(*envSwitchType)
+	case decorator:
+		return decorationsEqual(a, b) && FactoriesEqual(x.embedded(), b.(decorator).embedded())
+	case *conditionalType:
+		y := b.(*conditionalType)
+		return argumentEqual(x.predicate, y.predicate) && FactoriesEqual(x.ifTrue, y.ifTrue) && FactoriesEqual(x.ifFalse, y.ifFalse)
+	case *multiTypeOutput:
+		y := b.(*multiTypeOutput)
+		return x.fieldName == y.fieldName && FactoriesEqual(x.factory, y.factory)
+	case *invalidType:
+		return x.Error() == b.(*invalidType).Error()
+	default:
+		return a == b
+	}
+}
+
+// decorationsEqual compares the options of two decorators of the same kind without their embedded types.
+func decorationsEqual(a, b TypeFactory) bool {
+	switch x := a.(type) {
+	case *configuredType:
+		return *x.TypeConfigurator == *b.(*configuredType).TypeConfigurator
+	case *retryType:
+		y := b.(*retryType)
+		return x.attempts == y.attempts && x.backoff == y.backoff
+	case *ttlType:
+		return x.ttl == b.(*ttlType).ttl
+	case *taggedType:
+		return reflect.DeepEqual(x.tags, b.(*taggedType).tags)
+	case *declaredDependenciesType:
+		return reflect.DeepEqual(x.dependencies, b.(*declaredDependenciesType).dependencies)
+	default:
+		return false
+	}
+}
+
+func argumentsEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if argumentEqual(a[i], b[i]) == false {
+			return false
+		}
+	}
+
+	return true
+}
+
+// argumentEqual compares two factory arguments. Functions are compared using funcsEqual since
+// reflect.DeepEqual considers all non-nil functions to be different.
+func argumentEqual(a, b interface{}) bool {
+	if factory, isFactory := a.(TypeFactory); isFactory {
+		other, isFactory := b.(TypeFactory)
+		return isFactory && FactoriesEqual(factory, other)
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Func && vb.Kind() == reflect.Func {
+		return funcsEqual(va, vb)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// closureName matches the names of anonymous functions like "pkg.NewServer.func1" or "pkg.init.func2.1".
+var closureName = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+// funcsEqual returns true if both functions are provably equal. This is only the case for the same top-level
+// function or method expression since closures and method values (e.g. "service.Handle") share their code pointer
+// with all other closures of the same literal or method values of other receivers.
+func funcsEqual(a, b reflect.Value) bool {
+	if a.Type() != b.Type() || a.IsNil() || b.IsNil() || a.Pointer() != b.Pointer() {
+		return false
+	}
+
+	f := runtime.FuncForPC(a.Pointer())
+	if f == nil {
+		return false
+	}
+
+	name := f.Name()
+	return strings.HasSuffix(name, "-fm") == false && closureName.MatchString(name) == false
+}
@@ -0,0 +1,76 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FactoriesEqual", func() {
+	It("should return true for identical factories", func() {
+		Expect(goldi.FactoriesEqual(goldi.NewType(NewMockType), goldi.NewType(NewMockType))).To(BeTrue())
+		Expect(goldi.FactoriesEqual(
+			goldi.NewType(NewMockTypeWithArgs, "%foo%", true),
+			goldi.NewType(NewMockTypeWithArgs, "%foo%", true),
+		)).To(BeTrue())
+		Expect(goldi.FactoriesEqual(goldi.NewStructType(MockType{}, "hello"), goldi.NewStructType(MockType{}, "hello"))).To(BeTrue())
+		Expect(goldi.FactoriesEqual(goldi.NewAliasType("foo"), goldi.NewAliasType("foo"))).To(BeTrue())
+		Expect(goldi.FactoriesEqual(goldi.NewProxyType("foo", "DoStuff"), goldi.NewProxyType("foo", "DoStuff"))).To(BeTrue())
+		Expect(goldi.FactoriesEqual(goldi.NewFuncType(SomeFunctionForFuncTypeTest), goldi.NewFuncType(SomeFunctionForFuncTypeTest))).To(BeTrue())
+	})
+
+	It("should compare decorated and inline type factories recursively", func() {
+		Expect(goldi.FactoriesEqual(
+			goldi.NewTaggedType(goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewMockType)), "foo"),
+			goldi.NewTaggedType(goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewMockType)), "foo"),
+		)).To(BeTrue())
+
+		Expect(goldi.FactoriesEqual(
+			goldi.NewTaggedType(goldi.NewType(NewMockType), "foo"),
+			goldi.NewTaggedType(goldi.NewType(NewMockType), "bar"),
+		)).To(BeFalse())
+
+		Expect(goldi.FactoriesEqual(
+			goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewMockType)),
+			goldi.NewType(NewTypeForServiceInjection, goldi.NewStructType(MockType{})),
+		)).To(BeFalse())
+	})
+
+	It("should return false for factories that differ", func() {
+		Expect(goldi.FactoriesEqual(goldi.NewType(NewMockType), goldi.NewType(NewFoo))).To(BeFalse())
+		Expect(goldi.FactoriesEqual(
+			goldi.NewType(NewMockTypeWithArgs, "foo", true),
+			goldi.NewType(NewMockTypeWithArgs, "foo", false),
+		)).To(BeFalse())
+		Expect(goldi.FactoriesEqual(goldi.NewType(NewMockType), goldi.NewStructType(MockType{}))).To(BeFalse())
+		Expect(goldi.FactoriesEqual(goldi.NewAliasType("foo"), goldi.NewAliasType("bar"))).To(BeFalse())
+		Expect(goldi.FactoriesEqual(goldi.NewType(NewMockType), nil)).To(BeFalse())
+	})
+
+	It("should only consider identical instances to be equal", func() {
+		instance := &MockType{}
+		Expect(goldi.FactoriesEqual(goldi.NewInstanceType(instance), goldi.NewInstanceType(instance))).To(BeTrue())
+		Expect(goldi.FactoriesEqual(goldi.NewInstanceType(instance), goldi.NewInstanceType(&MockType{}))).To(BeFalse())
+	})
+
+	It("should not consider closures or method values to be equal", func() {
+		newClosure := func(name string) func() *MockType {
+			return func() *MockType { return &MockType{StringParameter: name} }
+		}
+		Expect(goldi.FactoriesEqual(goldi.NewType(newClosure("foo")), goldi.NewType(newClosure("bar")))).To(BeFalse())
+
+		closure := newClosure("foo")
+		Expect(goldi.FactoriesEqual(goldi.NewType(closure), goldi.NewType(closure))).To(BeFalse())
+
+		first, second := &MockTypeFactory{}, &MockTypeFactory{}
+		Expect(goldi.FactoriesEqual(goldi.NewType(first.NewMockType), goldi.NewType(second.NewMockType))).To(BeFalse())
+		Expect(goldi.FactoriesEqual(goldi.NewFuncType(first.NewMockType), goldi.NewFuncType(second.NewMockType))).To(BeFalse())
+	})
+
+	It("should consider method expressions to be equal", func() {
+		Expect(goldi.FactoriesEqual(
+			goldi.NewType((*MockTypeFactory).NewMockType, "@factory"),
+			goldi.NewType((*MockTypeFactory).NewMockType, "@factory"),
+		)).To(BeTrue())
+	})
+})
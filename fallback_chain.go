@@ -0,0 +1,53 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FallbackAlternatives returns the alternatives of a fallback chain like "@primary|@secondary|%default%" or nil
+// if the given string is no fallback chain. All alternatives except for the last one must be type references or
+// parameters. The last alternative may also be a literal string that is used if no other alternative is available.
+func FallbackAlternatives(s string) []string {
+	if strings.Contains(s, "|") == false {
+		return nil
+	}
+
+	alternatives := strings.Split(s, "|")
+	for i, alternative := range alternatives {
+		if alternative == "" {
+			return nil
+		}
+
+		if i < len(alternatives)-1 && IsParameterOrTypeReference(alternative) == false {
+			return nil
+		}
+	}
+
+	return alternatives
+}
+
+// resolveFallbackChain resolves the first alternative of the given fallback chain that is available.
+// Type references are unavailable if they are not defined or can not be generated and parameters are unavailable
+// if they have not been configured.
+func (r *ParameterResolver) resolveFallbackChain(chain string, alternatives []string, expectedType reflect.Type) (reflect.Value, ResolutionKind, error) {
+	errs := NewMultiError(fmt.Sprintf("none of the alternatives of %q could be resolved", chain))
+	for _, alternative := range alternatives {
+		if IsParameter(alternative) {
+			if _, isConfigured := r.Container.parameter(alternative[1 : len(alternative)-1]); isConfigured == false {
+				errs.Add(fmt.Errorf("the parameter %q has not been defined", alternative))
+				continue
+			}
+		}
+
+		result, kind, err := r.resolve(reflect.ValueOf(alternative), expectedType)
+		if err == nil {
+			return result, kind, nil
+		}
+
+		errs.Add(err)
+	}
+
+	return reflect.Value{}, ReferenceResolution, errs
+}
@@ -0,0 +1,84 @@
+package goldi_test
+
+import (
+	"reflect"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FallbackAlternatives", func() {
+	It("should return the alternatives of a fallback chain", func() {
+		Expect(goldi.FallbackAlternatives("@primary|@?secondary::Do|%default%")).To(Equal([]string{"@primary", "@?secondary::Do", "%default%"}))
+		Expect(goldi.FallbackAlternatives("%param%|fallback value")).To(Equal([]string{"%param%", "fallback value"}))
+	})
+
+	It("should return nil if the string is no fallback chain", func() {
+		Expect(goldi.FallbackAlternatives("@primary")).To(BeNil())
+		Expect(goldi.FallbackAlternatives("foo|@bar")).To(BeNil())
+		Expect(goldi.FallbackAlternatives("@foo|bar|@baz")).To(BeNil())
+		Expect(goldi.FallbackAlternatives("@foo|")).To(BeNil())
+	})
+})
+
+var _ = Describe("ParameterResolver with fallback chains", func() {
+	var (
+		container    *goldi.Container
+		resolver     *goldi.ParameterResolver
+		expectedType = reflect.TypeOf(&MockType{})
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+		container.Register("secondary", goldi.NewStructType(MockType{}, "secondary"))
+	})
+
+	It("should resolve the first alternative if it is available", func() {
+		container.Register("primary", goldi.NewStructType(MockType{}, "primary"))
+
+		result, err := resolver.Resolve(reflect.ValueOf("@primary|@secondary"), expectedType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("primary")))
+	})
+
+	It("should resolve the next alternative if the primary type has not been defined", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("@primary|@secondary"), expectedType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("secondary")))
+	})
+
+	It("should resolve the next alternative if the primary type can not be generated", func() {
+		container.Register("primary", goldi.NewType(NewTypeForServiceInjection, "@undefined"))
+
+		result, err := resolver.Resolve(reflect.ValueOf("@primary|@secondary"), expectedType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("secondary")))
+	})
+
+	It("should skip parameters that have not been configured", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("%name%|@primary|%default_name%|anonymous"), reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal("anonymous"))
+
+		container.Config["default_name"] = "John"
+		result, err = resolver.Resolve(reflect.ValueOf("%name%|@primary|%default_name%|anonymous"), reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal("John"))
+	})
+
+	It("should return an error if no alternative can be resolved", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@primary|@tertiary|%default%"), expectedType)
+		Expect(err).To(MatchError(`none of the alternatives of "@primary|@tertiary|%default%" could be resolved: ` +
+			`the referenced type "@primary" has not been defined; ` +
+			`the referenced type "@tertiary" has not been defined; ` +
+			`the parameter "%default%" has not been defined`,
+		))
+	})
+
+	It("should be used when types are generated by the container", func() {
+		container.Register("service", goldi.NewType(NewTypeForServiceInjection, "@primary|@secondary"))
+		Expect(container.MustGet("service").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(container.MustGet("secondary")))
+	})
+})
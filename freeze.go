@@ -0,0 +1,29 @@
+package goldi
+
+import "fmt"
+
+// Freeze makes the type registry of the container immutable. This can be used to enforce that all types are
+// registered while the application is set up and that the wiring does not change while it is running.
+//
+// After the container has been frozen Register, RegisterAll, RegisterType, RegisterWithTags, RegisterWithDeps,
+// InjectInstance and Replace panic while Override and GetOrRegister return an error instead of modifying the registry.
+// Types can still be retrieved and generated as usual. Note that the TypeRegistry that is embedded in the container
+// is a plain map, so types which are registered directly on it (e.g. via container.TypeRegistry.Register) are not
+// rejected. A frozen container can not be unfrozen.
+func (c *Container) Freeze() {
+	c.frozen.Store(true)
+}
+
+// IsFrozen returns true if Freeze has been called on the container.
+func (c *Container) IsFrozen() bool {
+	return c.frozen.Load()
+}
+
+// checkNotFrozen returns an error if the container has been frozen.
+func (c *Container) checkNotFrozen(action, typeID string) error {
+	if c.IsFrozen() {
+		return fmt.Errorf("goldi: can not %s type %q: the container has been frozen", action, typeID)
+	}
+
+	return nil
+}
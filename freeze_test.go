@@ -0,0 +1,63 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Freeze", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("mock", goldi.NewType(NewMockType))
+		container.Register("service", goldi.NewType(NewTypeForServiceInjection, "@mock"))
+	})
+
+	It("should report whether the container has been frozen", func() {
+		Expect(container.IsFrozen()).To(BeFalse())
+		container.Freeze()
+		Expect(container.IsFrozen()).To(BeTrue())
+	})
+
+	Context("when the container has been frozen", func() {
+		BeforeEach(func() {
+			container.Freeze()
+		})
+
+		It("should still generate and retrieve types", func() {
+			service := container.MustGet("service").(*TypeForServiceInjection)
+			Expect(service.InjectedType).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+
+		It("should panic when new types are registered", func() {
+			Expect(func() { container.Register("foo", goldi.NewType(NewMockType)) }).To(
+				PanicWith(MatchError(`goldi: can not register type "foo": the container has been frozen`)),
+			)
+			Expect(func() { container.InjectInstance("bar", &MockType{}) }).To(Panic())
+			Expect(container.TypeRegistry).NotTo(HaveKey("foo"))
+			Expect(container.TypeRegistry).NotTo(HaveKey("bar"))
+		})
+
+		It("should return an error when types are overridden", func() {
+			err := container.Override("mock", goldi.NewInstanceType(&MockType{}))
+			Expect(err).To(MatchError(`goldi: can not override type "mock": the container has been frozen`))
+		})
+
+		It("should panic when types are replaced", func() {
+			Expect(func() { container.Replace("mock", &MockType{}) }).To(
+				PanicWith(MatchError(`goldi: can not replace type "mock": the container has been frozen`)),
+			)
+		})
+
+		It("should return an error if GetOrRegister would register a new type", func() {
+			_, err := container.GetOrRegister("foo", func() goldi.TypeFactory { return goldi.NewType(NewMockType) })
+			Expect(err).To(MatchError(`goldi: can not register type "foo": the container has been frozen`))
+
+			instance, err := container.GetOrRegister("mock", func() goldi.TypeFactory { return nil })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(BeIdenticalTo(container.MustGet("mock")))
+		})
+	})
+})
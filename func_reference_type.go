@@ -7,7 +7,8 @@ import (
 )
 
 type funcReferenceType struct {
-	typeID *TypeID
+	typeID    *TypeID
+	lateBound bool
 }
 
 // NewFuncReferenceType returns a TypeFactory that returns a method of another type as method value (function).
@@ -20,7 +21,24 @@ func NewFuncReferenceType(typeID, functionName string) TypeFactory {
 		return newInvalidType(fmt.Errorf("can not use unexported method %q as second argument to NewFuncReferenceType", functionName))
 	}
 
-	return &funcReferenceType{NewTypeID("@" + typeID + "::" + functionName)}
+	return &funcReferenceType{typeID: NewTypeID("@" + typeID + "::" + functionName)}
+}
+
+// NewLateBoundFuncReferenceType works like NewFuncReferenceType but the returned function retrieves the referenced
+// type from the container each time it is called instead of binding the method when the function is generated.
+// This way the function always calls the method of the latest instance, e.g. after the type has been replaced via
+// Container.Replace.
+//
+// Late binding comes at a cost: each call goes through the container and reflection which is considerably slower
+// than calling a bound method value. Since the function can not return an error that is not part of its signature,
+// it panics if the referenced type can not be retrieved at call time or if its method has a different signature.
+func NewLateBoundFuncReferenceType(typeID, functionName string) TypeFactory {
+	t := NewFuncReferenceType(typeID, functionName)
+	if f, isFuncReference := t.(*funcReferenceType); isFuncReference {
+		f.lateBound = true
+	}
+
+	return t
 }
 
 func (t *funcReferenceType) Arguments() []interface{} {
@@ -28,7 +46,7 @@ func (t *funcReferenceType) Arguments() []interface{} {
 }
 
 func (t *funcReferenceType) Generate(resolver *ParameterResolver) (interface{}, error) {
-	referencedType, err := resolver.Container.Get(t.typeID.ID)
+	referencedType, err := resolver.get(t.typeID.ID)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate func reference type %s : %s", t.typeID, err)
 	}
@@ -40,5 +58,30 @@ func (t *funcReferenceType) Generate(resolver *ParameterResolver) (interface{},
 		return nil, fmt.Errorf("could not generate func reference type %s : method does not exist", t.typeID)
 	}
 
+	if t.lateBound {
+		return t.lateBoundMethod(resolver.Container, method.Type()).Interface(), nil
+	}
+
 	return method.Interface(), nil
 }
+
+// lateBoundMethod returns a function of the given type that retrieves the referenced type and calls its method on each call.
+func (t *funcReferenceType) lateBoundMethod(container *Container, methodType reflect.Type) reflect.Value {
+	return reflect.MakeFunc(methodType, func(args []reflect.Value) []reflect.Value {
+		referencedType, err := container.Get(t.typeID.ID)
+		if err != nil {
+			panic(fmt.Errorf("goldi: could not call late bound func reference %s: %s", t.typeID, err))
+		}
+
+		method := reflect.ValueOf(referencedType).MethodByName(t.typeID.FuncReferenceMethod)
+		if method.IsValid() == false || method.Type() != methodType {
+			panic(fmt.Errorf("goldi: could not call late bound func reference %s: the method does not exist or its signature has changed", t.typeID))
+		}
+
+		if methodType.IsVariadic() {
+			return method.CallSlice(args)
+		}
+
+		return method.Call(args)
+	})
+}
@@ -85,4 +85,38 @@ var _ = Describe("funcReferenceType", func() {
 			Expect(err).To(MatchError("could not generate func reference type @foo::ThisMethodDoesNotExist : method does not exist"))
 		})
 	})
+
+	Describe("NewLateBoundFuncReferenceType()", func() {
+		var container *goldi.Container
+
+		BeforeEach(func() {
+			container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			container.Register("foo", goldi.NewInstanceType(&Foo{Value: "first"}))
+		})
+
+		It("should call the method of the latest instance of the referenced type", func() {
+			container.Register("late_bound", goldi.NewLateBoundFuncReferenceType("foo", "ReturnString"))
+			container.Register("early_bound", goldi.NewFuncReferenceType("foo", "ReturnString"))
+			lateBound := container.MustGet("late_bound").(func(string) string)
+			earlyBound := container.MustGet("early_bound").(func(string) string)
+			Expect(lateBound("call")).To(Equal("first call"))
+
+			container.Replace("foo", &Foo{Value: "second"})
+			Expect(lateBound("call")).To(Equal("second call"))
+			Expect(earlyBound("call")).To(Equal("first call"))
+		})
+
+		It("should return an invalid type if the method name is not exported", func() {
+			t := goldi.NewLateBoundFuncReferenceType("foo", "doStuff")
+			Expect(goldi.IsValid(t)).To(BeFalse())
+		})
+
+		It("should panic if the method does not exist anymore when the function is called", func() {
+			container.Register("late_bound", goldi.NewLateBoundFuncReferenceType("foo", "ReturnString"))
+			f := container.MustGet("late_bound").(func(string) string)
+
+			container.Replace("foo", &Bar{})
+			Expect(func() { f("call") }).To(PanicWith(MatchError("goldi: could not call late bound func reference @foo::ReturnString: the method does not exist or its signature has changed")))
+		})
+	})
 })
@@ -0,0 +1,55 @@
+package main
+
+// An ArgumentMatcher decides whether an ArgumentRenderer is responsible for an argument of a type definition.
+// The argument is passed as it has been parsed from yaml (e.g. a string or a map[interface{}]interface{}).
+type ArgumentMatcher func(argument interface{}) bool
+
+// An ArgumentRenderer returns the go code of an argument of a type definition together with the import paths
+// of all packages that are used by this code.
+type ArgumentRenderer func(argument interface{}) (code string, packages []string)
+
+type argumentRenderer struct {
+	matcher  ArgumentMatcher
+	renderer ArgumentRenderer
+}
+
+var argumentRenderers []*argumentRenderer
+
+// RegisterArgumentRenderer registers a custom renderer for all arguments that are matched by the given matcher.
+// This can be used to render complex literals like regular expressions that can not be expressed in yaml directly:
+//     main.RegisterArgumentRenderer(
+//         func(arg interface{}) bool { m, ok := arg.(map[interface{}]interface{}); return ok && m["regexp"] != nil },
+//         func(arg interface{}) (string, []string) {
+//             pattern := arg.(map[interface{}]interface{})["regexp"]
+//             return fmt.Sprintf("regexp.MustCompile(%q)", pattern), []string{"regexp"}
+//         },
+//     )
+//
+// Custom renderers take precedence over the built-in argument syntax and are consulted in the order in which
+// they have been registered. The returned function removes the renderer again.
+// RegisterArgumentRenderer is not safe for concurrent use so renderers should be registered before generating code.
+func RegisterArgumentRenderer(matcher ArgumentMatcher, renderer ArgumentRenderer) (unregister func()) {
+	r := &argumentRenderer{matcher, renderer}
+	argumentRenderers = append(argumentRenderers, r)
+
+	return func() {
+		for i, registered := range argumentRenderers {
+			if registered == r {
+				argumentRenderers = append(argumentRenderers[:i:i], argumentRenderers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// renderArgument renders the given argument using the first registered renderer that matches it.
+func renderArgument(arg interface{}) (code string, packages []string, isRendered bool) {
+	for _, r := range argumentRenderers {
+		if r.matcher(arg) {
+			code, packages = r.renderer(arg)
+			return code, packages, true
+		}
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// BuilderName returns the name of the generated builder type which is derived from the configured function name
+// by removing the "Register" prefix (e.g. "RegisterTypes" results in "TypesBuilder").
+func (c Config) BuilderName() string {
+	return strings.TrimPrefix(c.FunctionName, "Register") + "Builder"
+}
+
+// BuilderMethodName returns the name of the builder method that overrides the type with the given ID.
+// The type ID is split at all characters that are neither letters nor digits and the parts are joined in
+// camel case (e.g. "http.client_pool" results in "WithHttpClientPool").
+func BuilderMethodName(typeID string) string {
+	parts := strings.FieldsFunc(typeID, func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsDigit(r) == false
+	})
+
+	name := "With"
+	for _, part := range parts {
+		runes := []rune(part)
+		name += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+
+	return name
+}
+
+// builderMethodNames returns the builder method name for each type and an error if several types would result
+// in the same method name.
+func builderMethodNames(typeIDs []string) (map[string]string, error) {
+	names := map[string]string{}
+	typeIDsByName := map[string]string{}
+	for _, typeID := range typeIDs {
+		name := BuilderMethodName(typeID)
+		if name == "With" {
+			return nil, fmt.Errorf("can not generate a builder method for type %q", typeID)
+		}
+
+		if otherTypeID, exists := typeIDsByName[name]; exists {
+			return nil, fmt.Errorf("can not generate the builder method %s because it would be used for the types %q and %q", name, otherTypeID, typeID)
+		}
+
+		names[typeID] = name
+		typeIDsByName[name] = typeID
+	}
+
+	return names, nil
+}
+
+func (g *Generator) generateBuilder(conf *TypesConfiguration, methodNames map[string]string, output io.Writer) {
+	builderName := g.Config.BuilderName()
+	g.logVerbose("Generating builder %s", builderName)
+
+	fmt.Fprintf(output, "\n// %s can be used to create a container with all types that have been defined in the file %q.\n", builderName, g.Config.InputName())
+	fmt.Fprintf(output, "// Each type can be overridden by the With method of the type before the container is built.\n")
+	fmt.Fprintf(output, "type %s struct {\n", builderName)
+	fmt.Fprint(output, "\toverrides map[string]goldi.TypeFactory\n")
+	fmt.Fprint(output, "}\n\n")
+
+	fmt.Fprintf(output, "// New%s creates a new %s.\n", builderName, builderName)
+	fmt.Fprintf(output, "func New%s() *%s {\n", builderName, builderName)
+	fmt.Fprintf(output, "\treturn &%s{overrides: map[string]goldi.TypeFactory{}}\n", builderName)
+	fmt.Fprint(output, "}\n")
+
+	for _, typeID := range conf.TypeIDs() {
+		fmt.Fprintf(output, "\n// %s overrides the type %q with the given type factory.\n", methodNames[typeID], typeID)
+		fmt.Fprintf(output, "func (b *%s) %s(factory goldi.TypeFactory) *%s {\n", builderName, methodNames[typeID], builderName)
+		fmt.Fprintf(output, "\tb.overrides[%q] = factory\n", typeID)
+		fmt.Fprint(output, "\treturn b\n")
+		fmt.Fprint(output, "}\n")
+	}
+
+	fmt.Fprintf(output, "\n// Build creates a new container with all types and the overrides of this builder.\n")
+	if len(conf.Parameters) > 0 {
+		fmt.Fprintf(output, "// The default parameters are set on the given config unless they have already been set.\n")
+	}
+	fmt.Fprintf(output, "func (b *%s) Build(config map[string]interface{}) *goldi.Container {\n", builderName)
+	fmt.Fprint(output, "\tregistry := goldi.NewTypeRegistry()\n")
+	fmt.Fprintf(output, "\t%s(registry)\n", g.Config.FunctionName)
+	fmt.Fprint(output, "\tfor typeID, factory := range b.overrides {\n")
+	fmt.Fprint(output, "\t\tregistry.Register(typeID, factory)\n")
+	fmt.Fprint(output, "\t}\n\n")
+	if len(conf.Parameters) > 0 {
+		fmt.Fprintf(output, "\t%s(config)\n", g.Config.ParametersFunctionName())
+	}
+	fmt.Fprint(output, "\treturn goldi.NewContainer(registry, config)\n")
+	fmt.Fprint(output, "}\n")
+}
@@ -0,0 +1,124 @@
+package main_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/fgrosse/gomega-matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		gen    *main.Generator
+		output *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		config := main.NewConfig("github.com/fgrosse/some/thing", "RegisterAppTypes", "/absolute/path/conf/types.yml", "/absolute/path/types.go")
+		config.Builder = true
+		gen = main.NewGenerator(config)
+		output = &bytes.Buffer{}
+	})
+
+	It("should generate a builder method for each type", func() {
+		input := `
+			types:
+				http.client_pool:
+					package: github.com/fgrosse/some/thing
+					factory: NewClientPool
+
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output.String()).To(ContainSubstring(" --builder "))
+		Expect(output).To(ContainCode(`
+			type AppTypesBuilder struct {
+				overrides map[string]goldi.TypeFactory
+			}
+
+			// NewAppTypesBuilder creates a new AppTypesBuilder.
+			func NewAppTypesBuilder() *AppTypesBuilder {
+				return &AppTypesBuilder{overrides: map[string]goldi.TypeFactory{}}
+			}
+
+			// WithHttpClientPool overrides the type "http.client_pool" with the given type factory.
+			func (b *AppTypesBuilder) WithHttpClientPool(factory goldi.TypeFactory) *AppTypesBuilder {
+				b.overrides["http.client_pool"] = factory
+				return b
+			}
+
+			// WithLogger overrides the type "logger" with the given type factory.
+			func (b *AppTypesBuilder) WithLogger(factory goldi.TypeFactory) *AppTypesBuilder {
+				b.overrides["logger"] = factory
+				return b
+			}
+
+			// Build creates a new container with all types and the overrides of this builder.
+			func (b *AppTypesBuilder) Build(config map[string]interface{}) *goldi.Container {
+				registry := goldi.NewTypeRegistry()
+				RegisterAppTypes(registry)
+				for typeID, factory := range b.overrides {
+					registry.Register(typeID, factory)
+				}
+
+				return goldi.NewContainer(registry, config)
+			}
+		`))
+	})
+
+	It("should set the default parameters in Build", func() {
+		input := `
+			parameters:
+				log_level: info
+
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+					args: [ "%log_level%" ]
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(ContainSubstring("\tRegisterAppParameters(config)\n\treturn goldi.NewContainer(registry, config)\n"))
+	})
+
+	It("should return an error if several types result in the same builder method", func() {
+		input := `
+			types:
+				http.client:
+					package: github.com/fgrosse/some/thing
+					factory: NewClient
+
+				http_client:
+					package: github.com/fgrosse/some/thing
+					factory: NewClient
+		`
+		err := gen.Generate(strings.NewReader(input), output)
+		Expect(err).To(MatchError(`can not generate the builder method WithHttpClient because it would be used for the types "http.client" and "http_client"`))
+	})
+
+	It("should not generate a builder if it is disabled", func() {
+		gen.Config.Builder = false
+		input := `
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).NotTo(ContainSubstring("Builder"))
+	})
+})
+
+var _ = Describe("BuilderMethodName", func() {
+	It("should convert the type ID into camel case", func() {
+		Expect(main.BuilderMethodName("logger")).To(Equal("WithLogger"))
+		Expect(main.BuilderMethodName("goldi.test-foo_bar")).To(Equal("WithGoldiTestFooBar"))
+		Expect(main.BuilderMethodName("cache2")).To(Equal("WithCache2"))
+	})
+})
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"github.com/fgrosse/goldi"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultCompositionFunctionName is the name of the generated function that calls the registration functions of
+// all modules if no other function name has been specified.
+const DefaultCompositionFunctionName = "RegisterAll"
+
+// A ModulesManifest lists the modules whose generated type registration functions are composed into a single
+// registration function.
+type ModulesManifest struct {
+	Modules []ModuleDefinition `yaml:"modules"`
+}
+
+// A ModuleDefinition references the registration function that goldigen has generated for a single module.
+type ModuleDefinition struct {
+	// Package is the import path of the package that contains the generated registration function.
+	Package string `yaml:"package"`
+
+	// Function is the name of the registration function. It defaults to DefaultFunctionName.
+	Function string `yaml:"function"`
+}
+
+// Validate checks that all modules of the manifest reference a package and a valid function name.
+func (m *ModulesManifest) Validate() error {
+	if len(m.Modules) == 0 {
+		return fmt.Errorf("the manifest does not contain any modules")
+	}
+
+	for i, module := range m.Modules {
+		if module.Package == "" {
+			return fmt.Errorf("module %d has no package", i+1)
+		}
+
+		if module.Function != "" && token.IsExported(module.Function) == false {
+			return fmt.Errorf("module %d has the invalid function name %q: it must be an exported identifier", i+1, module.Function)
+		}
+	}
+
+	return nil
+}
+
+// GenerateComposition reads a yaml modules manifest from the `input` and writes go code to the `output` that
+// defines a single registration function which calls the generated registration function of each module in the
+// order in which the modules are listed in the manifest.
+func (g *Generator) GenerateComposition(input io.Reader, output io.Writer) error {
+	g.logVerbose("Generating composition from manifest %q with output package %q", g.Config.InputPath, g.Config.Package)
+	functionName, err := g.Config.RenderFunctionName()
+	if err != nil {
+		return err
+	}
+	g.Config.FunctionName = functionName
+
+	buildConstraint, err := g.Config.BuildConstraint()
+	if err != nil {
+		return err
+	}
+
+	manifest, err := g.parseManifest(input)
+	if err != nil {
+		return fmt.Errorf("could not parse modules manifest: %s", err)
+	}
+
+	if err = manifest.Validate(); err != nil {
+		return fmt.Errorf("invalid modules manifest: %s", err)
+	}
+
+	code := &bytes.Buffer{}
+	if buildConstraint != "" {
+		fmt.Fprintf(code, "%s\n\n", buildConstraint)
+	}
+
+	if g.Config.OutputPath != "" && g.Config.NoGenerateLine == false {
+		fmt.Fprintf(code, "//go:generate goldigen compose --in %q --out %q --package %s --function %s",
+			g.Config.InputName(), g.Config.OutputName(), g.Config.Package, g.Config.FunctionName,
+		)
+
+		if g.Config.BuildTags != "" {
+			fmt.Fprintf(code, " --build-tags %q", g.Config.BuildTags)
+		}
+
+		fmt.Fprint(code, " --overwrite --nointeraction\n")
+	}
+
+	qualifiers := g.moduleQualifiers(manifest)
+
+	fmt.Fprintf(code, "package %s\n\n", g.Config.PackageName())
+	fmt.Fprint(code, "import (\n")
+	fmt.Fprintf(code, "\t%q\n", "github.com/fgrosse/goldi")
+	imported := goldi.StringSet{}
+	for _, module := range manifest.Modules {
+		if qualifier, isImported := qualifiers[module.Package]; isImported && imported.Contains(module.Package) == false {
+			fmt.Fprintf(code, "\t%s %q\n", qualifier, module.Package)
+			imported.Set(module.Package)
+		}
+	}
+	fmt.Fprint(code, ")\n\n")
+
+	fmt.Fprintf(code, "// %s registers the types of all modules that have been listed in the file %q\n", g.Config.FunctionName, g.Config.InputName())
+	fmt.Fprintf(code, "//\n")
+	fmt.Fprintf(code, "// DO NOT EDIT THIS FILE: it has been generated by goldigen v%s.\n", Version)
+	fmt.Fprintf(code, "// It is however good practice to put this file under version control.\n")
+	fmt.Fprintf(code, "// See https://github.com/fgrosse/goldi for what is going on here.\n")
+	fmt.Fprintf(code, "func %s(types goldi.TypeRegistry) {\n", g.Config.FunctionName)
+	for _, module := range manifest.Modules {
+		function := module.Function
+		if function == "" {
+			function = DefaultFunctionName
+		}
+
+		if qualifier, isImported := qualifiers[module.Package]; isImported {
+			function = qualifier + "." + function
+		}
+
+		fmt.Fprintf(code, "\t%s(types)\n", function)
+	}
+	fmt.Fprint(code, "}\n")
+
+	_, err = code.WriteTo(output)
+	return err
+}
+
+func (g *Generator) parseManifest(input io.Reader) (*ModulesManifest, error) {
+	g.logVerbose("Parsing manifest..")
+	inputData, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ModulesManifest{}
+	err = yaml.UnmarshalStrict(g.sanitizeInput(inputData), manifest)
+	return manifest, err
+}
+
+// moduleQualifiers returns the identifiers that are used to import the packages of all modules that are not in
+// the output package. The identifiers are derived from the last element of the import path and made unique by
+// appending a number.
+func (g *Generator) moduleQualifiers(manifest *ModulesManifest) map[string]string {
+	qualifiers := map[string]string{}
+	used := map[string]bool{"goldi": true, "types": true}
+	for _, module := range manifest.Modules {
+		if module.Package == g.Config.Package {
+			continue
+		}
+
+		if _, isImported := qualifiers[module.Package]; isImported {
+			continue
+		}
+
+		parts := strings.Split(module.Package, "/")
+		base := packageIdentifier(parts[len(parts)-1])
+		qualifier := base
+		for i := 2; used[qualifier] || token.IsKeyword(qualifier); i++ {
+			qualifier = fmt.Sprintf("%s%d", base, i)
+		}
+
+		used[qualifier] = true
+		qualifiers[module.Package] = qualifier
+	}
+
+	return qualifiers
+}
+
+// packageIdentifier converts the last element of an import path into a valid go identifier
+// (e.g. "go-users" becomes "gousers").
+func packageIdentifier(name string) string {
+	identifier := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "module" + identifier
+	}
+
+	return identifier
+}
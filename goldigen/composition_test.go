@@ -0,0 +1,107 @@
+package main_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GenerateComposition", func() {
+	var (
+		gen    *main.Generator
+		output *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		config := main.NewConfig("github.com/fgrosse/some/app", main.DefaultCompositionFunctionName, "/absolute/path/app/modules.yml", "/absolute/path/app/modules.go")
+		gen = main.NewGenerator(config)
+		output = &bytes.Buffer{}
+	})
+
+	It("should generate a function that calls the registration function of each module", func() {
+		input := `
+			modules:
+				- package: github.com/fgrosse/some/users
+				- package: github.com/fgrosse/some/go-orders
+				  function: RegisterOrderTypes
+				- package: github.com/fgrosse/other/users
+				- package: github.com/fgrosse/some/app
+				  function: RegisterAppTypes
+		`
+		Expect(gen.GenerateComposition(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(Equal(`//go:generate goldigen compose --in "modules.yml" --out "modules.go" --package github.com/fgrosse/some/app --function RegisterAll --overwrite --nointeraction
+package app
+
+import (
+	"github.com/fgrosse/goldi"
+	users "github.com/fgrosse/some/users"
+	goorders "github.com/fgrosse/some/go-orders"
+	users2 "github.com/fgrosse/other/users"
+)
+
+// RegisterAll registers the types of all modules that have been listed in the file "modules.yml"
+//
+// DO NOT EDIT THIS FILE: it has been generated by goldigen v` + main.Version + `.
+// It is however good practice to put this file under version control.
+// See https://github.com/fgrosse/goldi for what is going on here.
+func RegisterAll(types goldi.TypeRegistry) {
+	users.RegisterTypes(types)
+	goorders.RegisterOrderTypes(types)
+	users2.RegisterTypes(types)
+	RegisterAppTypes(types)
+}
+`))
+	})
+
+	It("should import packages only once", func() {
+		input := `
+			modules:
+				- package: github.com/fgrosse/some/users
+				  function: RegisterUserTypes
+				- package: github.com/fgrosse/some/users
+				  function: RegisterAdminTypes
+		`
+		Expect(gen.GenerateComposition(strings.NewReader(input), output)).To(Succeed())
+		Expect(strings.Count(output.String(), `"github.com/fgrosse/some/users"`)).To(Equal(1))
+		Expect(output.String()).To(ContainSubstring("\tusers.RegisterUserTypes(types)\n\tusers.RegisterAdminTypes(types)\n"))
+	})
+
+	It("should add the build tags to the build constraint and the go:generate line", func() {
+		gen.Config.BuildTags = "integration"
+		input := `
+			modules:
+				- package: github.com/fgrosse/some/users
+		`
+		Expect(gen.GenerateComposition(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(HavePrefix("//go:build integration\n\n" +
+			`//go:generate goldigen compose --in "modules.yml" --out "modules.go" --package github.com/fgrosse/some/app --function RegisterAll --build-tags "integration" --overwrite --nointeraction` + "\n",
+		))
+	})
+
+	It("should return an error if a module has no package", func() {
+		input := `
+			modules:
+				- function: RegisterTypes
+		`
+		err := gen.GenerateComposition(strings.NewReader(input), output)
+		Expect(err).To(MatchError("invalid modules manifest: module 1 has no package"))
+	})
+
+	It("should return an error if a function name is not exported", func() {
+		input := `
+			modules:
+				- package: github.com/fgrosse/some/users
+				  function: registerTypes
+		`
+		err := gen.GenerateComposition(strings.NewReader(input), output)
+		Expect(err).To(MatchError(`invalid modules manifest: module 1 has the invalid function name "registerTypes": it must be an exported identifier`))
+	})
+
+	It("should return an error if the manifest can not be parsed", func() {
+		err := gen.GenerateComposition(strings.NewReader("modules: [ foo"), output)
+		Expect(err).To(MatchError(HavePrefix("could not parse modules manifest: ")))
+	})
+})
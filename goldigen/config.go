@@ -1,20 +1,77 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"go/build/constraint"
+	"go/token"
 	"path/filepath"
 	"strings"
+	"text/template"
 )
 
 // DefaultFunctionName is the name of the registration function that is used if nothing else has been specified.
 const DefaultFunctionName = "RegisterTypes"
 
+// DefaultMockPattern is the mock constructor pattern that is used in mock mode if nothing else has been specified.
+// The placeholder {TypeName} is replaced with the type name of each type definition.
+const DefaultMockPattern = "mocks.New{TypeName}"
+
 // Config is the goldigen configuration.
 type Config struct {
 	Package      string
 	FunctionName string
 	InputPath    string
 	OutputPath   string
+
+	// MockPattern enables the mock mode if it is not empty.
+	// In mock mode all types are registered using the mock constructor that results from this pattern.
+	MockPattern string
+
+	// MockPackage is the package that contains the mock constructors.
+	MockPackage string
+
+	// ChunkSize is the maximum number of types that are registered in a single function.
+	// If there are more types the registration is split into multiple helper functions.
+	// A value of zero disables this behavior.
+	ChunkSize int
+
+	// SourceComments enables comments that link each generated type registration to its yaml source line.
+	SourceComments bool
+
+	// DependencyOrder enables registering the types in dependency order instead of alphabetical order.
+	DependencyOrder bool
+
+	// Verify enables parsing and type checking the generated code before it is written.
+	Verify bool
+
+	// FunctionNameTemplate is a text/template that is used to derive the name of the registration function
+	// from the output package (e.g. "Register{{.Package}}Types"). {{.Package}} is replaced with the capitalized
+	// name of the output package. If the template is empty FunctionName is used as it is.
+	FunctionNameTemplate string
+
+	// InlineParametersFile is the path of a yaml file with a "parameters" section. If it is set all parameter
+	// arguments are resolved at generation time and their values are inlined into the generated code.
+	InlineParametersFile string
+
+	// ReportPath is the path of a file that documents all generated types with their factories, arguments and
+	// dependencies. The report is written as JSON if the path ends with ".json" and as Markdown otherwise.
+	ReportPath string
+
+	// Builder enables generating a fluent builder type that creates a container with all types and allows
+	// overriding each type via its own With method. See Config.BuilderName.
+	Builder bool
+
+	// BuildTags is a build constraint expression like "integration" or "linux && !race". If it is set the generated
+	// file starts with a corresponding //go:build line so it is only compiled if the constraint is satisfied.
+	BuildTags string
+
+	// NoGenerateLine disables the go:generate comment that is otherwise written at the top of the output file.
+	NoGenerateLine bool
+
+	// GroupImports enables grouping the imports into standard library, third party and local packages like goimports
+	// does. Local packages are all packages of the repository that contains the output package.
+	GroupImports bool
 }
 
 // NewConfig creates a new Config with the given parameters.
@@ -29,7 +86,68 @@ func NewConfig(completePackage, functionName, inputPath, outputPath string) Conf
 		functionName = DefaultFunctionName
 	}
 
-	return Config{completePackage, functionName, inputPath, outputPath}
+	return Config{
+		Package:      completePackage,
+		FunctionName: functionName,
+		InputPath:    inputPath,
+		OutputPath:   outputPath,
+	}
+}
+
+// RenderFunctionName renders the FunctionNameTemplate or returns the FunctionName if no template has been configured.
+// RenderFunctionName returns an error if the template is invalid or does not result in a valid go identifier.
+func (c Config) RenderFunctionName() (string, error) {
+	if c.FunctionNameTemplate == "" {
+		return c.FunctionName, nil
+	}
+
+	t, err := template.New("function").Option("missingkey=error").Parse(c.FunctionNameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid function name template %q: %s", c.FunctionNameTemplate, err)
+	}
+
+	data := map[string]string{}
+	if packageName := c.PackageName(); packageName != "" {
+		// without a package name templates that use {{.Package}} fail since the key is missing
+		data["Package"] = strings.ToUpper(packageName[:1]) + packageName[1:]
+	}
+
+	name := &bytes.Buffer{}
+	if err = t.Execute(name, data); err != nil {
+		return "", fmt.Errorf("invalid function name template %q: %s", c.FunctionNameTemplate, err)
+	}
+
+	if token.IsIdentifier(name.String()) == false {
+		return "", fmt.Errorf("function name template %q results in the invalid function name %q", c.FunctionNameTemplate, name.String())
+	}
+
+	return name.String(), nil
+}
+
+// BuildConstraint returns the //go:build line of the configured BuildTags or an empty string if no build tags
+// have been configured. It returns an error if the build tags are no valid build constraint expression.
+func (c Config) BuildConstraint() (string, error) {
+	if strings.TrimSpace(c.BuildTags) == "" {
+		return "", nil
+	}
+
+	expr, err := constraint.Parse("//go:build " + c.BuildTags)
+	if err != nil {
+		return "", fmt.Errorf("invalid build tags %q: %s", c.BuildTags, err)
+	}
+
+	return "//go:build " + expr.String(), nil
+}
+
+// ParametersFunctionName returns the name of the generated function that sets the default parameters.
+// It is derived from the configured function name by replacing a trailing "Types" with "Parameters".
+func (c Config) ParametersFunctionName() string {
+	return strings.TrimSuffix(c.FunctionName, "Types") + "Parameters"
+}
+
+// ChunkFunctionName returns the name of the unexported helper function that registers the i-th chunk of types.
+func (c Config) ChunkFunctionName(i int) string {
+	return fmt.Sprintf("%s%s%d", strings.ToLower(c.FunctionName[:1]), c.FunctionName[1:], i)
 }
 
 // PackageName returns the name of the configured package.
@@ -44,6 +162,17 @@ func (c Config) OutputName() string {
 	return filepath.Base(c.OutputPath)
 }
 
+// RelativePath returns the given path relative to the output directory.
+// If no relative path can be determined the path is returned unchanged.
+func (c Config) RelativePath(path string) string {
+	relativePath, err := filepath.Rel(filepath.Dir(c.OutputPath), path)
+	if err != nil {
+		return path
+	}
+
+	return relativePath
+}
+
 // InputName returns the input file path relative to the output directory.
 func (c Config) InputName() string {
 	inputFile, err := filepath.Rel(filepath.Dir(c.OutputPath), c.InputPath)
@@ -26,6 +26,56 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("RenderFunctionName", func() {
+		It("should return the function name if no template has been configured", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "Setup", "", "")
+			Expect(config.RenderFunctionName()).To(Equal("Setup"))
+		})
+
+		It("should render the template with the capitalized package name", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "", "", "")
+			config.FunctionNameTemplate = "Register{{.Package}}Types"
+			Expect(config.RenderFunctionName()).To(Equal("RegisterServoTypes"))
+		})
+
+		It("should return an error if the template is invalid", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "", "", "")
+			config.FunctionNameTemplate = "Register{{.Foo}}Types"
+			_, err := config.RenderFunctionName()
+			Expect(err).To(MatchError(HavePrefix(`invalid function name template "Register{{.Foo}}Types": `)))
+		})
+
+		It("should return an error if the template does not result in a valid function name", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "", "", "")
+			config.FunctionNameTemplate = "Register {{.Package}}"
+			_, err := config.RenderFunctionName()
+			Expect(err).To(MatchError(`function name template "Register {{.Package}}" results in the invalid function name "Register Servo"`))
+		})
+
+		It("should return an error if the template uses the package name but no package has been configured", func() {
+			config := main.Config{FunctionNameTemplate: "Register{{.Package}}Types"}
+			_, err := config.RenderFunctionName()
+			Expect(err).To(MatchError(HavePrefix(`invalid function name template "Register{{.Package}}Types": `)))
+
+			config.FunctionNameTemplate = "RegisterTypes"
+			Expect(config.RenderFunctionName()).To(Equal("RegisterTypes"))
+		})
+	})
+
+	Describe("ParametersFunctionName", func() {
+		It("should derive the parameters function name from the function name", func() {
+			Expect(main.NewConfig("github.com/fgrosse/servo", "", "", "").ParametersFunctionName()).To(Equal("RegisterParameters"))
+			Expect(main.NewConfig("github.com/fgrosse/servo", "Setup", "", "").ParametersFunctionName()).To(Equal("SetupParameters"))
+		})
+	})
+
+	Describe("ChunkFunctionName", func() {
+		It("should return an unexported function name", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "RegisterTypes", "", "")
+			Expect(config.ChunkFunctionName(3)).To(Equal("registerTypes3"))
+		})
+	})
+
 	Describe("OutputName", func() {
 		It("should return the output file base bane", func() {
 			config := main.NewConfig("github.com/fgrosse/servo", "", "/home/fgrosse/goldi/config/types.yml", "/home/fgrosse/goldi/types.go")
@@ -33,6 +83,18 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("RelativePath", func() {
+		It("should return the path relative to the output file", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "", "/home/fgrosse/goldi/config/types.yml", "/home/fgrosse/goldi/types.go")
+			Expect(config.RelativePath("/home/fgrosse/goldi/config/other.yml")).To(Equal("config/other.yml"))
+		})
+
+		It("should return the path unchanged if it can not be made relative", func() {
+			config := main.NewConfig("github.com/fgrosse/servo", "", "", "/home/fgrosse/goldi/types.go")
+			Expect(config.RelativePath("config/other.yml")).To(Equal("config/other.yml"))
+		})
+	})
+
 	Describe("InputName", func() {
 		It("should return the input file name relative to the output file", func() {
 			config := main.NewConfig("github.com/fgrosse/servo", "", "/home/fgrosse/goldi/config/types.yml", "/home/fgrosse/goldi/types.go")
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// A FactorySignature holds the parameter names of a factory function as they are declared in the go source code.
+type FactorySignature struct {
+	ParameterNames []string
+	IsVariadic     bool
+}
+
+// ParseFactorySignature parses all go files in the given directory and returns the signature
+// of the package level function with the given name.
+func ParseFactorySignature(dir, functionName string) (*FactorySignature, error) {
+	fileFilter := func(info os.FileInfo) bool {
+		return strings.HasSuffix(info.Name(), "_test.go") == false
+	}
+
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, fileFilter, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse go files in %q: %s", dir, err)
+	}
+
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				funcDecl, isFunc := decl.(*ast.FuncDecl)
+				if !isFunc || funcDecl.Recv != nil || funcDecl.Name.Name != functionName {
+					continue
+				}
+
+				return newFactorySignature(funcDecl.Type), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("could not find function %q in %q", functionName, dir)
+}
+
+func newFactorySignature(funcType *ast.FuncType) *FactorySignature {
+	s := &FactorySignature{}
+	for _, field := range funcType.Params.List {
+		for _, name := range field.Names {
+			s.ParameterNames = append(s.ParameterNames, name.Name)
+		}
+
+		_, s.IsVariadic = field.Type.(*ast.Ellipsis)
+	}
+
+	return s
+}
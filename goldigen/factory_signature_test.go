@@ -0,0 +1,45 @@
+package main_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseFactorySignature", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "goldigen")
+		Expect(err).NotTo(HaveOccurred())
+
+		source := `package foo
+
+			func NewFoo(name string, timeout, retries int, tags ...string) *Foo { return nil }
+
+			func (f *Foo) NewBar(other int) *Bar { return nil }
+		`
+		Expect(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(source), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should return the parameter names of the function", func() {
+		signature, err := main.ParseFactorySignature(dir, "NewFoo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signature.ParameterNames).To(Equal([]string{"name", "timeout", "retries", "tags"}))
+		Expect(signature.IsVariadic).To(BeTrue())
+	})
+
+	It("should ignore methods", func() {
+		_, err := main.ParseFactorySignature(dir, "NewBar")
+		Expect(err).To(MatchError(ContainSubstring(`could not find function "NewBar"`)))
+	})
+})
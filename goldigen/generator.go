@@ -3,12 +3,15 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/format"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/fgrosse/goldi"
 	"gopkg.in/yaml.v2"
 )
 
@@ -31,6 +34,17 @@ func NewGenerator(config Config) *Generator {
 // Generate reads a yaml type configuration from the `input` and writes the corresponding go code to the `output`.
 func (g *Generator) Generate(input io.Reader, output io.Writer) error {
 	g.logVerbose("Generating code from input %q with output package %q", g.Config.InputPath, g.Config.Package)
+	functionName, err := g.Config.RenderFunctionName()
+	if err != nil {
+		return err
+	}
+	g.Config.FunctionName = functionName
+
+	buildConstraint, err := g.Config.BuildConstraint()
+	if err != nil {
+		return err
+	}
+
 	conf, err := g.parseInput(input)
 	if err != nil {
 		return fmt.Errorf("could not parse type definition: %s", err)
@@ -41,17 +55,72 @@ func (g *Generator) Generate(input io.Reader, output io.Writer) error {
 		return err
 	}
 
-	if g.Config.OutputPath != "" {
-		g.generateGoGenerateLine(output)
+	err = g.resolveNamedArguments(conf)
+	if err != nil {
+		return err
 	}
 
-	fmt.Fprintf(output, "package %s\n\n", g.Config.PackageName())
-	g.generateImports(conf, output)
-	g.generateGoldiGenComment(output)
-	g.generateTypeRegistrationFunction(conf, output)
+	// the report is created before the parameters are inlined so it documents the parameters that are used
+	report := NewTypesReport(conf)
 
-	// TODO: once done check if the output is valid go code
-	return nil
+	if g.Config.InlineParametersFile != "" {
+		if err = g.inlineParameters(conf); err != nil {
+			return err
+		}
+	}
+
+	typeIDs := conf.TypeIDs()
+	if g.Config.DependencyOrder {
+		typeIDs, err = conf.DependencyOrder()
+		if err != nil {
+			return err
+		}
+	}
+
+	var builderMethods map[string]string
+	if g.Config.Builder {
+		if builderMethods, err = builderMethodNames(conf.TypeIDs()); err != nil {
+			return err
+		}
+	}
+
+	code := &bytes.Buffer{}
+	if buildConstraint != "" {
+		fmt.Fprintf(code, "%s\n\n", buildConstraint)
+	}
+
+	g.generateGoGenerateLine(code)
+
+	fmt.Fprintf(code, "package %s\n\n", g.Config.PackageName())
+	g.generateImports(conf, code)
+	g.generateGoldiGenComment(code)
+	g.generateTypeRegistrationFunction(conf, typeIDs, code)
+	g.generateParametersFunction(conf, code)
+	if g.Config.Builder {
+		g.generateBuilder(conf, builderMethods, code)
+	}
+
+	if g.Config.Verify {
+		g.logVerbose("Verifying generated code..")
+		if err = VerifyCode(code.Bytes(), g.Config.OutputPath); err != nil {
+			return err
+		}
+	}
+
+	// the code is formatted so e.g. the source comments are aligned exactly like gofmt would do it.
+	// Code that can not be parsed is written as it is (see Config.Verify to detect such errors).
+	if formatted, formatErr := format.Source(code.Bytes()); formatErr == nil {
+		code = bytes.NewBuffer(formatted)
+	}
+
+	if g.Config.ReportPath != "" {
+		if err = g.writeReport(report); err != nil {
+			return err
+		}
+	}
+
+	_, err = code.WriteTo(output)
+	return err
 }
 
 func (g *Generator) parseInput(input io.Reader) (*TypesConfiguration, error) {
@@ -61,16 +130,86 @@ func (g *Generator) parseInput(input io.Reader) (*TypesConfiguration, error) {
 		return nil, err
 	}
 
+	config, err := g.parseYAML(inputData, g.Config.InputPath)
+	if err != nil {
+		return config, err
+	}
+
+	err = g.resolveImports(config, filepath.Clean(g.Config.InputPath), nil, goldi.StringSet{})
+	return config, err
+}
+
+func (g *Generator) parseYAML(inputData []byte, sourcePath string) (*TypesConfiguration, error) {
+	lines := typeDefinitionLines(inputData)
 	inputData = g.sanitizeInput(inputData)
 
 	var config TypesConfiguration
-	err = yaml.Unmarshal(inputData, &config)
+	err := yaml.Unmarshal(inputData, &config)
 
 	captureStrings(&config)
 
+	for typeID, typeDef := range config.Types {
+		if typeDef.isCompact {
+			// the factories of compact type definitions are always defined in the output package
+			typeDef.Package = g.Config.Package
+		}
+
+		typeDef.SourceFile = sourcePath
+		typeDef.SourceLine = lines[typeID]
+		config.Types[typeID] = typeDef
+	}
+
 	return &config, err
 }
 
+// resolveImports merges all files that are imported by the configuration at the given path into that configuration.
+// Import paths are relative to the importing file. Files that have already been imported are skipped.
+func (g *Generator) resolveImports(conf *TypesConfiguration, path string, importStack []string, imported goldi.StringSet) error {
+	importStack = append(importStack, path)
+	imports := conf.Imports
+	conf.Imports = nil
+
+	for _, importName := range imports {
+		importPath := importName
+		if filepath.IsAbs(importPath) == false {
+			importPath = filepath.Join(filepath.Dir(path), importName)
+		}
+
+		for _, p := range importStack {
+			if p == importPath {
+				return fmt.Errorf("detected import cycle: %s", strings.Join(append(importStack, importPath), " -> "))
+			}
+		}
+
+		if imported.Contains(importPath) {
+			g.logVerbose("Skipping %q because it has already been imported", importPath)
+			continue
+		}
+
+		g.logVerbose("Importing %q", importPath)
+		imported.Set(importPath)
+		data, err := ioutil.ReadFile(importPath)
+		if err != nil {
+			return fmt.Errorf("could not import %q: %s", importName, err)
+		}
+
+		importedConf, err := g.parseYAML(data, importPath)
+		if err != nil {
+			return fmt.Errorf("could not import %q: %s", importName, err)
+		}
+
+		if err = g.resolveImports(importedConf, importPath, importStack, imported); err != nil {
+			return err
+		}
+
+		if err = conf.Merge(importedConf); err != nil {
+			return fmt.Errorf("could not import %q: %s", importName, err)
+		}
+	}
+
+	return nil
+}
+
 func (g *Generator) sanitizeInput(input []byte) []byte {
 	g.logVerbose("Sanitizing input..")
 	var sanitizedInput = newSanitizer()
@@ -106,6 +245,34 @@ func (g *Generator) sanitizeInput(input []byte) []byte {
 	return s
 }
 
+// resolveNamedArguments converts all named arguments into positional arguments by parsing the
+// factory functions in the directory of the output file.
+func (g *Generator) resolveNamedArguments(conf *TypesConfiguration) error {
+	for typeID, typeDef := range conf.Types {
+		if len(typeDef.NamedArguments) == 0 {
+			continue
+		}
+
+		if typeDef.Package != g.Config.Package || g.Config.OutputPath == "" {
+			return fmt.Errorf("type %q uses named arguments which are only supported for factories in the output package", typeID)
+		}
+
+		g.logVerbose("Resolving named arguments of type %q", typeID)
+		signature, err := ParseFactorySignature(filepath.Dir(g.Config.OutputPath), typeDef.FactoryMethod)
+		if err != nil {
+			return fmt.Errorf("could not resolve named arguments of type %q: %s", typeID, err)
+		}
+
+		if err = typeDef.ResolveNamedArguments(typeID, signature); err != nil {
+			return err
+		}
+
+		conf.Types[typeID] = typeDef
+	}
+
+	return nil
+}
+
 // captureStrings reverts any escape sequences that were introduced during the input sanitizing.
 func captureStrings(config *TypesConfiguration) {
 	unescape := func(input string) string {
@@ -137,25 +304,125 @@ func captureStrings(config *TypesConfiguration) {
 			}
 			t.RawArgumentsShort[i] = unescape(s)
 		}
+		for platform, reference := range t.Platforms {
+			t.Platforms[platform] = unescape(reference)
+		}
+		for name, a := range t.NamedArguments {
+			s, isString := a.(string)
+			if !isString {
+				continue
+			}
+			t.NamedArguments[name] = unescape(s)
+		}
 
 		config.Types[id] = t
 	}
+
+	for name, p := range config.Parameters {
+		switch v := p.(type) {
+		case string:
+			config.Parameters[name] = unescape(v)
+		case []interface{}:
+			for i, e := range v {
+				if s, isString := e.(string); isString {
+					v[i] = unescape(s)
+				}
+			}
+		}
+	}
 }
 
+// generateGoGenerateLine writes the go:generate comment that reproduces this output file.
+// Nothing is written if there is no output file or if Config.NoGenerateLine is set.
 func (g *Generator) generateGoGenerateLine(output io.Writer) {
-	fmt.Fprintf(output, "//go:generate goldigen --in %q --out %q --package %s --function %s --overwrite --nointeraction\n",
+	if g.Config.OutputPath == "" || g.Config.NoGenerateLine {
+		return
+	}
+
+	fmt.Fprintf(output, "//go:generate goldigen --in %q --out %q --package %s --function %s",
 		g.Config.InputName(), g.Config.OutputName(), g.Config.Package, g.Config.FunctionName,
 	)
+
+	if g.Config.ChunkSize > 0 {
+		fmt.Fprintf(output, " --chunk-size %d", g.Config.ChunkSize)
+	}
+
+	if g.Config.InlineParametersFile != "" {
+		fmt.Fprintf(output, " --inline-params %q", g.Config.RelativePath(g.Config.InlineParametersFile))
+	}
+
+	if g.Config.ReportPath != "" {
+		fmt.Fprintf(output, " --report %q", g.Config.RelativePath(g.Config.ReportPath))
+	}
+
+	if g.Config.SourceComments {
+		fmt.Fprint(output, " --source-comments")
+	}
+
+	if g.Config.DependencyOrder {
+		fmt.Fprint(output, " --dependency-order")
+	}
+
+	if g.Config.Builder {
+		fmt.Fprint(output, " --builder")
+	}
+
+	if g.Config.Verify {
+		fmt.Fprint(output, " --verify")
+	}
+
+	if g.Config.BuildTags != "" {
+		fmt.Fprintf(output, " --build-tags %q", g.Config.BuildTags)
+	}
+
+	if g.Config.GroupImports {
+		fmt.Fprint(output, " --group-imports")
+	}
+
+	if g.Config.MockPattern != "" {
+		fmt.Fprintf(output, " --mocks --mock-pattern %q", g.Config.MockPattern)
+		if g.Config.MockPackage != "" {
+			fmt.Fprintf(output, " --mock-package %s", g.Config.MockPackage)
+		}
+	}
+
+	fmt.Fprint(output, " --overwrite --nointeraction\n")
 }
 
 func (g *Generator) generateImports(conf *TypesConfiguration, output io.Writer) {
 	g.logVerbose("Generating import packages (ignoring %q)", g.Config.Package)
 	packages := conf.Packages("github.com/fgrosse/goldi")
+	if g.Config.MockPattern != "" {
+		// the packages of the mocked types are not used in the generated code
+		unmocked := &TypesConfiguration{Types: map[string]TypeDefinition{}}
+		for typeID, typeDef := range conf.Types {
+			if !IsMockable(typeDef) {
+				unmocked.Types[typeID] = typeDef
+			}
+		}
+		packages = unmocked.Packages("github.com/fgrosse/goldi", g.Config.MockPackage)
+	}
 
-	fmt.Fprint(output, "import (\n")
+	var imports []string
 	for _, pkg := range packages {
 		if pkg != "" && pkg != g.Config.Package {
 			g.logVerbose("Detected new import package %q", pkg)
+			imports = append(imports, pkg)
+		}
+	}
+
+	groups := [][]string{imports}
+	if g.Config.GroupImports {
+		groups = groupImports(imports, g.Config.Package)
+	}
+
+	fmt.Fprint(output, "import (\n")
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprint(output, "\n")
+		}
+
+		for _, pkg := range group {
 			fmt.Fprintf(output, "\t%q\n", pkg)
 		}
 	}
@@ -163,6 +430,43 @@ func (g *Generator) generateImports(conf *TypesConfiguration, output io.Writer)
 	fmt.Fprint(output, ")\n\n")
 }
 
+// groupImports splits the given sorted packages into the non empty groups of standard library packages,
+// third party packages and packages of the repository that contains the output package (in this order).
+func groupImports(packages []string, outputPackage string) [][]string {
+	var standard, thirdParty, local []string
+	root := repositoryRoot(outputPackage)
+	for _, pkg := range packages {
+		switch {
+		case strings.Contains(strings.Split(pkg, "/")[0], ".") == false:
+			standard = append(standard, pkg)
+		case pkg == root || strings.HasPrefix(pkg, root+"/"):
+			local = append(local, pkg)
+		default:
+			thirdParty = append(thirdParty, pkg)
+		}
+	}
+
+	var groups [][]string
+	for _, group := range [][]string{standard, thirdParty, local} {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// repositoryRoot returns the import path of the repository that contains the given package.
+// For packages of a host like github.com this is the host followed by two path elements (e.g. "github.com/fgrosse/goldi").
+func repositoryRoot(pkg string) string {
+	parts := strings.Split(pkg, "/")
+	if len(parts) >= 3 && strings.Contains(parts[0], ".") {
+		return strings.Join(parts[:3], "/")
+	}
+
+	return parts[0]
+}
+
 func (g *Generator) generateGoldiGenComment(output io.Writer) {
 	fmt.Fprintf(output, "// %s registers all types that have been defined in the file %q\n", g.Config.FunctionName, g.Config.InputName())
 	fmt.Fprintf(output, "//\n")
@@ -171,38 +475,150 @@ func (g *Generator) generateGoldiGenComment(output io.Writer) {
 	fmt.Fprintf(output, "// See https://github.com/fgrosse/goldi for what is going on here.\n")
 }
 
-func (g *Generator) generateTypeRegistrationFunction(conf *TypesConfiguration, output io.Writer) {
+func (g *Generator) generateTypeRegistrationFunction(conf *TypesConfiguration, typeIDs []string, output io.Writer) {
 	fmt.Fprintf(output, "func %s(types goldi.TypeRegistry) {\n", g.Config.FunctionName)
-	typeIDs := make([]string, len(conf.Types))
-	i := 0
+
+	chunkSize := g.Config.ChunkSize
+	if chunkSize <= 0 || len(typeIDs) <= chunkSize {
+		g.generateTypeRegistrations(conf, typeIDs, output)
+
+		// close the outmost surrounding function
+		fmt.Fprint(output, "}\n")
+		return
+	}
+
+	g.logVerbose("Splitting type registration into chunks of %d types", chunkSize)
+	var chunks [][]string
+	for len(typeIDs) > chunkSize {
+		chunks = append(chunks, typeIDs[:chunkSize])
+		typeIDs = typeIDs[chunkSize:]
+	}
+	chunks = append(chunks, typeIDs)
+
+	for i := range chunks {
+		fmt.Fprintf(output, "\t%s(types)\n", g.Config.ChunkFunctionName(i+1))
+	}
+	fmt.Fprint(output, "}\n")
+
+	for i, chunk := range chunks {
+		fmt.Fprintf(output, "\nfunc %s(types goldi.TypeRegistry) {\n", g.Config.ChunkFunctionName(i+1))
+		g.generateTypeRegistrations(conf, chunk, output)
+		fmt.Fprint(output, "}\n")
+	}
+}
+
+func (g *Generator) generateTypeRegistrations(conf *TypesConfiguration, typeIDs []string, output io.Writer) {
+	if len(typeIDs) == 1 || g.Config.DependencyOrder {
+		// the map in RegisterAll would not preserve the dependency order
+		for _, typeID := range typeIDs {
+			g.generateTypeRegistration(typeID, conf.Types[typeID], output)
+		}
+		return
+	}
+
+	// tagged types can not be registered via RegisterAll
+	var untaggedTypeIDs, taggedTypeIDs []string
+	for _, typeID := range typeIDs {
+		if len(conf.Types[typeID].Tags) > 0 {
+			taggedTypeIDs = append(taggedTypeIDs, typeID)
+		} else {
+			untaggedTypeIDs = append(untaggedTypeIDs, typeID)
+		}
+	}
+
+	if len(untaggedTypeIDs) > 0 {
+		g.generateRegisterAll(conf, untaggedTypeIDs, output)
+	}
+
+	for _, typeID := range taggedTypeIDs {
+		g.generateTypeRegistration(typeID, conf.Types[typeID], output)
+	}
+}
+
+func (g *Generator) generateTypeRegistration(typeID string, typeDef TypeDefinition, output io.Writer) {
+	fmt.Fprint(output, "\t")
+	if len(typeDef.Tags) > 0 {
+		tags := make([]string, len(typeDef.Tags))
+		for i, tag := range typeDef.Tags {
+			tags[i] = fmt.Sprintf("%q", tag)
+		}
+		fmt.Fprintf(output, "types.RegisterWithTags(%q, %s, %s)", typeID, g.factoryCode(typeDef), strings.Join(tags, ", "))
+	} else {
+		fmt.Fprintf(output, "types.Register(%q, %s)", typeID, g.factoryCode(typeDef))
+	}
+	g.generateSourceComment(typeDef, output)
+	fmt.Fprint(output, "\n")
+}
+
+func (g *Generator) generateRegisterAll(conf *TypesConfiguration, typeIDs []string, output io.Writer) {
 	maxIDLength := 0
-	for typeID := range conf.Types {
-		typeIDs[i] = typeID
-		i++
+	for _, typeID := range typeIDs {
 		if len(typeID) > maxIDLength {
 			maxIDLength = len(typeID)
 		}
 	}
-	sort.Strings(typeIDs)
 
-	if len(conf.Types) == 1 {
-		typeID := typeIDs[0]
+	fmt.Fprint(output, "\ttypes.RegisterAll(map[string]goldi.TypeFactory{\n")
+	for _, typeID := range typeIDs {
 		typeDef := conf.Types[typeID]
-		fmt.Fprint(output, "\t")
-		fmt.Fprintf(output, "types.Register(%q, %s)", typeID, FactoryCode(typeDef, g.Config.Package))
+		spaces := strings.Repeat(" ", maxIDLength-len(typeID))
+		fmt.Fprintf(output, "\t\t%q: %s%s,", typeID, spaces, g.factoryCode(typeDef))
+		g.generateSourceComment(typeDef, output)
 		fmt.Fprint(output, "\n")
-	} else {
-		fmt.Fprint(output, "\ttypes.RegisterAll(map[string]goldi.TypeFactory{\n")
-		for _, typeID := range typeIDs {
-			typeDef := conf.Types[typeID]
-			spaces := strings.Repeat(" ", maxIDLength-len(typeID))
-			fmt.Fprintf(output, "\t\t%q: %s%s,\n", typeID, spaces, FactoryCode(typeDef, g.Config.Package))
-		}
+	}
+
+	fmt.Fprint(output, "\t})\n")
+}
 
-		fmt.Fprint(output, "\t})\n")
+func (g *Generator) generateSourceComment(typeDef TypeDefinition, output io.Writer) {
+	if g.Config.SourceComments == false || typeDef.SourceLine == 0 {
+		return
 	}
 
-	// close the outmost surrounding function
+	fmt.Fprintf(output, " // defined at %s:%d", g.Config.RelativePath(typeDef.SourceFile), typeDef.SourceLine)
+}
+
+func (g *Generator) factoryCode(typeDef TypeDefinition) string {
+	if g.Config.MockPattern != "" && IsMockable(typeDef) {
+		return MockFactoryCode(typeDef, g.Config.MockPattern)
+	}
+
+	return FactoryCode(typeDef, g.Config.Package)
+}
+
+func (g *Generator) generateParametersFunction(conf *TypesConfiguration, output io.Writer) {
+	if len(conf.Parameters) == 0 {
+		return
+	}
+
+	g.logVerbose("Generating default parameters function")
+	names := make([]string, 0, len(conf.Parameters))
+	maxNameLength := 0
+	for name := range conf.Parameters {
+		names = append(names, name)
+		if len(name) > maxNameLength {
+			maxNameLength = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	functionName := g.Config.ParametersFunctionName()
+	fmt.Fprintf(output, "\n// %s sets all parameters that have been defined in the file %q\n", functionName, g.Config.InputName())
+	fmt.Fprintf(output, "// unless they have already been set in the given config.\n")
+	fmt.Fprintf(output, "func %s(config map[string]interface{}) {\n", functionName)
+	fmt.Fprint(output, "\tdefaults := map[string]interface{}{\n")
+	for _, name := range names {
+		// the values have already been checked in TypesConfiguration.Validate
+		code, _ := ParameterCode(conf.Parameters[name])
+		spaces := strings.Repeat(" ", maxNameLength-len(name))
+		fmt.Fprintf(output, "\t\t%q: %s%s,\n", name, spaces, code)
+	}
+	fmt.Fprint(output, "\t}\n\n")
+	fmt.Fprint(output, "\tfor name, value := range defaults {\n")
+	fmt.Fprint(output, "\t\tif _, isSet := config[name]; !isSet {\n")
+	fmt.Fprint(output, "\t\t\tconfig[name] = value\n")
+	fmt.Fprint(output, "\t\t}\n")
+	fmt.Fprint(output, "\t}\n")
 	fmt.Fprint(output, "}\n")
 }
 
@@ -4,8 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"go/format"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/fgrosse/goldi"
 	"github.com/fgrosse/goldi/goldigen"
 	. "github.com/fgrosse/gomega-matchers"
 	. "github.com/onsi/ginkgo/v2"
@@ -138,6 +144,239 @@ var _ = Describe("Generator", func() {
 		})
 	})
 
+	It("should group the imports if configured", func() {
+		gen.Config.GroupImports = true
+		input := `
+			types:
+				http_client:
+					package: net/http
+					type:    Client
+				graphigo.client:
+					package: github.com/fgrosse/graphigo
+					type:    Graphigo
+				some.other:
+					package: github.com/fgrosse/some/other
+					type:    Other
+				logger:
+					package: log
+					factory: Default
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output.String()).To(ContainSubstring(`
+import (
+	"log"
+	"net/http"
+
+	"github.com/fgrosse/goldi"
+	"github.com/fgrosse/graphigo"
+
+	"github.com/fgrosse/some/other"
+)
+`))
+		Expect(output.String()).To(ContainSubstring(" --group-imports "))
+	})
+
+	It("should not group the imports by default", func() {
+		input := `
+			types:
+				http_client:
+					package: net/http
+					type:    Client
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(ContainSubstring(`
+import (
+	"github.com/fgrosse/goldi"
+	"net/http"
+)
+`))
+	})
+
+	It("should generate platform switch types", func() {
+		input := `
+			types:
+				file_watcher:
+					platforms:
+						linux:   @inotify_watcher
+						default: "@polling_watcher"
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`types.Register("file_watcher", goldi.NewPlatformSwitchType(map[string]string{"default": "polling_watcher", "linux": "inotify_watcher"}))`))
+	})
+
+	It("should generate a closure for inline types", func() {
+		input := `
+			types:
+				greeting:
+					package: strings
+					inline:
+						params:  "name string"
+						returns: "*strings.Reader"
+						body:    return strings.NewReader("Hello " + name)
+					args: [ "%name%" ]
+				http_client:
+					package: net/http
+					inline:
+						returns: "*http.Client"
+						body: |
+							client := &http.Client{}
+							return client
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ImportPackage("net/http"))
+		Expect(output).To(ImportPackage("strings"))
+		Expect(output).To(ContainCode(`"greeting": goldi.NewType(func(name string) *strings.Reader { return strings.NewReader("Hello " + name) }, "%name%"),`))
+		Expect(output).To(ContainCode(`"http_client": goldi.NewType(func() *http.Client {
+			client := &http.Client{}
+			return client
+		}),`))
+
+		// the generated closures must be valid goldi factories
+		greeting := goldi.NewType(func(name string) *strings.Reader { return strings.NewReader("Hello " + name) }, "%name%")
+		Expect(goldi.IsValid(greeting)).To(BeTrue())
+		httpClient := goldi.NewType(func() *http.Client {
+			client := &http.Client{}
+			return client
+		})
+		Expect(goldi.IsValid(httpClient)).To(BeTrue())
+	})
+
+	It("should support the compact type definition syntax", func() {
+		input := `
+			types:
+				my_service: "@factory:NewThing(%a%, @b)"
+				other_service: @factory:NewOtherThing(@my_service, 42)
+				b:
+					package: github.com/fgrosse/servo/example
+					factory: NewB
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`goldi.NewType(NewThing, "%a%", "@b")`))
+		Expect(output).To(ContainCode(`goldi.NewType(NewOtherThing, "@my_service", 42)`))
+	})
+
+	It("should derive the function name from the function name template", func() {
+		gen.Config.FunctionNameTemplate = "Register{{.Package}}Types"
+		input := `
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+			parameters:
+				level: debug
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`func RegisterThingTypes(types goldi.TypeRegistry) {`))
+		Expect(output).To(ContainCode(`func RegisterThingParameters(config map[string]interface{}) {`))
+		Expect(output.String()).To(ContainSubstring("--function RegisterThingTypes"))
+	})
+
+	It("should register tagged types with their tags", func() {
+		input := `
+			types:
+				listener:
+					package: github.com/fgrosse/some/thing
+					factory: NewListener
+					tags:    [ event_listener, health_check ]
+
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+
+				metrics:
+					package: github.com/fgrosse/some/thing
+					factory: NewMetrics
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`
+			func RegisterTypes(types goldi.TypeRegistry) {
+				types.RegisterAll(map[string]goldi.TypeFactory{
+					"logger":  goldi.NewType(NewLogger),
+					"metrics": goldi.NewType(NewMetrics),
+				})
+				types.RegisterWithTags("listener", goldi.NewType(NewListener), "event_listener", "health_check")
+			}
+		`))
+	})
+
+	It("should render constants unquoted and import their packages", func() {
+		input := `
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+					arguments:
+						- const:   logrus.InfoLevel
+						  package: github.com/sirupsen/logrus
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ImportPackage("github.com/sirupsen/logrus"))
+		Expect(output).To(ContainCode(`types.Register("logger", goldi.NewType(NewLogger, logrus.InfoLevel))`))
+	})
+
+	It("should render arguments with custom argument renderers and import their packages", func() {
+		unregister := main.RegisterArgumentRenderer(
+			func(arg interface{}) bool {
+				m, isMap := arg.(map[interface{}]interface{})
+				return isMap && m["regexp"] != nil
+			},
+			func(arg interface{}) (string, []string) {
+				pattern := arg.(map[interface{}]interface{})["regexp"]
+				return fmt.Sprintf("regexp.MustCompile(%q)", pattern), []string{"regexp"}
+			},
+		)
+		defer unregister()
+
+		input := `
+			types:
+				router:
+					package: github.com/fgrosse/some/thing
+					factory: NewRouter
+					arguments:
+						- regexp: "^/users/[0-9]+$"
+						- "%base_path%"
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ImportPackage("regexp"))
+		Expect(output).To(ContainCode(`types.Register("router", goldi.NewType(NewRouter, regexp.MustCompile("^/users/[0-9]+$"), "%base_path%"))`))
+	})
+
+	It("should not use argument renderers after they have been unregistered", func() {
+		unregister := main.RegisterArgumentRenderer(
+			func(arg interface{}) bool { return arg == "%base_path%" },
+			func(arg interface{}) (string, []string) { return `"/"`, nil },
+		)
+		unregister()
+
+		t := main.TypeDefinition{FactoryMethod: "NewRouter", RawArguments: []interface{}{"%base_path%"}}
+		Expect(t.Arguments()).To(Equal([]string{`"%base_path%"`}))
+	})
+
+	It("should render cast arguments as type conversions", func() {
+		input := `
+			types:
+				rate_limiter:
+					package: github.com/fgrosse/some/thing
+					factory: NewRateLimiter
+					arguments:
+						- value: "%requests_per_second%"
+						  cast:  int64
+						- value: 0.5
+						  cast:  float32
+		`
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output).To(ContainCode(`types.Register("rate_limiter", goldi.NewType(NewRateLimiter, goldi.Cast("%requests_per_second%", "int64"), float32(0.5)))`))
+	})
+
 	It("should define the types in a global function", func() {
 		Expect(gen.Generate(strings.NewReader(exampleYaml), output)).To(Succeed())
 		// Note that NewFoo has no explicit package name since it is defined within the given outputPackageName
@@ -179,6 +418,51 @@ var _ = Describe("Generator", func() {
 				}
 			`))
 		})
+
+		It("should generate a function that sets the default parameters", func() {
+			input := `
+			parameters:
+				graphigo.base_url: https://example.com/graphigo:8443
+				graphigo.timeout:  2.5
+				graphigo.retries:  3
+				graphigo.admins:   [ alice@example.com, bob@example.com ]
+
+			types:
+				graphigo.client:
+					package: github.com/fgrosse/graphigo
+					type:    Graphigo
+					factory: NewClient
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`
+				func RegisterParameters(config map[string]interface{}) {
+					defaults := map[string]interface{}{
+						"graphigo.admins":   []interface{}{"alice@example.com", "bob@example.com"},
+						"graphigo.base_url": "https://example.com/graphigo:8443",
+						"graphigo.retries":  3,
+						"graphigo.timeout":  2.5,
+					}
+
+					for name, value := range defaults {
+						if _, isSet := config[name]; !isSet {
+							config[name] = value
+						}
+					}
+				}
+			`))
+		})
+
+		It("should not generate a parameters function if no parameters have been defined", func() {
+			Expect(gen.Generate(strings.NewReader(`
+			types:
+				graphigo.client:
+					package: github.com/fgrosse/graphigo
+					type:    Graphigo
+					factory: NewClient
+			`), output)).To(Succeed())
+			Expect(output.String()).NotTo(ContainSubstring("RegisterParameters"))
+		})
 	})
 
 	It("should validate the input", func() {
@@ -221,6 +505,30 @@ var _ = Describe("Generator", func() {
 		)))
 	})
 
+	It("should omit the go generate line if NoGenerateLine is set", func() {
+		gen.Config.NoGenerateLine = true
+		Expect(gen.Generate(strings.NewReader(exampleYaml), output)).To(Succeed())
+		Expect(output.String()).NotTo(ContainSubstring("//go:generate"))
+		Expect(output.String()).To(HavePrefix("package thing\n"))
+	})
+
+	It("should start with a build constraint if build tags have been configured", func() {
+		gen.Config.BuildTags = "integration && !race"
+		Expect(gen.Generate(strings.NewReader(exampleYaml), output)).To(Succeed())
+		Expect(output.String()).To(HavePrefix(fmt.Sprintf(
+			"//go:build integration && !race\n\n"+
+				`//go:generate goldigen --in "conf/servo_types.yml" --out "servo_types.go" --package %s --function RegisterTypes --build-tags "integration && !race" --overwrite --nointeraction`+"\n"+
+				"package thing\n",
+			outputPackageName,
+		)))
+	})
+
+	It("should return an error if the build tags are invalid", func() {
+		gen.Config.BuildTags = "integration,race"
+		err := gen.Generate(strings.NewReader(exampleYaml), output)
+		Expect(err).To(MatchError(HavePrefix(`invalid build tags "integration,race": `)))
+	})
+
 	It("should allow specifying configuration types", func() {
 		input := `
 			types:
@@ -240,6 +548,561 @@ var _ = Describe("Generator", func() {
 		`))
 	})
 
+	Context("with inlined parameters", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "goldigen")
+			Expect(err).NotTo(HaveOccurred())
+
+			params := "parameters:\n    base_url: http://example.com\n    timeout: 2.5\n    retries: 3\n"
+			Expect(ioutil.WriteFile(filepath.Join(dir, "params.yml"), []byte(params), 0644)).To(Succeed())
+			gen.Config.InlineParametersFile = filepath.Join(dir, "params.yml")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should inline the parameter values as literals", func() {
+			input := `
+				parameters:
+					debug: false
+					timeout: 10
+
+				types:
+					client:
+						package: github.com/fgrosse/some/thing
+						factory: NewClient
+						args:
+							- "%base_url%"
+							- "%timeout%"
+							- "%debug%"
+							- value: "%retries%"
+							  cast:  int64
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`types.Register("client", goldi.NewType(NewClient, goldi.InlineParameter("base_url", "http://example.com"), goldi.InlineParameter("timeout", 2.5), goldi.InlineParameter("debug", false), int64(3)))`))
+		})
+
+		It("should inline the parameter values so they are converted into the argument types of the factory", func() {
+			input := `
+				parameters:
+					port: 8080
+					read_timeout: 5s
+					ratio: 1
+
+				types:
+					server:
+						package: github.com/fgrosse/some/thing
+						factory: NewServer
+						args: [ "%port%", "%read_timeout%", "%ratio%" ]
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`types.Register("server", goldi.NewType(NewServer, goldi.InlineParameter("port", 8080), goldi.InlineParameter("read_timeout", "5s"), goldi.InlineParameter("ratio", 1)))`))
+		})
+
+		It("should return an error if a parameter can not be inlined", func() {
+			input := `
+				types:
+					client:
+						package: github.com/fgrosse/some/thing
+						factory: NewClient
+						args: [ "%missing%" ]
+			`
+			err := gen.Generate(strings.NewReader(input), output)
+			Expect(err).To(MatchError(`could not inline the parameters of type "client": the parameter "%missing%" has not been defined`))
+		})
+	})
+
+	It("should support yaml merge keys to share common fields of type definitions", func() {
+		input := `
+			templates:
+				client: &client
+					package: github.com/fgrosse/some/client
+					factory: NewClient
+					configurator: [ "@client_configurator", Configure ]
+
+			types:
+				users_client:
+					<<: *client
+					args: [ "%users_url%", @logger ]
+
+				orders_client:
+					<<: *client
+					factory: NewOrdersClient
+		`
+		gen.Config.SourceComments = true
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output).To(BeValidGoCode())
+		Expect(output.String()).To(ContainSubstring(`goldi.NewType(client.NewOrdersClient),`))
+		Expect(output.String()).To(ContainSubstring(`goldi.NewType(client.NewClient, "%users_url%", "@logger"),`))
+		Expect(strings.Count(output.String(), `"client_configurator", "Configure",`)).To(Equal(2))
+		Expect(output.String()).To(ContainSubstring(`// defined at conf/servo_types.yml:9`))
+		Expect(output.String()).To(ContainSubstring(`// defined at conf/servo_types.yml:13`))
+		Expect(output.String()).NotTo(ContainSubstring(`"client"`))
+	})
+
+	Context("with imports", func() {
+		var dir string
+
+		writeFile := func(name, content string) {
+			Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "goldigen")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Mkdir(filepath.Join(dir, "conf"), 0755)).To(Succeed())
+
+			config := main.NewConfig(outputPackageName, "RegisterTypes", filepath.Join(dir, "types.yml"), filepath.Join(dir, "types.go"))
+			gen = main.NewGenerator(config)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should merge the types of the imported files", func() {
+			writeFile("conf/logging.yml", `
+				import: [ base.yml ]
+				types:
+					logger:
+						package: github.com/fgrosse/some/thing
+						factory: NewLogger
+			`)
+			writeFile("conf/base.yml", `
+				parameters:
+					foo: bar
+				types:
+					clock:
+						package: github.com/fgrosse/some/thing
+						factory: NewClock
+			`)
+
+			input := `
+			import:
+				- conf/logging.yml
+				- conf/base.yml
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+					arguments: [ "@logger", "%foo%" ]
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(ContainCode(`
+				func RegisterTypes(types goldi.TypeRegistry) {
+					types.RegisterAll(map[string]goldi.TypeFactory{
+						"clock":  goldi.NewType(NewClock),
+						"foo":    goldi.NewType(NewFoo, "@logger", "%foo%"),
+						"logger": goldi.NewType(NewLogger),
+					})
+				}
+			`))
+			Expect(output).To(ContainCode(`"foo": "bar",`))
+		})
+
+		It("should return an error if a type is defined multiple times", func() {
+			writeFile("conf/other.yml", `
+				types:
+					foo:
+						package: github.com/fgrosse/some/thing
+						factory: NewOtherFoo
+			`)
+
+			input := `
+			import: [ conf/other.yml ]
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(MatchError(
+				`could not parse type definition: could not import "conf/other.yml": type "foo" has been defined multiple times`,
+			))
+		})
+
+		It("should detect import cycles", func() {
+			writeFile("types.yml", `
+				import: [ conf/a.yml ]
+			`)
+			writeFile("conf/a.yml", `
+				import: [ ../types.yml ]
+			`)
+
+			input := `
+			import: [ conf/a.yml ]
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+			`
+			err := gen.Generate(strings.NewReader(input), output)
+			Expect(err).To(MatchError(ContainSubstring("detected import cycle")))
+		})
+
+		It("should return an error if an imported file does not exist", func() {
+			input := `
+			import: [ conf/missing.yml ]
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+			`
+			err := gen.Generate(strings.NewReader(input), output)
+			Expect(err).To(MatchError(ContainSubstring(`could not import "conf/missing.yml"`)))
+		})
+	})
+
+	Context("with source comments", func() {
+		BeforeEach(func() {
+			gen.Config.SourceComments = true
+		})
+
+		It("should annotate each type with its position in the yaml input", func() {
+			input := "types:\n" +
+				"    # the foo type\n" +
+				"    foo:\n" +
+				"        package: github.com/fgrosse/some/thing\n" +
+				"        factory: NewFoo\n" +
+				"\n" +
+				"    bar:\n" +
+				"        package: github.com/fgrosse/some/thing\n" +
+				"        factory: NewBar\n"
+
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output.String()).To(ContainSubstring(`"bar": goldi.NewType(NewBar), // defined at conf/servo_types.yml:7`))
+			Expect(output.String()).To(ContainSubstring(`"foo": goldi.NewType(NewFoo), // defined at conf/servo_types.yml:3`))
+		})
+
+		It("should align the comments like gofmt", func() {
+			input := "types:\n" +
+				"    foo:\n" +
+				"        package: github.com/fgrosse/some/thing\n" +
+				"        factory: NewFoo\n" +
+				"    long_bar:\n" +
+				"        package: github.com/fgrosse/some/thing\n" +
+				"        factory: NewBar\n" +
+				"        args: [ \"%some_parameter%\", \"@foo\" ]\n"
+
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			formatted, err := format.Source(output.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal(string(formatted)))
+			Expect(output.String()).To(ContainSubstring("\"foo\":      goldi.NewType(NewFoo),                             // defined at conf/servo_types.yml:2\n"))
+		})
+
+		It("should ignore keys of nested values and other sections", func() {
+			input := `
+			parameters:
+				foo: bar
+			types:
+				goldi.test.foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+					arguments:
+						- "%foo%"
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output.String()).To(ContainSubstring(`types.Register("goldi.test.foo", goldi.NewType(NewFoo, "%foo%")) // defined at conf/servo_types.yml:5`))
+		})
+	})
+
+	Context("with a chunk size", func() {
+		input := `
+			types:
+				type_a:
+					package: github.com/fgrosse/some/thing
+					factory: NewA
+				type_b:
+					package: github.com/fgrosse/some/thing
+					factory: NewB
+				type_c:
+					package: github.com/fgrosse/some/thing
+					factory: NewC
+		`
+
+		It("should split the type registration into multiple functions", func() {
+			gen.Config.ChunkSize = 2
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`
+				func RegisterTypes(types goldi.TypeRegistry) {
+					registerTypes1(types)
+					registerTypes2(types)
+				}
+
+				func registerTypes1(types goldi.TypeRegistry) {
+					types.RegisterAll(map[string]goldi.TypeFactory{
+						"type_a": goldi.NewType(NewA),
+						"type_b": goldi.NewType(NewB),
+					})
+				}
+
+				func registerTypes2(types goldi.TypeRegistry) {
+					types.Register("type_c", goldi.NewType(NewC))
+				}
+			`))
+		})
+
+		It("should not split the type registration if the number of types does not exceed the chunk size", func() {
+			gen.Config.ChunkSize = 3
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output.String()).NotTo(ContainSubstring("registerTypes1"))
+		})
+	})
+
+	Context("with dependency order", func() {
+		BeforeEach(func() {
+			gen.Config.DependencyOrder = true
+		})
+
+		It("should register referenced types before the types that depend on them", func() {
+			input := `
+				types:
+					type_a:
+						package: github.com/fgrosse/some/thing
+						factory: NewA
+						arguments: [ "@type_c" ]
+					type_b:
+						package: github.com/fgrosse/some/thing
+						factory: NewB
+					type_c:
+						package: github.com/fgrosse/some/thing
+						factory: NewC
+						arguments: [ "@type_b" ]
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`
+				func RegisterTypes(types goldi.TypeRegistry) {
+					types.Register("type_b", goldi.NewType(NewB))
+					types.Register("type_c", goldi.NewType(NewC, "@type_b"))
+					types.Register("type_a", goldi.NewType(NewA, "@type_c"))
+				}
+			`))
+		})
+
+		It("should return an error if the types contain circular references", func() {
+			input := `
+				types:
+					type_a:
+						package: github.com/fgrosse/some/thing
+						factory: NewA
+						arguments: [ "@type_b" ]
+					type_b:
+						package: github.com/fgrosse/some/thing
+						factory: NewB
+						arguments: [ "@type_a" ]
+			`
+			err := gen.Generate(strings.NewReader(input), output)
+			Expect(err).To(MatchError(ContainSubstring("circular references")))
+		})
+	})
+
+	Context("with verification", func() {
+		BeforeEach(func() {
+			gen.Config.Verify = true
+		})
+
+		It("should generate valid code", func() {
+			Expect(gen.Generate(strings.NewReader(exampleYaml), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output.String()).To(ContainSubstring(" --verify --overwrite"))
+		})
+
+		It("should return an error with the line context if the code can not be parsed", func() {
+			input := `
+				types:
+					foo:
+						package: github.com/fgrosse/some/thing
+						type:    Foo Bar
+			`
+			err := gen.Generate(strings.NewReader(input), output)
+			Expect(err).To(MatchError(MatchRegexp(`^generated code is invalid: line \d+: .+\n\ttypes.Register\("foo", goldi.NewStructType\(new\(Foo Bar\)\)\)$`)))
+			Expect(output.Len()).To(BeZero())
+		})
+
+		Context("when the output package contains other files", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "goldigen")
+				Expect(err).NotTo(HaveOccurred())
+
+				source := "package thing\n\ntype Foo struct{}\n\nfunc NewFoo() *Foo { return nil }\n"
+				Expect(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(source), 0644)).To(Succeed())
+
+				config := main.NewConfig(outputPackageName, "RegisterTypes", filepath.Join(dir, "types.yml"), filepath.Join(dir, "types.go"))
+				config.Verify = true
+				gen = main.NewGenerator(config)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("should type check the generated code", func() {
+				input := `
+					types:
+						foo:
+							package: github.com/fgrosse/some/thing
+							factory: NewFoo
+						bar:
+							package: github.com/fgrosse/some/thing
+							factory: NewBar
+				`
+				err := gen.Generate(strings.NewReader(input), output)
+				Expect(err).To(MatchError(ContainSubstring("undefined: NewBar")))
+				Expect(err).To(MatchError(ContainSubstring(`"bar": goldi.NewType(NewBar),`)))
+			})
+
+			It("should not return an error if the generated code is valid", func() {
+				input := `
+					types:
+						foo:
+							package: github.com/fgrosse/some/thing
+							factory: NewFoo
+				`
+				Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			})
+		})
+	})
+
+	Context("in mock mode", func() {
+		input := `
+			types:
+				goldi.test.foo:
+					package: github.com/fgrosse/some/thing
+					type:    Foo
+					factory: NewFoo
+
+				graphigo.client:
+					package: github.com/fgrosse/graphigo
+					factory: NewGraphigo
+					arguments: [ "%graphigo.base_url%" ]
+
+				http_handler:
+					package: github.com/fgrosse/servo/example
+					func:    HandleHTTP
+
+				logger:
+					package: github.com/mgutz/logxi.v1
+					package-name: log
+					type:    Logger
+					factory: New
+					configurator: ["@confoogurator", Configure]
+		`
+
+		BeforeEach(func() {
+			gen.Config.MockPattern = "mocks.New{TypeName}"
+			gen.Config.MockPackage = "github.com/fgrosse/some/thing/mocks"
+		})
+
+		It("should register the mocks instead of the actual types", func() {
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(BeValidGoCode())
+			Expect(output).To(ContainCode(`
+				func RegisterTypes(types goldi.TypeRegistry) {
+					types.RegisterAll(map[string]goldi.TypeFactory{
+						"goldi.test.foo":  goldi.NewType(mocks.NewFoo),
+						"graphigo.client": goldi.NewType(mocks.NewGraphigo),
+						"http_handler":    goldi.NewFuncType(example.HandleHTTP),
+						"logger":          goldi.NewType(mocks.NewLogger),
+					})
+				}
+			`))
+		})
+
+		It("should only import the packages that are still used", func() {
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(ImportPackage("github.com/fgrosse/some/thing/mocks"))
+			Expect(output).To(ImportPackage("github.com/fgrosse/servo/example"))
+			Expect(output).NotTo(ImportPackage("github.com/fgrosse/graphigo"))
+			Expect(output).NotTo(ImportPackage("github.com/mgutz/logxi.v1"))
+		})
+
+		It("should include the mock flags in the go generate line", func() {
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(ContainCode(fmt.Sprintf(
+				`//go:generate goldigen --in "conf/servo_types.yml" --out "servo_types.go" --package %s --function RegisterTypes --mocks --mock-pattern "mocks.New{TypeName}" --mock-package github.com/fgrosse/some/thing/mocks --overwrite --nointeraction`,
+				outputPackageName,
+			)))
+		})
+	})
+
+	Context("with named arguments", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "goldigen")
+			Expect(err).NotTo(HaveOccurred())
+
+			source := "package thing\n\nfunc NewFoo(name string, timeout int, logger Logger) *Foo { return nil }\n"
+			Expect(ioutil.WriteFile(filepath.Join(dir, "foo.go"), []byte(source), 0644)).To(Succeed())
+
+			config := main.NewConfig(outputPackageName, "RegisterTypes", filepath.Join(dir, "types.yml"), filepath.Join(dir, "types.go"))
+			gen = main.NewGenerator(config)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should generate the arguments in the order of the factory signature", func() {
+			input := `
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+					named_arguments:
+						logger:  @logger
+						timeout: 42
+						name:    "%foo.name%"
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+			Expect(output).To(ContainCode(`
+				func RegisterTypes(types goldi.TypeRegistry) {
+					types.Register("foo", goldi.NewType(NewFoo, "%foo.name%", 42, "@logger"))
+				}
+			`))
+		})
+
+		It("should return an error if a named argument does not match any parameter", func() {
+			input := `
+			types:
+				foo:
+					package: github.com/fgrosse/some/thing
+					factory: NewFoo
+					named_arguments:
+						name:    "foo"
+						timeout: 42
+						loger:   "@logger"
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(MatchError(`named argument "loger" of type "foo" does not match any parameter of NewFoo`))
+		})
+
+		It("should return an error if the factory is not defined in the output package", func() {
+			input := `
+			types:
+				foo:
+					package: github.com/fgrosse/other
+					factory: NewFoo
+					named_arguments:
+						name: "foo"
+			`
+			Expect(gen.Generate(strings.NewReader(input), output)).To(MatchError(`type "foo" uses named arguments which are only supported for factories in the output package`))
+		})
+	})
+
 	It("should log message in debug mode", func() {
 		logger := new(bytes.Buffer)
 		gen.Debug = true
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fgrosse/goldi"
+)
+
+// GenerateGraph reads a yaml type configuration from the `input` and writes a graph of the dependencies
+// between the defined types to the `output`. The graph is written in the DOT language of Graphviz.
+// The type definitions are not validated, so a graph can also be generated for incomplete configurations.
+func (g *Generator) GenerateGraph(input io.Reader, output io.Writer) error {
+	conf, err := g.parseInput(input)
+	if err != nil {
+		return fmt.Errorf("could not parse type definition: %s", err)
+	}
+
+	return conf.WriteGraph(output)
+}
+
+// WriteGraph writes a graph of the dependencies between all types of this configuration to the given writer.
+// Each type is a node and each type reference is an edge from the referencing to the referenced type.
+// The graph is written in the DOT language of Graphviz. Referenced types that are not defined are drawn dashed.
+func (c *TypesConfiguration) WriteGraph(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph goldi {")
+
+	undefined := goldi.StringSet{}
+	var edges []string
+	for _, typeID := range c.TypeIDs() {
+		fmt.Fprintf(out, "\t%q;\n", typeID)
+
+		typeDef := c.Types[typeID]
+		references := goldi.StringSet{}
+		for _, reference := range typeDef.References() {
+			references.Set(reference)
+		}
+
+		for reference := range references {
+			if _, isDefined := c.Types[reference]; isDefined == false {
+				undefined.Set(reference)
+			}
+			edges = append(edges, fmt.Sprintf("\t%q -> %q;\n", typeID, reference))
+		}
+	}
+
+	var undefinedTypeIDs []string
+	for typeID := range undefined {
+		undefinedTypeIDs = append(undefinedTypeIDs, typeID)
+	}
+	sort.Strings(undefinedTypeIDs)
+	for _, typeID := range undefinedTypeIDs {
+		fmt.Fprintf(out, "\t%q [style=dashed];\n", typeID)
+	}
+
+	sort.Strings(edges)
+	for _, edge := range edges {
+		fmt.Fprint(out, edge)
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
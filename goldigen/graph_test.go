@@ -0,0 +1,63 @@
+package main_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GenerateGraph", func() {
+	var (
+		gen    *main.Generator
+		output *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		config := main.NewConfig("github.com/fgrosse/some/thing", "RegisterTypes", "/absolute/path/conf/types.yml", "")
+		gen = main.NewGenerator(config)
+		output = &bytes.Buffer{}
+	})
+
+	It("should write an edge for each type reference", func() {
+		input := `
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+					args: [ "%log_level%" ]
+
+				client:
+					package: github.com/fgrosse/some/thing
+					factory: NewClient
+					args: [ @logger, "@?metrics", "@logger" ]
+					configurator: [ "@client_configurator", Configure ]
+
+				client_configurator: "@factory:NewConfigurator(@logger)"
+
+				default_client:
+					alias: client
+		`
+		Expect(gen.GenerateGraph(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(Equal(`digraph goldi {
+	"client";
+	"client_configurator";
+	"default_client";
+	"logger";
+	"metrics" [style=dashed];
+	"client" -> "client_configurator";
+	"client" -> "logger";
+	"client" -> "metrics";
+	"client_configurator" -> "logger";
+	"default_client" -> "client";
+}
+`))
+	})
+
+	It("should return an error if the input can not be parsed", func() {
+		err := gen.GenerateGraph(strings.NewReader("types: [ foo"), output)
+		Expect(err).To(MatchError(HavePrefix("could not parse type definition: ")))
+	})
+})
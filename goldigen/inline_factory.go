@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"strings"
+)
+
+// An InlineFactory defines the factory function of a type directly in the types configuration.
+// This is useful for trivial glue services that do not justify a named factory function or struct.
+// The factory is rendered as closure in the generated code and registered via goldi.NewType
+// so the arguments of the type definition are passed as parameters to the closure.
+type InlineFactory struct {
+	// Params contains the go parameter declarations of the closure (e.g. "name string, logger *log.Logger").
+	Params string `yaml:"params,omitempty"`
+
+	// Returns is the go type expression of the value the closure returns (e.g. "*http.Client").
+	Returns string `yaml:"returns"`
+
+	// Body contains the go statements of the closure including the final return statement.
+	Body string `yaml:"body"`
+}
+
+// Code returns the go code of the closure.
+// A body that consists of a single line is rendered on the same line as the function signature.
+func (f *InlineFactory) Code() string {
+	body := strings.TrimSpace(f.Body)
+	if strings.Contains(body, "\n") == false {
+		return fmt.Sprintf("func(%s) %s { %s }", f.Params, f.Returns, body)
+	}
+
+	code := fmt.Sprintf("func(%s) %s {\n", f.Params, f.Returns)
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == "" {
+			code += "\n"
+			continue
+		}
+		code += "\t\t\t" + strings.TrimRight(line, " \t") + "\n"
+	}
+
+	return code + "\t\t}"
+}
+
+// parse checks that the closure is a syntactically valid function literal and returns it.
+func (f *InlineFactory) parse() (*ast.FuncLit, error) {
+	expr, err := parser.ParseExpr(f.Code())
+	if err != nil {
+		return nil, err
+	}
+
+	closure, isFuncLit := expr.(*ast.FuncLit)
+	if isFuncLit == false {
+		return nil, fmt.Errorf("expected a function literal but got %T", expr)
+	}
+
+	return closure, nil
+}
+
+func (t *TypeDefinition) validateInlineFactory(typeID string) error {
+	if t.FactoryMethod != "" || t.TypeName != "" || t.FuncName != "" || len(t.Platforms) > 0 {
+		return fmt.Errorf("inline type %q must not define a type, factory, func or platforms", typeID)
+	}
+
+	if len(t.NamedArguments) > 0 {
+		return fmt.Errorf("inline type %q does not support named arguments", typeID)
+	}
+
+	if strings.TrimSpace(t.Inline.Returns) == "" {
+		return fmt.Errorf("inline type %q is missing the required %q key", typeID, "returns")
+	}
+
+	if strings.TrimSpace(t.Inline.Body) == "" {
+		return fmt.Errorf("inline type %q is missing the required %q key", typeID, "body")
+	}
+
+	if isFactoryResultType(t.Inline.Returns) == false {
+		return fmt.Errorf("inline type %q must return a pointer, interface or function but returns %s", typeID, t.Inline.Returns)
+	}
+
+	closure, err := t.Inline.parse()
+	if err != nil {
+		return fmt.Errorf("inline type %q is no valid go code: %s", typeID, err)
+	}
+
+	numParams := closure.Type.Params.NumFields()
+	numArgs := len(t.RawArguments) + len(t.RawArgumentsShort)
+	isVariadic := false
+	if list := closure.Type.Params.List; len(list) > 0 {
+		_, isVariadic = list[len(list)-1].Type.(*ast.Ellipsis)
+	}
+
+	switch {
+	case isVariadic && numArgs < numParams-1:
+		return fmt.Errorf("inline type %q needs at least %d arguments but got %d", typeID, numParams-1, numArgs)
+	case isVariadic == false && numArgs != numParams:
+		return fmt.Errorf("inline type %q has %d parameters but got %d arguments", typeID, numParams, numArgs)
+	}
+
+	return nil
+}
+
+// isFactoryResultType returns true if the given go type expression can be the result of a goldi type factory.
+// goldi.NewType only accepts factories that return a pointer, an interface or a function. Package qualified types
+// like "io.Writer" are assumed to be interfaces since they can not be checked without loading the package.
+func isFactoryResultType(returns string) bool {
+	expr, err := parser.ParseExpr(returns)
+	if err != nil {
+		return false
+	}
+
+	switch e := expr.(type) {
+	case *ast.StarExpr, *ast.InterfaceType, *ast.FuncType, *ast.SelectorExpr:
+		return true
+	case *ast.Ident:
+		return e.Name == "error" || e.Name == "any"
+	default:
+		return false
+	}
+}
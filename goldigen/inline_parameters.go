@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/fgrosse/goldi"
+	"gopkg.in/yaml.v2"
+)
+
+// An inlinedParameter is a factory argument whose parameter value has been resolved at generation time.
+// It is rendered using goldi.InlineParameter so the value is still converted into the type the factory expects
+// when the type is generated, just like the value of a parameter that is looked up in the container configuration.
+type inlinedParameter struct {
+	name  string
+	value interface{}
+}
+
+// code returns the go code of this inlined parameter.
+func (p inlinedParameter) code() string {
+	if p.value == nil {
+		return fmt.Sprintf("goldi.InlineParameter(%q, nil)", p.name)
+	}
+
+	return fmt.Sprintf("goldi.InlineParameter(%q, %#v)", p.name, p.value)
+}
+
+// inlineParameters replaces all parameter arguments of the configured types with the values from the configured
+// parameters file. Parameters which are not defined in that file fall back to the default parameters of the configuration.
+// An error is returned if any used parameter can not be resolved since the generated code must not depend on
+// the runtime configuration.
+func (g *Generator) inlineParameters(conf *TypesConfiguration) error {
+	g.logVerbose("Inlining parameters from %q", g.Config.InlineParametersFile)
+	data, err := ioutil.ReadFile(g.Config.InlineParametersFile)
+	if err != nil {
+		return fmt.Errorf("could not read parameters file: %s", err)
+	}
+
+	var paramsFile TypesConfiguration
+	if err = yaml.Unmarshal(data, &paramsFile); err != nil {
+		return fmt.Errorf("could not parse parameters file %q: %s", g.Config.InlineParametersFile, err)
+	}
+
+	parameters := map[string]interface{}{}
+	for name, value := range conf.Parameters {
+		parameters[name] = value
+	}
+	for name, value := range paramsFile.Parameters {
+		parameters[name] = value
+	}
+
+	for typeID, typeDef := range conf.Types {
+		for _, args := range [][]interface{}{typeDef.RawArguments, typeDef.RawArgumentsShort} {
+			for i, arg := range args {
+				if args[i], err = inlineParameter(arg, parameters); err != nil {
+					return fmt.Errorf("could not inline the parameters of type %q: %s", typeID, err)
+				}
+			}
+		}
+		conf.Types[typeID] = typeDef
+	}
+
+	return nil
+}
+
+// inlineParameter returns the value of the given argument if it is a parameter.
+// Parameters in cast arguments are replaced with their raw value so the value is converted by the cast.
+func inlineParameter(arg interface{}, parameters map[string]interface{}) (interface{}, error) {
+	if value, typeName, isCast := castArgument(arg); isCast {
+		inlinedValue, err := inlineParameter(value, parameters)
+		if inlined, isInlined := inlinedValue.(inlinedParameter); isInlined {
+			inlinedValue = inlined.value
+		}
+		return map[interface{}]interface{}{"value": inlinedValue, "cast": typeName}, err
+	}
+
+	s, isString := arg.(string)
+	if isString == false || goldi.IsParameter(s) == false {
+		return arg, nil
+	}
+
+	name := s[1 : len(s)-1]
+	value, isDefined := parameters[name]
+	if isDefined == false {
+		return arg, fmt.Errorf("the parameter %q has not been defined", s)
+	}
+
+	return inlinedParameter{name: name, value: value}, nil
+}
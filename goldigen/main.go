@@ -27,21 +27,69 @@ var (
 	verbose       = app.Flag("verbose", "Print verbose output").Default("false").Bool()
 	overwrite     = app.Flag("overwrite", "Overwrite any existing files").Default("false").Short('y').Bool()
 	forceStdOut   = app.Flag("echo", "Echo the generated code to std out even if a output path is given").Default("false").Bool()
+	functionTmpl  = app.Flag("function-template", `A text/template to derive the function name from the output package (e.g. "Register{{.Package}}Types")`).String()
+	inlineParams  = app.Flag("inline-params", "Inline the values of all parameters from this yaml file into the generated code").ExistingFile()
+	reportPath    = app.Flag("report", "Additionally write a Markdown (or JSON if the file ends with .json) report of all generated types to this file").String()
+	chunkSize     = app.Flag("chunk-size", "Split the type registration into multiple functions with at most this many types (0 disables splitting)").Default("0").Int()
+	sourceComment = app.Flag("source-comments", "Annotate each type registration with its position in the yaml input").Default("false").Bool()
+	depOrder      = app.Flag("dependency-order", "Register the types such that referenced types are registered before the types that depend on them").Default("false").Bool()
+	builder       = app.Flag("builder", "Additionally generate a fluent builder that creates a container and can override each type").Default("false").Bool()
+	verify        = app.Flag("verify", "Parse and type check the generated code before writing it").Default("false").Bool()
+	buildTags     = app.Flag("build-tags", `A build constraint (e.g. "integration" or "linux && !race") that is written as //go:build line into the output file`).String()
+	groupImport   = app.Flag("group-imports", "Group the imports into standard library, third party and local packages").Default("false").Bool()
+	noGenLine     = app.Flag("no-generate-line", "Do not write a go:generate comment into the output file").Default("false").Bool()
+	mocks         = app.Flag("mocks", "Register mocks instead of the actual types").Default("false").Bool()
+	mockPattern   = app.Flag("mock-pattern", "The pattern of the mock constructors. {TypeName} is replaced with the name of each type").Default(DefaultMockPattern).String()
+	mockPackage   = app.Flag("mock-package", "The package that contains the mock constructors").String()
+
+	generateCmd = app.Command("generate", "Generate the go code that registers the types (default)").Default()
+	graphCmd    = app.Command("graph", "Write a Graphviz (DOT) graph of the dependencies between the types instead of generating code")
+	composeCmd  = app.Command("compose", "Generate a function that calls the generated registration functions of all modules of a yaml manifest")
 )
 
 func main() {
 	defer panicHandler()
 	app.Version(Version)
 
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
 
 	inputPath, _ := filepath.Abs((*inputFile).Name())
 	if *outputPath != "" {
 		*outputPath, _ = filepath.Abs(*outputPath)
 	}
 
+	if command == graphCmd.FullCommand() {
+		generateGraph(inputPath)
+		return
+	}
+
+	if command == composeCmd.FullCommand() {
+		generateComposition(inputPath)
+		return
+	}
+
 	outputPackageName := determineOutputPackageName()
 	config := NewConfig(outputPackageName, *functionName, inputPath, *outputPath)
+	config.FunctionNameTemplate = *functionTmpl
+	config.ChunkSize = *chunkSize
+	if *inlineParams != "" {
+		config.InlineParametersFile, _ = filepath.Abs(*inlineParams)
+	}
+	if *reportPath != "" {
+		config.ReportPath, _ = filepath.Abs(*reportPath)
+	}
+	config.SourceComments = *sourceComment
+	config.DependencyOrder = *depOrder
+	config.Verify = *verify
+	config.Builder = *builder
+	config.NoGenerateLine = *noGenLine
+	config.BuildTags = *buildTags
+	config.GroupImports = *groupImport
+	if *mocks {
+		config.MockPattern = *mockPattern
+		config.MockPackage = *mockPackage
+	}
+
 	gen := NewGenerator(config)
 	output := &bytes.Buffer{}
 
@@ -65,6 +113,52 @@ func main() {
 	writeOutputFile(output)
 }
 
+func generateGraph(inputPath string) {
+	// the graph does not depend on the output package so there is no need to determine it
+	gen := NewGenerator(Config{Package: *packageName, InputPath: inputPath, OutputPath: *outputPath})
+	gen.Debug = *verbose
+
+	output := &bytes.Buffer{}
+	if err := gen.GenerateGraph(*inputFile, output); err != nil {
+		log(err.Error())
+		os.Exit(1)
+	}
+
+	if *outputPath == "" || *forceStdOut {
+		fmt.Print(output.String())
+		return
+	}
+
+	writeOutputFile(output)
+}
+
+func generateComposition(inputPath string) {
+	if *functionName == "" {
+		*functionName = DefaultCompositionFunctionName
+	}
+
+	config := NewConfig(determineOutputPackageName(), *functionName, inputPath, *outputPath)
+	config.FunctionNameTemplate = *functionTmpl
+	config.NoGenerateLine = *noGenLine
+	config.BuildTags = *buildTags
+
+	gen := NewGenerator(config)
+	gen.Debug = *verbose
+
+	output := &bytes.Buffer{}
+	if err := gen.GenerateComposition(*inputFile, output); err != nil {
+		log(err.Error())
+		os.Exit(1)
+	}
+
+	if *outputPath == "" || *forceStdOut {
+		fmt.Print(output.String())
+		return
+	}
+
+	writeOutputFile(output)
+}
+
 func panicHandler() {
 	if r := recover(); r != nil {
 		log("FATAL ERROR: %s", r)
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParameterCode returns the go code that represents the given parameter value as it was parsed from yaml.
+// Supported are strings, booleans, integers, floats and lists of these values.
+func ParameterCode(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEnN") {
+			// make sure the value is not interpreted as int by the go compiler
+			s += ".0"
+		}
+		return s, nil
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			code, err := ParameterCode(element)
+			if err != nil {
+				return "", err
+			}
+			elements[i] = code
+		}
+		return fmt.Sprintf("[]interface{}{%s}", strings.Join(elements, ", ")), nil
+	default:
+		return "", fmt.Errorf("unsupported parameter value %v (type %T)", value, value)
+	}
+}
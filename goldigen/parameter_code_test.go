@@ -0,0 +1,26 @@
+package main_test
+
+import (
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParameterCode", func() {
+	It("should return the golang code for scalar values", func() {
+		Expect(main.ParameterCode("foo")).To(Equal(`"foo"`))
+		Expect(main.ParameterCode(true)).To(Equal(`true`))
+		Expect(main.ParameterCode(42)).To(Equal(`42`))
+		Expect(main.ParameterCode(42.5)).To(Equal(`42.5`))
+		Expect(main.ParameterCode(42.0)).To(Equal(`42.0`))
+	})
+
+	It("should return the golang code for lists", func() {
+		Expect(main.ParameterCode([]interface{}{"foo", 42, false})).To(Equal(`[]interface{}{"foo", 42, false}`))
+	})
+
+	It("should return an error for unsupported values", func() {
+		_, err := main.ParameterCode(map[interface{}]interface{}{"foo": "bar"})
+		Expect(err).To(MatchError("unsupported parameter value map[foo:bar] (type map[interface {}]interface {})"))
+	})
+})
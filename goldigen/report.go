@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A TypesReport documents all types of a configuration together with their factories, arguments and dependencies.
+type TypesReport struct {
+	Types []TypesReportEntry `json:"types"`
+}
+
+// A TypesReportEntry documents a single type of a TypesReport.
+type TypesReportEntry struct {
+	ID           string   `json:"id"`
+	Kind         string   `json:"kind"`
+	Factory      string   `json:"factory"`
+	Arguments    []string `json:"arguments"`
+	Dependencies []string `json:"dependencies"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// NewTypesReport creates a TypesReport of all types of the given configuration in alphabetical order.
+// To avoid any unexpected behavior you should call TypesConfiguration.Validate first.
+func NewTypesReport(conf *TypesConfiguration) TypesReport {
+	report := TypesReport{Types: []TypesReportEntry{}}
+	for _, typeID := range conf.TypeIDs() {
+		typeDef := conf.Types[typeID]
+		kind, factory := reportFactory(typeDef)
+
+		dependencies := []string{}
+		for _, reference := range typeDef.References() {
+			if containsString(dependencies, reference) == false {
+				dependencies = append(dependencies, reference)
+			}
+		}
+		sort.Strings(dependencies)
+
+		report.Types = append(report.Types, TypesReportEntry{
+			ID:           typeID,
+			Kind:         kind,
+			Factory:      factory,
+			Arguments:    append([]string{}, typeDef.Arguments()...),
+			Dependencies: dependencies,
+			Tags:         typeDef.Tags,
+		})
+	}
+
+	return report
+}
+
+// reportFactory returns the kind of the given type definition and a description of its factory.
+func reportFactory(t TypeDefinition) (kind, factory string) {
+	qualified := func(name string) string {
+		if t.Package == "" {
+			return name
+		}
+		return t.Package + "." + name
+	}
+
+	switch {
+	case t.FuncName != "" && t.FuncName[0] == '@':
+		return "func reference", t.FuncName
+	case t.FuncName != "":
+		return "func", qualified(t.FuncName)
+	case t.AliasForType != "":
+		return "alias", "@" + strings.TrimPrefix(t.AliasForType, "@")
+	case len(t.Platforms) > 0:
+		platforms := make([]string, 0, len(t.Platforms))
+		for platform, reference := range t.Platforms {
+			platforms = append(platforms, fmt.Sprintf("%s: %s", platform, reference))
+		}
+		sort.Strings(platforms)
+		return "platform switch", strings.Join(platforms, ", ")
+	case t.Inline != nil:
+		return "inline closure", fmt.Sprintf("func(%s) %s", t.Inline.Params, t.Inline.Returns)
+	case t.FactoryMethod != "" && t.FactoryMethod[0] == '@':
+		return "proxy", t.FactoryMethod
+	case t.FactoryMethod != "":
+		return "factory", qualified(t.FactoryMethod)
+	default:
+		return "struct", qualified(t.TypeName)
+	}
+}
+
+// WriteMarkdown writes this report as Markdown document with one section per type.
+func (r TypesReport) WriteMarkdown(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprint(out, "# Types\n")
+
+	for _, entry := range r.Types {
+		fmt.Fprintf(out, "\n## %s\n\n", entry.ID)
+		fmt.Fprintf(out, "- **Factory:** `%s` (%s)\n", entry.Factory, entry.Kind)
+		fmt.Fprintf(out, "- **Arguments:** %s\n", markdownList(entry.Arguments))
+		fmt.Fprintf(out, "- **Dependencies:** %s\n", markdownList(entry.Dependencies))
+		if len(entry.Tags) > 0 {
+			fmt.Fprintf(out, "- **Tags:** %s\n", markdownList(entry.Tags))
+		}
+	}
+
+	return out.Flush()
+}
+
+func markdownList(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "`" + value + "`"
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// WriteJSON writes this report as indented JSON document.
+func (r TypesReport) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// writeReport writes the report to the configured report path.
+// The report is written as JSON if the path has the extension ".json" and as Markdown otherwise.
+func (g *Generator) writeReport(report TypesReport) error {
+	g.logVerbose("Writing report to %q", g.Config.ReportPath)
+	write := report.WriteMarkdown
+	if strings.ToLower(filepath.Ext(g.Config.ReportPath)) == ".json" {
+		write = report.WriteJSON
+	}
+
+	output := &strings.Builder{}
+	if err := write(output); err != nil {
+		return fmt.Errorf("could not write report: %s", err)
+	}
+
+	if err := ioutil.WriteFile(g.Config.ReportPath, []byte(output.String()), 0644); err != nil {
+		return fmt.Errorf("could not write report: %s", err)
+	}
+
+	return nil
+}
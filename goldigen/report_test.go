@@ -0,0 +1,104 @@
+package main_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fgrosse/goldi/goldigen"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TypesReport", func() {
+	var (
+		gen    *main.Generator
+		output *bytes.Buffer
+		dir    string
+		input  = `
+			types:
+				logger:
+					package: github.com/fgrosse/some/thing
+					factory: NewLogger
+					args: [ "%log_level%" ]
+
+				client:
+					package: github.com/fgrosse/other/pkg
+					factory: NewClient
+					args: [ "@logger", "@?metrics", "@logger" ]
+					tags: [ http ]
+
+				metrics:
+					package: github.com/fgrosse/some/thing
+					type: Metrics
+
+				default_client:
+					alias: client
+		`
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "goldigen")
+		Expect(err).NotTo(HaveOccurred())
+
+		config := main.NewConfig("github.com/fgrosse/some/thing", "RegisterTypes", filepath.Join(dir, "types.yml"), filepath.Join(dir, "types.go"))
+		gen = main.NewGenerator(config)
+		output = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	readReport := func() string {
+		data, err := ioutil.ReadFile(gen.Config.ReportPath)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("should write a Markdown report of all types and their dependencies", func() {
+		gen.Config.ReportPath = filepath.Join(dir, "types.md")
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(output.String()).To(ContainSubstring(`--report "types.md"`))
+		Expect(readReport()).To(Equal("# Types\n" +
+			"\n## client\n\n" +
+			"- **Factory:** `github.com/fgrosse/other/pkg.NewClient` (factory)\n" +
+			"- **Arguments:** `\"@logger\"`, `\"@?metrics\"`, `\"@logger\"`\n" +
+			"- **Dependencies:** `logger`, `metrics`\n" +
+			"- **Tags:** `http`\n" +
+			"\n## default_client\n\n" +
+			"- **Factory:** `@client` (alias)\n" +
+			"- **Arguments:** none\n" +
+			"- **Dependencies:** `client`\n" +
+			"\n## logger\n\n" +
+			"- **Factory:** `github.com/fgrosse/some/thing.NewLogger` (factory)\n" +
+			"- **Arguments:** `\"%log_level%\"`\n" +
+			"- **Dependencies:** none\n" +
+			"\n## metrics\n\n" +
+			"- **Factory:** `github.com/fgrosse/some/thing.Metrics` (struct)\n" +
+			"- **Arguments:** none\n" +
+			"- **Dependencies:** none\n",
+		))
+	})
+
+	It("should write a JSON report if the report path ends with .json", func() {
+		gen.Config.ReportPath = filepath.Join(dir, "types.json")
+		Expect(gen.Generate(strings.NewReader(input), output)).To(Succeed())
+		Expect(readReport()).To(MatchJSON(`{"types": [
+			{"id": "client", "kind": "factory", "factory": "github.com/fgrosse/other/pkg.NewClient", "arguments": ["\"@logger\"", "\"@?metrics\"", "\"@logger\""], "dependencies": ["logger", "metrics"], "tags": ["http"]},
+			{"id": "default_client", "kind": "alias", "factory": "@client", "arguments": [], "dependencies": ["client"]},
+			{"id": "logger", "kind": "factory", "factory": "github.com/fgrosse/some/thing.NewLogger", "arguments": ["\"%log_level%\""], "dependencies": []},
+			{"id": "metrics", "kind": "struct", "factory": "github.com/fgrosse/some/thing.Metrics", "arguments": [], "dependencies": []}
+		]}`))
+	})
+
+	It("should not write a report if the types are invalid", func() {
+		gen.Config.ReportPath = filepath.Join(dir, "types.md")
+		Expect(gen.Generate(strings.NewReader("types:\n    foo:\n        package: foo\n"), output)).NotTo(Succeed())
+		_, err := os.Stat(gen.Config.ReportPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+)
+
+// typeDefinitionLines scans the raw yaml input and returns the line numbers of all type definitions keyed by type ID.
+// This is done on the original input because the yaml parser does not expose the position of the parsed values
+// and the input sanitizing removes empty lines.
+func typeDefinitionLines(input []byte) map[string]int {
+	positions := map[string]int{}
+	topLevelIndent, typesIndent := -1, -1
+	inTypes := false
+
+	for i, line := range strings.Split(string(input), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+
+		indent := indentationWidth(line)
+		if topLevelIndent < 0 {
+			topLevelIndent = indent
+		}
+
+		if indent <= topLevelIndent {
+			inTypes = trimmed == "types:"
+			typesIndent = -1
+			continue
+		}
+
+		if !inTypes {
+			continue
+		}
+
+		if typesIndent < 0 {
+			typesIndent = indent
+		}
+
+		if indent == typesIndent && strings.Contains(trimmed, ":") {
+			typeID := strings.TrimSpace(trimmed[:strings.Index(trimmed, ":")])
+			typeID = strings.Trim(typeID, `"'`)
+			positions[typeID] = i + 1
+		}
+	}
+
+	return positions
+}
+
+func indentationWidth(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
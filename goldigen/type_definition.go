@@ -2,9 +2,14 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/fgrosse/goldi"
+	"gopkg.in/yaml.v2"
 )
 
 // A TypeDefinition holds all information necessary to register a type for a specific type ID
@@ -19,8 +24,100 @@ type TypeDefinition struct {
 	RawArguments      []interface{} `yaml:"arguments,omitempty"`
 	RawArgumentsShort []interface{} `yaml:"args,omitempty"`
 
+	// Platforms maps operating systems (e.g. "linux") or platforms (e.g. "linux/arm64") to the references of the
+	// types that should be used on them. See goldi.NewPlatformSwitchType.
+	Platforms map[string]string `yaml:"platforms,omitempty"`
+
+	// Inline defines the factory of the type directly in the configuration. See InlineFactory.
+	Inline *InlineFactory `yaml:"inline,omitempty"`
+
+	// NamedArguments can be used instead of positional arguments if the factory is defined in the output package.
+	NamedArguments map[string]interface{} `yaml:"named_arguments,omitempty"`
+
+	// Tags are registered together with the type so it can be retrieved via goldi.Container.GetByTag.
+	Tags []string `yaml:"tags,omitempty"`
+
 	// ForcePackageName can be used in case the full package does not correspond to the actual package name
 	ForcePackageName string `yaml:"package-name,omitempty"`
+
+	// SourceFile and SourceLine contain the position of this type definition in the yaml input.
+	SourceFile string `yaml:"-"`
+	SourceLine int    `yaml:"-"`
+
+	// isCompact is true if the type has been defined using the compact syntax and thus lacks a package.
+	isCompact bool
+}
+
+var compactTypeDefinition = regexp.MustCompile(`^@factory:\s*([A-Za-z_][A-Za-z0-9_]*)\s*\((.*)\)\s*$`)
+
+// ParseCompactTypeDefinition parses a type definition in the compact single line syntax.
+// The syntax looks like a call of a factory function that is defined in the output package,
+// e.g. "@factory:NewThing(%some_parameter%, @some_type, 42)".
+func ParseCompactTypeDefinition(s string) (TypeDefinition, error) {
+	s = strings.Replace(strings.TrimSpace(s), `\@`, `@`, -1)
+	matches := compactTypeDefinition.FindStringSubmatch(s)
+	if matches == nil {
+		return TypeDefinition{}, fmt.Errorf("invalid compact type definition %q: expected something like @factory:NewThing(arguments...)", s)
+	}
+
+	t := TypeDefinition{FactoryMethod: matches[1], isCompact: true}
+	for _, rawArgument := range splitCompactArguments(matches[2]) {
+		if strings.HasPrefix(rawArgument, "@") || strings.HasPrefix(rawArgument, "%") {
+			// type references and parameters are no valid plain yaml scalars
+			t.RawArguments = append(t.RawArguments, rawArgument)
+			continue
+		}
+
+		var argument interface{}
+		if err := yaml.Unmarshal([]byte(rawArgument), &argument); err != nil {
+			return TypeDefinition{}, fmt.Errorf("invalid argument %q in compact type definition %q: %s", rawArgument, s, err)
+		}
+		t.RawArguments = append(t.RawArguments, argument)
+	}
+
+	return t, nil
+}
+
+// splitCompactArguments splits the given arguments at all commas that are not inside quotes.
+func splitCompactArguments(s string) []string {
+	var arguments []string
+	var quoteChar rune
+	start := 0
+	for i, c := range s {
+		switch {
+		case quoteChar != 0 && c == quoteChar:
+			quoteChar = 0
+		case quoteChar == 0 && (c == '"' || c == '\''):
+			quoteChar = c
+		case quoteChar == 0 && c == ',':
+			arguments = append(arguments, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(s[start:]); last != "" || len(arguments) > 0 {
+		arguments = append(arguments, last)
+	}
+
+	return arguments
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface to support the compact type definition syntax
+// in addition to the regular yaml map.
+func (t *TypeDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var compact string
+	if err := unmarshal(&compact); err == nil {
+		parsed, err := ParseCompactTypeDefinition(compact)
+		if err != nil {
+			return err
+		}
+
+		*t = parsed
+		return nil
+	}
+
+	type plainTypeDefinition TypeDefinition
+	return unmarshal((*plainTypeDefinition)(t))
 }
 
 // Validate checks if this type definition contains all required fields
@@ -29,7 +126,17 @@ func (t *TypeDefinition) Validate(typeID string) error {
 		return t.validateTypeAlias(typeID)
 	}
 
-	if t.FuncName == "" || t.FuncName[0] != '@' {
+	if len(t.Platforms) > 0 {
+		return t.validatePlatformSwitch(typeID)
+	}
+
+	if t.Inline != nil {
+		if err := t.validateInlineFactory(typeID); err != nil {
+			return err
+		}
+	}
+
+	if t.Inline == nil && (t.FuncName == "" || t.FuncName[0] != '@') {
 		if !(t.FactoryMethod != "" && t.FactoryMethod[0] == '@' && strings.Contains(t.FactoryMethod, "::")) {
 			if err := t.requireField("package", t.Package, typeID); err != nil {
 				return err
@@ -37,7 +144,7 @@ func (t *TypeDefinition) Validate(typeID string) error {
 		}
 	}
 
-	if t.TypeName == "" && t.FuncName == "" {
+	if t.TypeName == "" && t.FuncName == "" && t.Inline == nil {
 		if err := t.requireField("factory", t.FactoryMethod, typeID); err != nil {
 			return err
 		}
@@ -53,6 +160,32 @@ func (t *TypeDefinition) Validate(typeID string) error {
 		}
 	}
 
+	if len(t.NamedArguments) > 0 {
+		if len(t.RawArguments) != 0 || len(t.RawArgumentsShort) != 0 {
+			return fmt.Errorf("type definition of %q can not have both named and positional arguments", typeID)
+		}
+
+		if t.FactoryMethod == "" || t.FactoryMethod[0] == '@' {
+			return fmt.Errorf("type definition of %q uses named arguments which are only supported for factory functions", typeID)
+		}
+	}
+
+	for _, arg := range t.allArguments() {
+		if err := validateConstantArgument(arg); err != nil {
+			return fmt.Errorf("type definition of %q contains an invalid constant: %s", typeID, err)
+		}
+
+		if err := validateCastArgument(arg); err != nil {
+			return fmt.Errorf("type definition of %q contains an invalid cast: %s", typeID, err)
+		}
+	}
+
+	for _, tag := range t.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("type definition of %q contains an empty tag", typeID)
+		}
+	}
+
 	if len(t.Configurator) > 0 {
 		if len(t.Configurator) != 2 {
 			return fmt.Errorf("configurator of type %q needs exactly 2 arguments but got %d", typeID, len(t.Configurator))
@@ -87,13 +220,31 @@ func (t *TypeDefinition) validateTypeAlias(typeID string) error {
 		return fmt.Errorf("type alias %q must not define a func", typeID)
 	}
 
-	if len(t.RawArguments) != 0 {
+	if len(t.RawArguments) != 0 || len(t.NamedArguments) != 0 {
 		return fmt.Errorf("type alias %q must not contain arguments", typeID)
 	}
 
 	return nil
 }
 
+func (t *TypeDefinition) validatePlatformSwitch(typeID string) error {
+	if t.FactoryMethod != "" || t.Package != "" || t.TypeName != "" || t.FuncName != "" {
+		return fmt.Errorf("platform switch type %q must not define a package, type, factory or func", typeID)
+	}
+
+	if len(t.RawArguments) != 0 || len(t.RawArgumentsShort) != 0 || len(t.NamedArguments) != 0 {
+		return fmt.Errorf("platform switch type %q must not contain arguments", typeID)
+	}
+
+	for platform, reference := range t.Platforms {
+		if goldi.IsTypeReference(reference) == false {
+			return fmt.Errorf("platform %q of type %q is no valid type reference (does not start with @)", platform, typeID)
+		}
+	}
+
+	return nil
+}
+
 func (t *TypeDefinition) requireField(fieldName, value, typeID string) error {
 	if strings.TrimSpace(value) == "" {
 		return fmt.Errorf("type definition of %q is missing the required %q key", typeID, fieldName)
@@ -113,16 +264,279 @@ func (t *TypeDefinition) PackageName() string {
 	return packageParts[len(packageParts)-1]
 }
 
+// ResolveNamedArguments converts the named arguments of this type definition into positional arguments
+// using the parameter names of the given factory signature.
+// An argument for a variadic parameter may be given as list which will be expanded.
+func (t *TypeDefinition) ResolveNamedArguments(typeID string, signature *FactorySignature) error {
+	for name := range t.NamedArguments {
+		if !containsString(signature.ParameterNames, name) {
+			return fmt.Errorf("named argument %q of type %q does not match any parameter of %s", name, typeID, t.FactoryMethod)
+		}
+	}
+
+	var arguments []interface{}
+	for i, name := range signature.ParameterNames {
+		value, isSet := t.NamedArguments[name]
+		isVariadic := signature.IsVariadic && i == len(signature.ParameterNames)-1
+		switch {
+		case !isSet && isVariadic:
+			continue
+		case !isSet:
+			return fmt.Errorf("type %q is missing the named argument %q for %s", typeID, name, t.FactoryMethod)
+		}
+
+		if list, isList := value.([]interface{}); isList && isVariadic {
+			arguments = append(arguments, list...)
+		} else {
+			arguments = append(arguments, value)
+		}
+	}
+
+	t.RawArguments = arguments
+	t.NamedArguments = nil
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// References returns the IDs of all types this type definition depends on.
+// This includes type references in the arguments, the configurator, the aliased type and referenced factories or functions.
+func (t *TypeDefinition) References() []string {
+	var references []string
+	addReference := func(s string) {
+		if alternatives := goldi.FallbackAlternatives(s); alternatives != nil {
+			for _, alternative := range alternatives {
+				if goldi.IsTypeReference(alternative) {
+					references = append(references, goldi.NewTypeID(alternative).ID)
+				}
+			}
+		} else if goldi.IsTypeReference(s) {
+			references = append(references, goldi.NewTypeID(s).ID)
+		}
+	}
+
+	for _, arg := range append(t.RawArguments, t.RawArgumentsShort...) {
+		if s, isString := arg.(string); isString {
+			addReference(s)
+		}
+	}
+
+	for _, arg := range t.NamedArguments {
+		if s, isString := arg.(string); isString {
+			addReference(s)
+		}
+	}
+
+	if len(t.Configurator) > 0 {
+		addReference(t.Configurator[0])
+	}
+
+	if t.AliasForType != "" {
+		addReference("@" + strings.TrimPrefix(t.AliasForType, "@"))
+	}
+
+	for _, reference := range t.Platforms {
+		addReference(reference)
+	}
+
+	addReference(t.FactoryMethod)
+	addReference(t.FuncName)
+	return references
+}
+
 func (t *TypeDefinition) Arguments() []string {
 	rawArgs := append(t.RawArguments, t.RawArgumentsShort...)
 	arguments := make([]string, len(rawArgs))
 	for i, arg := range rawArgs {
-		switch a := arg.(type) {
-		case string:
-			arguments[i] = fmt.Sprintf(`"%s"`, a)
-		default:
-			arguments[i] = fmt.Sprintf("%v", a)
+		if code, _, isRendered := renderArgument(arg); isRendered {
+			arguments[i] = code
+			continue
+		}
+
+		if constant, _, isConstant := constantArgument(arg); isConstant {
+			arguments[i] = constant
+			continue
+		}
+
+		if value, typeName, isCast := castArgument(arg); isCast {
+			arguments[i] = castCode(value, typeName)
+			continue
 		}
+
+		if inlined, isInlined := arg.(inlinedParameter); isInlined {
+			arguments[i] = inlined.code()
+			continue
+		}
+
+		arguments[i] = literalCode(arg)
+	}
+	return arguments
+}
+
+func literalCode(arg interface{}) string {
+	switch a := arg.(type) {
+	case string:
+		return fmt.Sprintf(`"%s"`, a)
+	default:
+		return fmt.Sprintf("%v", a)
+	}
+}
+
+// castCode converts literal values directly into the type of the cast while all strings (e.g. parameters)
+// are wrapped using goldi.Cast so they are converted when the type is generated.
+func castCode(value interface{}, typeName string) string {
+	if _, isString := value.(string); isString {
+		return fmt.Sprintf("goldi.Cast(%s, %q)", literalCode(value), typeName)
+	}
+
+	return fmt.Sprintf("%s(%s)", typeName, literalCode(value))
+}
+
+// ConstantPackages returns the packages of all constant arguments and of all arguments that are rendered
+// by a custom ArgumentRenderer of this type definition.
+func (t *TypeDefinition) ConstantPackages() []string {
+	var packages []string
+	for _, arg := range t.allArguments() {
+		if _, renderedPackages, isRendered := renderArgument(arg); isRendered {
+			packages = append(packages, renderedPackages...)
+			continue
+		}
+
+		if _, pkg, isConstant := constantArgument(arg); isConstant && pkg != "" {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// allArguments returns the positional and named arguments of this type definition.
+func (t *TypeDefinition) allArguments() []interface{} {
+	arguments := append([]interface{}{}, t.RawArguments...)
+	arguments = append(arguments, t.RawArgumentsShort...)
+	for _, arg := range t.NamedArguments {
+		arguments = append(arguments, arg)
 	}
 	return arguments
 }
+
+// constantArgument checks if the given argument refers to a (typed) constant.
+// Such arguments are maps with a "const" key (e.g. "logrus.InfoLevel") and an optional "package" key.
+// Constants are rendered as unquoted identifiers and their package is imported in the generated code.
+func constantArgument(arg interface{}) (constant, pkg string, isConstant bool) {
+	m, isMap := arg.(map[interface{}]interface{})
+	if isMap == false {
+		return "", "", false
+	}
+
+	constant, isConstant = m["const"].(string)
+	pkg, _ = m["package"].(string)
+	return constant, pkg, isConstant
+}
+
+// castArgument checks if the given argument must be converted into a specific type.
+// Such arguments are maps with a "value" and a "cast" key (e.g. "int64").
+func castArgument(arg interface{}) (value interface{}, typeName string, isCast bool) {
+	m, isMap := arg.(map[interface{}]interface{})
+	if isMap == false {
+		return nil, "", false
+	}
+
+	typeName, isCast = m["cast"].(string)
+	return m["value"], typeName, isCast
+}
+
+func validateCastArgument(arg interface{}) error {
+	m, isMap := arg.(map[interface{}]interface{})
+	if isMap == false {
+		return nil
+	}
+
+	if _, hasCast := m["cast"]; hasCast == false {
+		return nil
+	}
+
+	for key := range m {
+		if key != "cast" && key != "value" {
+			return fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	typeName, _ := m["cast"].(string)
+	castType, isKnown := goldi.CastType(typeName)
+	if isKnown == false {
+		return fmt.Errorf("can not cast to unknown type %q", typeName)
+	}
+
+	switch value := m["value"].(type) {
+	case string:
+		return nil
+	case bool, int, float64:
+		if isConvertibleLiteral(value, castType) == false {
+			return fmt.Errorf("the value %v can not be converted to %s", value, typeName)
+		}
+		return nil
+	default:
+		return fmt.Errorf(`the "value" key must be a string, number or boolean`)
+	}
+}
+
+// isConvertibleLiteral returns true if the given boolean or numeric literal can be converted to the given type
+// in the generated code. Casts of literals are rendered as type conversions like "int64(42)" which only compile
+// if the constant is representable by the type, so e.g. "int(2.5)", "uint8(300)" or "bool(1)" are rejected.
+func isConvertibleLiteral(value interface{}, t reflect.Type) bool {
+	v := reflect.New(t).Elem()
+	switch value := value.(type) {
+	case bool:
+		return t.Kind() == reflect.Bool
+	case int:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.OverflowInt(int64(value)) == false
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return value >= 0 && v.OverflowUint(uint64(value)) == false
+		case reflect.Float32, reflect.Float64:
+			return true
+		}
+	case float64:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return value == math.Trunc(value) && value >= math.MinInt64 && value < math.MaxInt64 && v.OverflowInt(int64(value)) == false
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return value == math.Trunc(value) && value >= 0 && value < math.MaxUint64 && v.OverflowUint(uint64(value)) == false
+		case reflect.Float32, reflect.Float64:
+			return v.OverflowFloat(value) == false
+		}
+	}
+
+	return false
+}
+
+func validateConstantArgument(arg interface{}) error {
+	m, isMap := arg.(map[interface{}]interface{})
+	if isMap == false {
+		return nil
+	}
+
+	if _, hasConstant := m["const"]; hasConstant == false {
+		return nil
+	}
+
+	for key, value := range m {
+		if key != "const" && key != "package" {
+			return fmt.Errorf("unknown key %q", key)
+		}
+
+		if s, isString := value.(string); isString == false || strings.TrimSpace(s) == "" {
+			return fmt.Errorf("the %q key must be a non empty string", key)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package main_test
 
 import (
+	"fmt"
+
 	"github.com/fgrosse/goldi/goldigen"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -64,6 +66,25 @@ var _ = Describe("TypeDefinition", func() {
 			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" is missing the required "factory" key`))
 		})
 
+		It("should return an error if the definition contains both named and positional arguments", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				FactoryMethod:  "NewBaz",
+				RawArguments:   []interface{}{"test"},
+				NamedArguments: map[string]interface{}{"name": "test"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" can not have both named and positional arguments`))
+		})
+
+		It("should return an error if the definition contains named arguments but no factory function", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				TypeName:       "Baz",
+				NamedArguments: map[string]interface{}{"name": "test"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" uses named arguments which are only supported for factory functions`))
+		})
+
 		It("should return an error if the configurator does not have exactly two arguments", func() {
 			t := main.TypeDefinition{
 				Package: "foo/bar", TypeName: "Blup",
@@ -105,6 +126,149 @@ var _ = Describe("TypeDefinition", func() {
 			Expect(t.Validate("foobar")).To(Succeed())
 		})
 
+		It("should return an error if a constant argument contains unknown keys", func() {
+			t := main.TypeDefinition{
+				Package: "foo/bar", FactoryMethod: "NewBlup",
+				RawArguments: []interface{}{
+					map[interface{}]interface{}{"const": "log.InfoLevel", "type": "log.Level"},
+				},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains an invalid constant: unknown key "type"`))
+		})
+
+		It("should return an error if a constant is empty", func() {
+			t := main.TypeDefinition{
+				Package: "foo/bar", FactoryMethod: "NewBlup",
+				RawArguments: []interface{}{
+					map[interface{}]interface{}{"const": ""},
+				},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains an invalid constant: the "const" key must be a non empty string`))
+		})
+
+		It("should return an error if a cast argument contains unknown keys", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments:  []interface{}{map[interface{}]interface{}{"cast": "int64", "value": 42, "foo": "bar"}},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains an invalid cast: unknown key "foo"`))
+		})
+
+		It("should return an error if a cast argument uses an unknown type", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments:  []interface{}{map[interface{}]interface{}{"cast": "complex128", "value": 42}},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains an invalid cast: can not cast to unknown type "complex128"`))
+		})
+
+		It("should return an error if a cast literal can not be converted to the type of the cast", func() {
+			for _, c := range []struct {
+				typeName string
+				value    interface{}
+			}{{"int", 2.5}, {"bool", 1}, {"int", true}, {"uint8", 300}, {"uint", -1}, {"string", 42}} {
+				t := main.TypeDefinition{
+					Package:       "foo/bar",
+					FactoryMethod: "NewBaz",
+					RawArguments:  []interface{}{map[interface{}]interface{}{"cast": c.typeName, "value": c.value}},
+				}
+				Expect(t.Validate("foobar")).To(MatchError(fmt.Sprintf(
+					`type definition of "foobar" contains an invalid cast: the value %v can not be converted to %s`, c.value, c.typeName,
+				)))
+			}
+		})
+
+		It("should accept cast literals that can be converted to the type of the cast", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments: []interface{}{
+					map[interface{}]interface{}{"cast": "int", "value": 2.0},
+					map[interface{}]interface{}{"cast": "float32", "value": 42},
+					map[interface{}]interface{}{"cast": "bool", "value": true},
+					map[interface{}]interface{}{"cast": "int8", "value": "%level%"},
+				},
+			}
+			Expect(t.Validate("foobar")).To(Succeed())
+		})
+
+		It("should return an error if a tag is empty", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				Tags:          []string{"listener", " "},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`type definition of "foobar" contains an empty tag`))
+		})
+
+		It("should return an error if a platform switch type defines a factory", func() {
+			t := main.TypeDefinition{
+				Package: "foo/bar", FactoryMethod: "NewBlup",
+				Platforms: map[string]string{"linux": "@foo"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`platform switch type "foobar" must not define a package, type, factory or func`))
+		})
+
+		It("should return an error if a platform does not reference a type", func() {
+			t := main.TypeDefinition{Platforms: map[string]string{"linux": "foo"}}
+			Expect(t.Validate("foobar")).To(MatchError(`platform "linux" of type "foobar" is no valid type reference (does not start with @)`))
+		})
+
+		It("should not return an error for valid platform switch types", func() {
+			t := main.TypeDefinition{Platforms: map[string]string{"linux": "@foo", "default": "@bar"}}
+			Expect(t.Validate("foobar")).To(Succeed())
+		})
+
+		It("should not return an error for valid inline types", func() {
+			t := main.TypeDefinition{
+				Inline:       &main.InlineFactory{Params: "name string", Returns: "*strings.Reader", Body: `return strings.NewReader("Hello " + name)`},
+				RawArguments: []interface{}{"%name%"},
+			}
+			Expect(t.Validate("foobar")).To(Succeed())
+		})
+
+		It("should return an error if an inline type is no valid go code", func() {
+			t := main.TypeDefinition{Inline: &main.InlineFactory{Returns: "*strings.Reader", Body: `return strings.NewReader("foo`}}
+			Expect(t.Validate("foobar")).To(MatchError(HavePrefix(`inline type "foobar" is no valid go code: `)))
+		})
+
+		It("should return an error if an inline type has no body", func() {
+			t := main.TypeDefinition{Inline: &main.InlineFactory{Returns: "*strings.Reader"}}
+			Expect(t.Validate("foobar")).To(MatchError(`inline type "foobar" is missing the required "body" key`))
+		})
+
+		It("should return an error if the arguments of an inline type do not match its parameters", func() {
+			t := main.TypeDefinition{
+				Inline:       &main.InlineFactory{Params: "a, b string", Returns: "*strings.Reader", Body: "return strings.NewReader(a + b)"},
+				RawArguments: []interface{}{"foo"},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`inline type "foobar" has 2 parameters but got 1 arguments`))
+		})
+
+		It("should return an error if an inline type does not return a pointer, interface or function", func() {
+			for _, returns := range []string{"string", "int", "[]byte", "map[string]int", "Config", "struct{}"} {
+				t := main.TypeDefinition{Inline: &main.InlineFactory{Returns: returns, Body: "return nil"}}
+				Expect(t.Validate("foobar")).To(MatchError(`inline type "foobar" must return a pointer, interface or function but returns ` + returns))
+			}
+		})
+
+		It("should accept inline types that return a pointer, interface or function", func() {
+			for _, returns := range []string{"*Config", "*http.Client", "io.Writer", "error", "interface{ Run() }", "func() string"} {
+				t := main.TypeDefinition{Inline: &main.InlineFactory{Returns: returns, Body: "return nil"}}
+				Expect(t.Validate("foobar")).To(Succeed(), returns)
+			}
+		})
+
+		It("should return an error if an inline type defines a factory", func() {
+			t := main.TypeDefinition{
+				Package: "foo/bar", FactoryMethod: "NewBlup",
+				Inline: &main.InlineFactory{Returns: "*strings.Reader", Body: `return strings.NewReader("foo")`},
+			}
+			Expect(t.Validate("foobar")).To(MatchError(`inline type "foobar" must not define a type, factory, func or platforms`))
+		})
+
 		It("should not return an error if a proxy type does not contain a package name", func() {
 			t := main.TypeDefinition{
 				FactoryMethod: "@blup::DoStuff",
@@ -134,7 +298,104 @@ var _ = Describe("TypeDefinition", func() {
 		})
 	})
 
+	Describe("ParseCompactTypeDefinition", func() {
+		It("should parse the factory method and its arguments", func() {
+			t, err := main.ParseCompactTypeDefinition(`@factory:NewThing(%a%, @b, "Hello, World", 42, true)`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.FactoryMethod).To(Equal("NewThing"))
+			Expect(t.RawArguments).To(Equal([]interface{}{"%a%", "@b", "Hello, World", 42, true}))
+		})
+
+		It("should parse factories without arguments", func() {
+			t, err := main.ParseCompactTypeDefinition(`@factory:NewThing()`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.FactoryMethod).To(Equal("NewThing"))
+			Expect(t.RawArguments).To(BeEmpty())
+		})
+
+		It("should accept escaped type references", func() {
+			t, err := main.ParseCompactTypeDefinition(`\@factory:NewThing(\@b)`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.RawArguments).To(Equal([]interface{}{"@b"}))
+		})
+
+		It("should return an error if the definition is invalid", func() {
+			_, err := main.ParseCompactTypeDefinition(`NewThing(%a%)`)
+			Expect(err).To(MatchError(`invalid compact type definition "NewThing(%a%)": expected something like @factory:NewThing(arguments...)`))
+		})
+	})
+
+	Describe("ResolveNamedArguments", func() {
+		signature := &main.FactorySignature{ParameterNames: []string{"name", "timeout", "tags"}, IsVariadic: true}
+
+		It("should convert the named arguments into positional arguments", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				FactoryMethod:  "NewBaz",
+				NamedArguments: map[string]interface{}{"tags": []interface{}{"a", "b"}, "timeout": 42, "name": "%name%"},
+			}
+			Expect(t.ResolveNamedArguments("foobar", signature)).To(Succeed())
+			Expect(t.Arguments()).To(Equal([]string{`"%name%"`, `42`, `"a"`, `"b"`}))
+		})
+
+		It("should allow to omit the variadic argument", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				FactoryMethod:  "NewBaz",
+				NamedArguments: map[string]interface{}{"timeout": 42, "name": "foo"},
+			}
+			Expect(t.ResolveNamedArguments("foobar", signature)).To(Succeed())
+			Expect(t.Arguments()).To(Equal([]string{`"foo"`, `42`}))
+		})
+
+		It("should return an error if a named argument does not match any parameter", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				FactoryMethod:  "NewBaz",
+				NamedArguments: map[string]interface{}{"timeout": 42, "name": "foo", "color": "red"},
+			}
+			Expect(t.ResolveNamedArguments("foobar", signature)).To(MatchError(`named argument "color" of type "foobar" does not match any parameter of NewBaz`))
+		})
+
+		It("should return an error if a named argument is missing", func() {
+			t := main.TypeDefinition{
+				Package:        "foo/bar",
+				FactoryMethod:  "NewBaz",
+				NamedArguments: map[string]interface{}{"name": "foo"},
+			}
+			Expect(t.ResolveNamedArguments("foobar", signature)).To(MatchError(`type "foobar" is missing the named argument "timeout" for NewBaz`))
+		})
+	})
+
 	Describe("Arguments", func() {
+		It("should return constants as unquoted identifiers", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments: []interface{}{
+					map[interface{}]interface{}{"const": "logrus.InfoLevel", "package": "github.com/sirupsen/logrus"},
+					map[interface{}]interface{}{"const": "DefaultTimeout"},
+				},
+			}
+
+			Expect(t.Arguments()).To(Equal([]string{"logrus.InfoLevel", "DefaultTimeout"}))
+			Expect(t.ConstantPackages()).To(Equal([]string{"github.com/sirupsen/logrus"}))
+		})
+
+		It("should convert cast arguments into the type of the cast", func() {
+			t := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments: []interface{}{
+					map[interface{}]interface{}{"cast": "int64", "value": 42},
+					map[interface{}]interface{}{"cast": "float32", "value": 2.5},
+					map[interface{}]interface{}{"cast": "int64", "value": "%max_connections%"},
+				},
+			}
+
+			Expect(t.Arguments()).To(Equal([]string{"int64(42)", "float32(2.5)", `goldi.Cast("%max_connections%", "int64")`}))
+		})
+
 		It("should return all parameters such that they can be used in go code directly", func() {
 			t := main.TypeDefinition{
 				Package:       "foo/bar",
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -17,6 +18,10 @@ func FactoryCode(t TypeDefinition, outputPackageName string) string {
 		typeFactoryCode = funcReferenceTypeCode(t)
 	case t.AliasForType != "":
 		typeFactoryCode = aliasTypeCode(t)
+	case len(t.Platforms) > 0:
+		typeFactoryCode = platformSwitchTypeCode(t)
+	case t.Inline != nil:
+		typeFactoryCode = inlineTypeCode(t)
 	case t.FactoryMethod != "" && t.FactoryMethod[0] == '@' && strings.Contains(t.FactoryMethod, "::"):
 		typeFactoryCode = proxyTypeCode(t)
 	case t.FactoryMethod != "":
@@ -37,6 +42,30 @@ func FactoryCode(t TypeDefinition, outputPackageName string) string {
 	return typeFactoryCode
 }
 
+// IsMockable returns whether the type can be replaced by a mock in mock mode.
+// Function types, aliases, platform switches, inline types and func reference types are not mocked.
+func IsMockable(t TypeDefinition) bool {
+	if t.FuncName != "" || t.AliasForType != "" || len(t.Platforms) > 0 || t.Inline != nil {
+		return false
+	}
+
+	return t.FactoryMethod == "" || t.FactoryMethod[0] != '@'
+}
+
+// MockFactoryCode returns the go code that is necessary to register a mock of this type.
+// The mock constructor is determined by replacing {TypeName} in the given pattern with the type name or,
+// if no type name was defined, with the name of the factory function without the "New" prefix.
+// Configurators are not applied to mocks.
+func MockFactoryCode(t TypeDefinition, pattern string) string {
+	typeName := t.TypeName
+	if typeName == "" {
+		typeName = strings.TrimPrefix(t.FactoryMethod, "New")
+	}
+
+	constructor := strings.Replace(pattern, "{TypeName}", typeName, -1)
+	return fmt.Sprintf("goldi.NewType(%s)", constructor)
+}
+
 func funcTypeCode(t TypeDefinition, outputPackageName string) string {
 	funcName := t.FuncName
 	if t.Package != outputPackageName {
@@ -59,6 +88,27 @@ func aliasTypeCode(t TypeDefinition) string {
 	return fmt.Sprintf("goldi.NewAliasType(%q)", alias)
 }
 
+func platformSwitchTypeCode(t TypeDefinition) string {
+	platforms := make([]string, 0, len(t.Platforms))
+	for platform := range t.Platforms {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	entries := make([]string, len(platforms))
+	for i, platform := range platforms {
+		entries[i] = fmt.Sprintf("%q: %q", platform, strings.TrimPrefix(t.Platforms[platform], "@"))
+	}
+
+	return fmt.Sprintf("goldi.NewPlatformSwitchType(map[string]string{%s})", strings.Join(entries, ", "))
+}
+
+func inlineTypeCode(t TypeDefinition) string {
+	arguments := []string{t.Inline.Code()}
+	arguments = append(arguments, t.Arguments()...)
+	return fmt.Sprintf("goldi.NewType(%s)", strings.Join(arguments, ", "))
+}
+
 func factoryTypeCode(t TypeDefinition, outputPackageName string) string {
 	factoryMethod := t.FactoryMethod
 	if t.Package != outputPackageName {
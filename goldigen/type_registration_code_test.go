@@ -58,6 +58,51 @@ var _ = Describe("FactoryCode", func() {
 		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewProxyType("logger_provider", "GetLogger", "foo", "%bar%", 42)`))
 	})
 
+	It("should return the golang code to register a platform switch type", func() {
+		typeDef := main.TypeDefinition{
+			Platforms: map[string]string{"linux": "@inotify_watcher", "default": "@polling_watcher"},
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewPlatformSwitchType(map[string]string{"default": "polling_watcher", "linux": "inotify_watcher"})`))
+	})
+
+	It("should return the golang code to register an inline type", func() {
+		typeDef := main.TypeDefinition{
+			Inline:       &main.InlineFactory{Params: "name string", Returns: "*strings.Reader", Body: `return strings.NewReader("Hello " + name)`},
+			RawArguments: []interface{}{"%name%"},
+		}
+		Expect(main.FactoryCode(typeDef, "some/package/lib")).To(Equal(`goldi.NewType(func(name string) *strings.Reader { return strings.NewReader("Hello " + name) }, "%name%")`))
+	})
+
+	Describe("MockFactoryCode", func() {
+		It("should return the golang code to register a mock of a struct type", func() {
+			typeDef := main.TypeDefinition{
+				Package:  "foo/bar",
+				TypeName: "Baz",
+			}
+			Expect(main.MockFactoryCode(typeDef, "mocks.New{TypeName}")).To(Equal(`goldi.NewType(mocks.NewBaz)`))
+		})
+
+		It("should use the factory name if no type name was given", func() {
+			typeDef := main.TypeDefinition{
+				Package:       "foo/bar",
+				FactoryMethod: "NewBaz",
+				RawArguments:  []interface{}{"foo", "%bar%", 42},
+			}
+			Expect(main.MockFactoryCode(typeDef, "NewMock{TypeName}")).To(Equal(`goldi.NewType(NewMockBaz)`))
+		})
+	})
+
+	Describe("IsMockable", func() {
+		It("should not mock functions, aliases and proxy types", func() {
+			Expect(main.IsMockable(main.TypeDefinition{Package: "foo/bar", FuncName: "DoFoo"})).To(BeFalse())
+			Expect(main.IsMockable(main.TypeDefinition{AliasForType: "@foo"})).To(BeFalse())
+			Expect(main.IsMockable(main.TypeDefinition{FactoryMethod: "@logger_provider::GetLogger"})).To(BeFalse())
+			Expect(main.IsMockable(main.TypeDefinition{Platforms: map[string]string{"linux": "@foo"}})).To(BeFalse())
+			Expect(main.IsMockable(main.TypeDefinition{Inline: &main.InlineFactory{Returns: "*strings.Reader", Body: `return strings.NewReader("foo")`}})).To(BeFalse())
+			Expect(main.IsMockable(main.TypeDefinition{Package: "foo/bar", FactoryMethod: "NewBaz"})).To(BeTrue())
+		})
+	})
+
 	It("should panic when type definition is not configured", func() {
 		typeDef := main.TypeDefinition{}
 		Expect(func() { main.FactoryCode(typeDef, "some/package/lib") }).To(Panic())
@@ -10,8 +10,13 @@ import (
 // The TypesConfiguration is the struct that holds the complete dependency injection configuration
 // as parsed from a yaml file
 type TypesConfiguration struct {
-	Parameters map[string]string         `yaml:"parameters,omitempty"`
+	Imports    []string                  `yaml:"import,omitempty"`
+	Parameters map[string]interface{}    `yaml:"parameters,omitempty"`
 	Types      map[string]TypeDefinition `yaml:"types,omitempty"`
+
+	// Templates can be used to define yaml anchors of common type definition fields which are merged into the
+	// type definitions via yaml merge keys (e.g. "<<: *http_client"). The templates themselves are not registered.
+	Templates map[string]interface{} `yaml:"templates,omitempty"`
 }
 
 // Validate checks if all type definitions of this configuration are valid
@@ -26,9 +31,110 @@ func (c *TypesConfiguration) Validate() (err error) {
 			return err
 		}
 	}
+
+	for name, value := range c.Parameters {
+		if _, err = ParameterCode(value); err != nil {
+			return fmt.Errorf("parameter %q is invalid: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// Merge adds all parameters and types of the other configuration to this configuration.
+// It returns an error if a parameter or type is defined in both configurations.
+func (c *TypesConfiguration) Merge(other *TypesConfiguration) error {
+	if c.Parameters == nil && len(other.Parameters) > 0 {
+		c.Parameters = map[string]interface{}{}
+	}
+
+	for name, value := range other.Parameters {
+		if _, isDefined := c.Parameters[name]; isDefined {
+			return fmt.Errorf("parameter %q has been defined multiple times", name)
+		}
+		c.Parameters[name] = value
+	}
+
+	if c.Types == nil && len(other.Types) > 0 {
+		c.Types = map[string]TypeDefinition{}
+	}
+
+	for typeID, typeDef := range other.Types {
+		if _, isDefined := c.Types[typeID]; isDefined {
+			return fmt.Errorf("type %q has been defined multiple times", typeID)
+		}
+		c.Types[typeID] = typeDef
+	}
+
 	return nil
 }
 
+// TypeIDs returns all type IDs of this configuration in alphabetical order.
+func (c *TypesConfiguration) TypeIDs() []string {
+	typeIDs := make([]string, 0, len(c.Types))
+	for typeID := range c.Types {
+		typeIDs = append(typeIDs, typeID)
+	}
+
+	sort.Strings(typeIDs)
+	return typeIDs
+}
+
+// DependencyOrder returns all type IDs of this configuration sorted such that each type comes after all the types it references.
+// Types that do not depend on each other are sorted alphabetically.
+// References to types that are not defined in this configuration are ignored.
+// DependencyOrder returns an error if the types contain circular references.
+func (c *TypesConfiguration) DependencyOrder() ([]string, error) {
+	dependencies := map[string]goldi.StringSet{}
+	for typeID, typeDef := range c.Types {
+		dependencies[typeID] = goldi.StringSet{}
+		for _, reference := range typeDef.References() {
+			if _, isDefined := c.Types[reference]; isDefined {
+				dependencies[typeID].Set(reference)
+			}
+		}
+	}
+
+	var ordered []string
+	done := goldi.StringSet{}
+	for len(ordered) < len(c.Types) {
+		var next string
+		for _, typeID := range c.TypeIDs() {
+			if done.Contains(typeID) {
+				continue
+			}
+
+			if c.allContained(dependencies[typeID], done) {
+				next = typeID
+				break
+			}
+		}
+
+		if next == "" {
+			var remaining []string
+			for _, typeID := range c.TypeIDs() {
+				if !done.Contains(typeID) {
+					remaining = append(remaining, typeID)
+				}
+			}
+			return nil, fmt.Errorf("can not determine dependency order: circular references between %q", remaining)
+		}
+
+		ordered = append(ordered, next)
+		done.Set(next)
+	}
+
+	return ordered, nil
+}
+
+func (c *TypesConfiguration) allContained(values, set goldi.StringSet) bool {
+	for value := range values {
+		if !set.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
 // Packages returns an alphabetically ordered list of unique package names that are referenced by this type configuration.
 func (c *TypesConfiguration) Packages(additionalPackages ...string) []string {
 	packages := additionalPackages
@@ -38,12 +144,14 @@ func (c *TypesConfiguration) Packages(additionalPackages ...string) []string {
 	}
 
 	for _, typeDef := range c.Types {
-		if seenPackages.Contains(typeDef.Package) {
-			continue
-		}
+		for _, pkg := range append([]string{typeDef.Package}, typeDef.ConstantPackages()...) {
+			if seenPackages.Contains(pkg) {
+				continue
+			}
 
-		seenPackages.Set(typeDef.Package)
-		packages = append(packages, typeDef.Package)
+			seenPackages.Set(pkg)
+			packages = append(packages, pkg)
+		}
 	}
 
 	sort.Strings(packages)
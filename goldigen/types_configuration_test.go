@@ -82,6 +82,60 @@ var _ = Describe("TypesConfiguration", func() {
 			}
 			Expect(c.Validate()).To(MatchError(`type alias "foo" must not contain arguments`))
 		})
+
+		It("should return an error if a parameter value is not supported", func() {
+			c := main.TypesConfiguration{
+				Parameters: map[string]interface{}{
+					"foo": map[interface{}]interface{}{"bar": "baz"},
+				},
+				Types: map[string]main.TypeDefinition{
+					"foo": {Package: "foo/bar", TypeName: "Baz"},
+				},
+			}
+			Expect(c.Validate()).To(MatchError(`parameter "foo" is invalid: unsupported parameter value map[bar:baz] (type map[interface {}]interface {})`))
+		})
+	})
+
+	Describe("Merge", func() {
+		It("should add the parameters and types of the other configuration", func() {
+			c := main.TypesConfiguration{}
+			other := &main.TypesConfiguration{
+				Parameters: map[string]interface{}{"foo": "bar"},
+				Types:      map[string]main.TypeDefinition{"baz": {Package: "foo/bar", TypeName: "Baz"}},
+			}
+			Expect(c.Merge(other)).To(Succeed())
+			Expect(c.Parameters).To(Equal(other.Parameters))
+			Expect(c.Types).To(Equal(other.Types))
+		})
+
+		It("should return an error if a parameter is defined in both configurations", func() {
+			c := main.TypesConfiguration{Parameters: map[string]interface{}{"foo": "bar"}}
+			other := &main.TypesConfiguration{Parameters: map[string]interface{}{"foo": "baz"}}
+			Expect(c.Merge(other)).To(MatchError(`parameter "foo" has been defined multiple times`))
+		})
+	})
+
+	Describe("DependencyOrder", func() {
+		It("should sort the types such that referenced types come first", func() {
+			c := main.TypesConfiguration{Types: map[string]main.TypeDefinition{
+				"a": {Package: "foo/bar", FactoryMethod: "NewA", RawArguments: []interface{}{"@c", "%param%"}},
+				"b": {AliasForType: "a"},
+				"c": {Package: "foo/bar", FactoryMethod: "NewC", Configurator: []string{"@d", "Configure"}},
+				"d": {Package: "foo/bar", FactoryMethod: "NewD", RawArguments: []interface{}{"@undefined"}},
+				"e": {Package: "foo/bar", TypeName: "E"},
+			}}
+			Expect(c.DependencyOrder()).To(Equal([]string{"d", "c", "a", "b", "e"}))
+		})
+
+		It("should return an error if the types contain circular references", func() {
+			c := main.TypesConfiguration{Types: map[string]main.TypeDefinition{
+				"a": {Package: "foo/bar", FactoryMethod: "NewA", RawArguments: []interface{}{"@b"}},
+				"b": {Package: "foo/bar", FactoryMethod: "NewB", RawArguments: []interface{}{"@a"}},
+				"c": {Package: "foo/bar", TypeName: "C"},
+			}}
+			_, err := c.DependencyOrder()
+			Expect(err).To(MatchError(`can not determine dependency order: circular references between ["a" "b"]`))
+		})
 	})
 
 	Describe("retrieving all packages", func() {
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// VerifyCode checks that the given generated code is valid go code.
+// The code is parsed and type checked together with all other go files in the output directory (if any).
+// Errors that are caused by packages that can not be imported are ignored because goldigen may run
+// without the dependencies of the output package being available.
+func VerifyCode(code []byte, outputPath string) error {
+	fileSet := token.NewFileSet()
+	fileName := filepath.Base(outputPath)
+	if outputPath == "" {
+		fileName = "generated.go"
+	}
+
+	file, err := parser.ParseFile(fileSet, fileName, code, parser.AllErrors)
+	if err != nil {
+		if errList, isList := err.(scanner.ErrorList); isList && len(errList) > 0 {
+			return verificationError(code, errList[0].Pos, errList[0].Msg)
+		}
+		return fmt.Errorf("generated code is invalid: %s", err)
+	}
+
+	packageFiles, err := parsePackageFiles(fileSet, outputPath, file.Name.Name)
+	if err != nil || len(packageFiles) == 0 {
+		// without the rest of the package type checking would report all identifiers of the output package
+		return nil
+	}
+
+	var firstErr *types.Error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fileSet, "source", nil),
+		Error: func(err error) {
+			typeErr, isTypeErr := err.(types.Error)
+			if isTypeErr == false || firstErr != nil || typeErr.Soft {
+				return
+			}
+
+			if typeErr.Fset.Position(typeErr.Pos).Filename != fileName || strings.Contains(typeErr.Msg, "could not import") {
+				return
+			}
+
+			firstErr = &typeErr
+		},
+	}
+
+	conf.Check(file.Name.Name, fileSet, append(packageFiles, file), nil)
+	if firstErr != nil {
+		return verificationError(code, firstErr.Fset.Position(firstErr.Pos), firstErr.Msg)
+	}
+
+	return nil
+}
+
+// parsePackageFiles parses all non test go files of the given package in the directory of the output path
+// except for the output file itself.
+func parsePackageFiles(fileSet *token.FileSet, outputPath, packageName string) ([]*ast.File, error) {
+	if outputPath == "" {
+		return nil, nil
+	}
+
+	fileInfos, err := ioutil.ReadDir(filepath.Dir(outputPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, info := range fileInfos {
+		name := info.Name()
+		if info.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") || name == filepath.Base(outputPath) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, filepath.Join(filepath.Dir(outputPath), name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if file.Name.Name == packageName {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+func verificationError(code []byte, position token.Position, message string) error {
+	lines := bytes.Split(code, []byte("\n"))
+	context := ""
+	if position.Line > 0 && position.Line <= len(lines) {
+		context = "\n\t" + strings.TrimSpace(string(lines[position.Line-1]))
+	}
+
+	return fmt.Errorf("generated code is invalid: line %d: %s%s", position.Line, message, context)
+}
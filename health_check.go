@@ -0,0 +1,32 @@
+package goldi
+
+import "context"
+
+// A HealthChecker is a type that is able to report whether it is working correctly.
+// Types that implement this interface are checked by Container.HealthCheck.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheck calls HealthChecker.HealthCheck on every instantiated type that implements the HealthChecker interface.
+// The results are returned keyed by type ID. Healthy types are contained in the result with a nil error.
+//
+// Types that have not been requested from the container yet are skipped because health checks should not
+// trigger the lazy instantiation of types. If you want to check these types as well you need to Get them first.
+func (c *Container) HealthCheck(ctx context.Context) map[string]error {
+	checkers := map[string]HealthChecker{}
+	c.mutex.Lock()
+	for typeID, instance := range c.typeCache {
+		if checker, isHealthChecker := instance.(HealthChecker); isHealthChecker {
+			checkers[typeID] = checker
+		}
+	}
+	c.mutex.Unlock()
+
+	results := map[string]error{}
+	for typeID, checker := range checkers {
+		results[typeID] = checker.HealthCheck(ctx)
+	}
+
+	return results
+}
@@ -0,0 +1,48 @@
+package goldi_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type checkedService struct {
+	err error
+}
+
+func (s *checkedService) HealthCheck(ctx context.Context) error {
+	return s.err
+}
+
+var _ = Describe("Container.HealthCheck", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.InjectInstance("healthy", &checkedService{})
+		container.InjectInstance("failing", &checkedService{errors.New("database is gone")})
+		container.InjectInstance("not_checked", &MockType{})
+	})
+
+	It("should return the results of all instantiated health checkers", func() {
+		container.MustGet("healthy")
+		container.MustGet("failing")
+		container.MustGet("not_checked")
+
+		results := container.HealthCheck(context.Background())
+		Expect(results).To(HaveLen(2))
+		Expect(results).To(HaveKeyWithValue("healthy", BeNil()))
+		Expect(results).To(HaveKeyWithValue("failing", MatchError("database is gone")))
+	})
+
+	It("should skip types that have not been instantiated yet", func() {
+		container.MustGet("healthy")
+
+		results := container.HealthCheck(context.Background())
+		Expect(results).To(HaveLen(1))
+		Expect(results).To(HaveKey("healthy"))
+	})
+})
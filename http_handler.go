@@ -0,0 +1,53 @@
+package goldi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// HTTPHandler retrieves the type with the given ID and adapts it into an http.Handler so it can be mounted on a router.
+// If method is not empty the handler is the method with that name of the generated type (just like the func reference
+// "@controller::Action"). Otherwise the type itself is used which is useful for func types and func references.
+//
+// The handler must either implement http.Handler or have the signature func(http.ResponseWriter, *http.Request).
+// HTTPHandler returns an error if the type can not be generated, the method does not exist or has a different signature.
+//
+// If the type resolves to nil (e.g. because its factory returned nil) HTTPHandler returns an error together with a
+// handler that responds with 500 Internal Server Error, so routers that ignore the error do not panic on each request.
+func (c *Container) HTTPHandler(typeID, method string) (http.Handler, error) {
+	instance, err := c.Get(typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("type %q", typeID)
+	if method != "" {
+		name = fmt.Sprintf("method %q of type %q", method, typeID)
+	}
+
+	if isNil(instance) {
+		err = fmt.Errorf("goldi: can not use %s as http handler: the type resolved to nil", name)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}), err
+	}
+
+	handler := instance
+	if method != "" {
+		methodValue := reflect.ValueOf(instance).MethodByName(method)
+		if methodValue.IsValid() == false {
+			return nil, fmt.Errorf("goldi: can not use %s as http handler: the method does not exist or is not exported", name)
+		}
+		handler = methodValue.Interface()
+	}
+
+	switch h := handler.(type) {
+	case http.Handler:
+		return h, nil
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(h), nil
+	default:
+		return nil, fmt.Errorf("goldi: can not use %s as http handler: %T does neither implement http.Handler nor is it a func(http.ResponseWriter, *http.Request)", name, handler)
+	}
+}
@@ -0,0 +1,90 @@
+package goldi_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type greetingController struct {
+	Greeting string
+}
+
+func (c *greetingController) Hello(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s %s", c.Greeting, r.URL.Query().Get("name"))
+}
+
+func (c *greetingController) NoHandler(name string) string {
+	return c.Greeting + " " + name
+}
+
+var _ = Describe("Container.HTTPHandler", func() {
+	var container *goldi.Container
+
+	serve := func(handler http.Handler, url string) string {
+		mux := http.NewServeMux()
+		mux.Handle("/hello", handler)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest("GET", url, nil))
+		return recorder.Body.String()
+	}
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.InjectInstance("controller", &greetingController{Greeting: "Hello"})
+	})
+
+	It("should adapt a method of a type into an http handler", func() {
+		handler, err := container.HTTPHandler("controller", "Hello")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serve(handler, "/hello?name=World")).To(Equal("Hello World"))
+	})
+
+	It("should return an error and a handler that responds with 500 if the type resolves to nil", func() {
+		container.Register("nil_controller", goldi.NewType(func() *greetingController { return nil }))
+		handler, err := container.HTTPHandler("nil_controller", "Hello")
+		Expect(err).To(MatchError(`goldi: can not use method "Hello" of type "nil_controller" as http handler: the type resolved to nil`))
+		Expect(handler).NotTo(BeNil())
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/hello", nil))
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		Expect(recorder.Body.String()).To(ContainSubstring("the type resolved to nil"))
+	})
+
+	It("should adapt func reference types into an http handler", func() {
+		container.Register("hello_action", goldi.NewFuncReferenceType("controller", "Hello"))
+
+		handler, err := container.HTTPHandler("hello_action", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serve(handler, "/hello?name=Gopher")).To(Equal("Hello Gopher"))
+	})
+
+	It("should use types that implement http.Handler directly", func() {
+		container.InjectInstance("not_found", http.NotFoundHandler())
+
+		handler, err := container.HTTPHandler("not_found", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serve(handler, "/hello")).To(ContainSubstring("404 page not found"))
+	})
+
+	It("should return an error if the method does not exist", func() {
+		_, err := container.HTTPHandler("controller", "Goodbye")
+		Expect(err).To(MatchError(`goldi: can not use method "Goodbye" of type "controller" as http handler: the method does not exist or is not exported`))
+	})
+
+	It("should return an error if the method has no handler signature", func() {
+		_, err := container.HTTPHandler("controller", "NoHandler")
+		Expect(err).To(MatchError(`goldi: can not use method "NoHandler" of type "controller" as http handler: func(string) string does neither implement http.Handler nor is it a func(http.ResponseWriter, *http.Request)`))
+	})
+
+	It("should return an error if the type can not be generated", func() {
+		_, err := container.HTTPHandler("missing", "Hello")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,43 @@
+package goldi
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// referencedElement returns the element of the given slice, array or map that is referenced by the index of the type ID.
+// Pointers to slices, arrays or maps are dereferenced.
+func referencedElement(t *TypeID, typeInstance interface{}) (interface{}, error) {
+	v := reflect.ValueOf(typeInstance)
+	if v.Kind() == reflect.Ptr && v.IsNil() == false {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(t.Index)
+		if err != nil {
+			return nil, newTypeReferenceError(t.ID, typeInstance, `the index of the referenced type %q must be an integer`, t.Raw)
+		}
+
+		if i < 0 || i >= v.Len() {
+			return nil, newTypeReferenceError(t.ID, typeInstance, `the index of the referenced type %q is out of range (length %d)`, t.Raw, v.Len())
+		}
+
+		return v.Index(i).Interface(), nil
+	case reflect.Map:
+		key, err := coerce(t.Index, v.Type().Key())
+		if err != nil {
+			return nil, newTypeReferenceError(t.ID, typeInstance, `the key of the referenced type %q can not be converted to %v: %s`, t.Raw, v.Type().Key(), err)
+		}
+
+		element := v.MapIndex(key)
+		if element.IsValid() == false {
+			return nil, newTypeReferenceError(t.ID, typeInstance, `the key of the referenced type %q does not exist`, t.Raw)
+		}
+
+		return element.Interface(), nil
+	default:
+		return nil, newTypeReferenceError(t.ID, typeInstance, `the referenced type %q (type %T) can not be indexed`, t.Raw, typeInstance)
+	}
+}
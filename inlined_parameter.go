@@ -0,0 +1,23 @@
+package goldi
+
+import "reflect"
+
+// An InlinedParameter is a factory argument that holds the value of a parameter which has already been looked up
+// when the code was generated (see the --inline-params flag of goldigen). Its value is converted into the type the
+// factory expects exactly like the value of a parameter that is looked up in the configuration of the container,
+// so the generated code behaves as if the parameter had been passed as "%name%".
+type InlinedParameter struct {
+	Name  string
+	Value interface{}
+}
+
+// InlineParameter returns a factory argument that resolves to the given value of the parameter with the given name.
+//
+//	goldi.NewType(NewServer, goldi.InlineParameter("port", 8080), goldi.InlineParameter("timeout", "5s"))
+func InlineParameter(name string, value interface{}) *InlinedParameter {
+	return &InlinedParameter{Name: name, Value: value}
+}
+
+func (r *ParameterResolver) resolveInlinedParameter(parameter *InlinedParameter, expectedType reflect.Type) (reflect.Value, error) {
+	return r.resolveParameterValue(parameter.Name, parameter.Value, expectedType)
+}
@@ -0,0 +1,55 @@
+package goldi_test
+
+import (
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type inlinedServer struct {
+	Port        uint16
+	ReadTimeout time.Duration
+	Ratio       float64
+}
+
+func newInlinedServer(port uint16, readTimeout time.Duration, ratio float64) *inlinedServer {
+	return &inlinedServer{Port: port, ReadTimeout: readTimeout, Ratio: ratio}
+}
+
+var _ = Describe("InlineParameter", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should convert the value into the type of the factory argument like a parameter", func() {
+		container.Register("server", goldi.NewType(newInlinedServer,
+			goldi.InlineParameter("port", 8080),
+			goldi.InlineParameter("read_timeout", "5s"),
+			goldi.InlineParameter("ratio", 1),
+		))
+
+		Expect(container.MustGet("server")).To(Equal(&inlinedServer{Port: 8080, ReadTimeout: 5 * time.Second, Ratio: 1}))
+	})
+
+	It("should return an error if the value can not be converted", func() {
+		container.Register("server", goldi.NewType(newInlinedServer,
+			goldi.InlineParameter("port", 70000),
+			goldi.InlineParameter("read_timeout", "5s"),
+			goldi.InlineParameter("ratio", 0.5),
+		))
+
+		_, err := container.Get("server")
+		Expect(err).To(MatchError(`goldi: error while generating type "server": invalid value of parameter "port": can not convert 70000 (type int) to uint16: value out of range`))
+	})
+
+	It("should resolve values that are type references", func() {
+		container.Register("mock", goldi.NewType(NewMockType))
+		container.Register("foo", goldi.NewType(NewTypeForServiceInjection, goldi.InlineParameter("mock", "@mock")))
+
+		Expect(container.MustGet("foo").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(container.MustGet("mock")))
+	})
+})
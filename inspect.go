@@ -0,0 +1,136 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A ContainerReport summarizes the registered types of a container and their state.
+// It is created by Container.Inspect.
+type ContainerReport struct {
+	// Types is the number of registered types.
+	Types int
+
+	// Kinds contains the number of registered types for each kind of TypeFactory (e.g. "type", "alias" or "instance").
+	Kinds map[string]int
+
+	// Instantiated is the number of types that have already been generated.
+	Instantiated int
+
+	// Lazy is the number of types that have not been generated yet.
+	Lazy int
+
+	// InvalidTypes contains the sorted IDs of all types whose TypeFactory is invalid.
+	InvalidTypes []string
+
+	// DanglingReferences contains the sorted IDs of all referenced but undefined types for each type that references them.
+	// Optional type references are never reported as dangling.
+	DanglingReferences map[string][]string
+}
+
+// Inspect creates a ContainerReport of this container. The report is created by statically inspecting all
+// registered type factories and the cache of generated types, so Inspect never generates any types.
+// This can be used for diagnostics or to assert the shape of a container in tests.
+func (c *Container) Inspect() *ContainerReport {
+	report := &ContainerReport{
+		Types:              len(c.TypeRegistry),
+		Kinds:              map[string]int{},
+		DanglingReferences: map[string][]string{},
+	}
+
+	c.mutex.Lock()
+	for typeID := range c.TypeRegistry {
+		if _, isCached := c.typeCache[typeID]; isCached {
+			report.Instantiated++
+		}
+	}
+	c.mutex.Unlock()
+	report.Lazy = report.Types - report.Instantiated
+
+	for typeID, factory := range c.TypeRegistry {
+		report.Kinds[factoryKind(factory)]++
+		if IsValid(factory) == false {
+			report.InvalidTypes = append(report.InvalidTypes, typeID)
+		}
+
+		dangling := c.danglingReferences(factory, StringSet{})
+		for referencedTypeID := range dangling {
+			report.DanglingReferences[typeID] = append(report.DanglingReferences[typeID], referencedTypeID)
+		}
+		sort.Strings(report.DanglingReferences[typeID])
+	}
+
+	sort.Strings(report.InvalidTypes)
+	return report
+}
+
+// danglingReferences returns the IDs of all types that are referenced by the given type factory (see Container.References)
+// but that are neither defined in this container nor in any of its fallback containers.
+func (c *Container) danglingReferences(typeFactory TypeFactory, dangling StringSet) StringSet {
+	for _, reference := range c.References(typeFactory) {
+		if reference.IsOptional || reference.IsAlternative {
+			// optional references and the alternatives of fallback chains are allowed to be undefined
+			continue
+		}
+
+		if c.isDefined(reference.ID) == false {
+			dangling.Set(reference.ID)
+		}
+	}
+
+	return dangling
+}
+
+// isDefined returns true if a type with the given ID has been registered in this container or any of its fallbacks.
+func (c *Container) isDefined(typeID string) bool {
+	for container := c; container != nil; container = container.fallback {
+		if _, isDefined := container.TypeRegistry[typeID]; isDefined {
+			return true
+		}
+	}
+
+	return false
+}
+
+// factoryKind returns a short human readable name of the kind of the given TypeFactory.
+func factoryKind(factory TypeFactory) string {
+	switch f := factory.(type) {
+	case *typeFactory:
+		return "type"
+	case *singletonFuncType:
+		return "singleton func"
+	case *structType:
+		return "struct"
+	case *instanceType:
+		return "instance"
+	case *funcType:
+		return "func"
+	case *funcReferenceType:
+		return "func reference"
+	case *proxyType:
+		return "proxy"
+	case *aliasType:
+		return "alias"
+	case *configuredType:
+		return "configured"
+	case *retryType:
+		return "retry"
+	case *ttlType:
+		return "ttl"
+	case *taggedType, *declaredDependenciesType:
+		// tags and declared dependencies do not change how the type is generated
+		return factoryKind(f.(decorator).embedded())
+	case *platformSwitchType:
+		return "platform switch"
+	case *envSwitchType:
+		return "env switch"
+	case *conditionalType:
+		return "conditional"
+	case *multiTypeOutput:
+		return "multi type output"
+	case *invalidType:
+		return "invalid"
+	default:
+		return fmt.Sprintf("%T", factory)
+	}
+}
@@ -0,0 +1,69 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Inspect", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should return an empty report for empty containers", func() {
+		report := container.Inspect()
+		Expect(report.Types).To(BeZero())
+		Expect(report.Kinds).To(BeEmpty())
+		Expect(report.InvalidTypes).To(BeEmpty())
+		Expect(report.DanglingReferences).To(BeEmpty())
+	})
+
+	It("should count the types of each kind and their state", func() {
+		container.Register("foo", goldi.NewType(NewMockType))
+		container.Register("bar", goldi.NewType(NewTypeForServiceInjection, "@foo"))
+		container.Register("baz", goldi.NewAliasType("foo"))
+		container.Register("qux", goldi.NewInstanceType(&MockType{}))
+		container.Register("broken", goldi.NewType(42))
+		container.MustGet("bar")
+
+		report := container.Inspect()
+		Expect(report.Types).To(Equal(5))
+		Expect(report.Kinds).To(Equal(map[string]int{"type": 2, "alias": 1, "instance": 1, "invalid": 1}))
+		Expect(report.Instantiated).To(Equal(2))
+		Expect(report.Lazy).To(Equal(3))
+		Expect(report.InvalidTypes).To(Equal([]string{"broken"}))
+	})
+
+	It("should report dangling references", func() {
+		container.Register("foo", goldi.NewType(NewTypeForServiceInjection, "@missing"))
+		container.Register("bar", goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewTypeForServiceInjection, "@also_missing")))
+		container.Register("baz", goldi.NewAliasType("foo"))
+		container.Register("optional", goldi.NewType(NewTypeForServiceInjection, "@?missing"))
+
+		report := container.Inspect()
+		Expect(report.DanglingReferences).To(Equal(map[string][]string{
+			"foo": {"missing"},
+			"bar": {"also_missing"},
+		}))
+	})
+
+	It("should report dangling references in map arguments", func() {
+		container.Register("foo", goldi.NewType(func(map[string]interface{}) *MockType { return nil },
+			map[string]interface{}{"a": "@missing", "b": "@?also_missing"},
+		))
+
+		Expect(container.Inspect().DanglingReferences).To(Equal(map[string][]string{"foo": {"missing"}}))
+	})
+
+	It("should not report references to types of the fallback container as dangling", func() {
+		fallback := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		fallback.Register("logger", goldi.NewType(NewMockType))
+		Expect(container.SetFallback(fallback)).To(Succeed())
+		container.Register("foo", goldi.NewType(NewTypeForServiceInjection, "@logger"))
+
+		Expect(container.Inspect().DanglingReferences).To(BeEmpty())
+	})
+})
@@ -0,0 +1,107 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// isResolvableMap returns true if the given argument is a map that is passed to a map argument and contains at least one
+// value that must be resolved (e.g. a parameter or a type reference). Maps without such values are passed as literals.
+func isResolvableMap(parameter reflect.Value, expectedType reflect.Type) bool {
+	if parameter.Kind() != reflect.Map || expectedType.Kind() != reflect.Map {
+		return false
+	}
+
+	iter := parameter.MapRange()
+	for iter.Next() {
+		if needsResolution(iter.Value()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func needsResolution(value reflect.Value) bool {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if value.IsValid() == false {
+		return false
+	}
+
+	switch v := value.Interface().(type) {
+	case string:
+		return IsParameterOrTypeReference(v) || FallbackAlternatives(v) != nil
+	case TypeFactory, *CastArgument, *InlinedParameter, contextArgument, containerArgument, parametersArgument:
+		return true
+	}
+
+	if value.Kind() == reflect.Map {
+		iter := value.MapRange()
+		for iter.Next() {
+			if needsResolution(iter.Value()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolveMap resolves each value of the given map like a regular argument and converts it into the element type of
+// the expected map type. The keys are converted into the key type of the expected map type so maps with string keys
+// can also be passed to maps with numeric keys. All offending keys are reported in the returned error.
+func (r *ParameterResolver) resolveMap(parameter reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	keys := parameter.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	result := reflect.MakeMapWithSize(expectedType, len(keys))
+	errs := NewMultiError("could not resolve all values of the map argument")
+	for _, key := range keys {
+		resolvedKey, err := coerce(key.Interface(), expectedType.Key())
+		if err != nil {
+			errs.Add(fmt.Errorf("invalid key %v: %s", key.Interface(), err))
+			continue
+		}
+
+		value, err := r.resolveMapValue(parameter.MapIndex(key), expectedType.Elem())
+		if err != nil {
+			errs.Add(fmt.Errorf("invalid value of key %v: %s", key.Interface(), err))
+			continue
+		}
+
+		result.SetMapIndex(resolvedKey, value)
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return result, nil
+}
+
+func (r *ParameterResolver) resolveMapValue(value reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	resolved, _, err := r.resolve(value, expectedType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if resolved.IsValid() == false {
+		return reflect.Zero(expectedType), nil
+	}
+
+	if resolved.Type().AssignableTo(expectedType) {
+		return resolved, nil
+	}
+
+	return coerce(resolved.Interface(), expectedType)
+}
@@ -0,0 +1,101 @@
+package goldi_test
+
+import (
+	"reflect"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type FooRegistry struct {
+	Foos map[string]*Foo
+}
+
+func NewFooRegistry(foos map[string]*Foo) *FooRegistry {
+	return &FooRegistry{Foos: foos}
+}
+
+var _ = Describe("map arguments", func() {
+	var (
+		config    map[string]interface{}
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{"param_b": "Hello World"}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+		container.RegisterType("svc_a", NewFoo)
+		container.RegisterType("svc_b", NewFoo)
+		resolver = goldi.NewParameterResolver(container)
+	})
+
+	It("should resolve the type references and parameters of each value", func() {
+		parameter := map[string]interface{}{"a": "@svc_a", "b": "%param_b%", "c": 42}
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[string]interface{}{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(map[string]interface{}{
+			"a": container.MustGet("svc_a"),
+			"b": "Hello World",
+			"c": 42,
+		}))
+	})
+
+	It("should convert the values into the element type of the expected map", func() {
+		parameter := map[interface{}]interface{}{"a": "@svc_a", "b": "@svc_b"}
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[string]*Foo{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(map[string]*Foo{
+			"a": container.MustGet("svc_a").(*Foo),
+			"b": container.MustGet("svc_b").(*Foo),
+		}))
+	})
+
+	It("should convert the keys into the key type of the expected map", func() {
+		parameter := map[interface{}]interface{}{"1": "@svc_a", 2: "@svc_b"}
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[int]*Foo{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(map[int]*Foo{
+			1: container.MustGet("svc_a").(*Foo),
+			2: container.MustGet("svc_b").(*Foo),
+		}))
+	})
+
+	It("should resolve optional type references that have not been defined to nil", func() {
+		parameter := map[string]interface{}{"a": "@svc_a", "b": "@?missing"}
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[string]*Foo{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(HaveKeyWithValue("b", BeNil()))
+	})
+
+	It("should return an error for each offending key", func() {
+		parameter := map[interface{}]interface{}{"a": "@missing", "1": "@svc_a", "b": "@svc_b"}
+		_, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[int]*Foo{}))
+		Expect(err).To(MatchError(`could not resolve all values of the map argument: ` +
+			`invalid key a: can not convert "a" to int: strconv.ParseInt: parsing "a": invalid syntax; ` +
+			`invalid key b: can not convert "b" to int: strconv.ParseInt: parsing "b": invalid syntax`,
+		))
+	})
+
+	It("should return an error if a value can not be resolved", func() {
+		parameter := map[string]interface{}{"a": "@svc_a", "b": "@missing"}
+		_, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[string]*Foo{}))
+		Expect(err).To(MatchError(`could not resolve all values of the map argument: invalid value of key b: the referenced type "@missing" has not been defined`))
+	})
+
+	It("should pass maps without references as they are", func() {
+		parameter := map[string]string{"a": "foo"}
+		result, err := resolver.Resolve(reflect.ValueOf(parameter), reflect.TypeOf(map[string]string{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(parameter))
+	})
+
+	It("should pass the resolved map to the factory", func() {
+		container.RegisterType("registry", NewFooRegistry, map[string]interface{}{"a": "@svc_a", "b": "@svc_b"})
+		registry := container.MustGet("registry").(*FooRegistry)
+		Expect(registry.Foos).To(HaveLen(2))
+		Expect(registry.Foos["a"]).To(BeIdenticalTo(container.MustGet("svc_a")))
+		Expect(registry.Foos["b"]).To(BeIdenticalTo(container.MustGet("svc_b")))
+	})
+})
@@ -0,0 +1,86 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// resolveChain applies the method calls and field accesses of the chain of the given type ID to the referenced
+// type instance and returns the result of the last step.
+// Methods must not have any arguments and must return a single value and an optional error.
+func resolveChain(t *TypeID, instance interface{}) (interface{}, error) {
+	value := reflect.ValueOf(instance)
+	for i, step := range t.Chain {
+		if value.IsValid() == false {
+			return nil, fmt.Errorf("could not resolve %q: step %d (%s) is applied to nil", t.Raw, i+1, step)
+		}
+
+		var err error
+		if strings.HasSuffix(step, "()") {
+			value, err = callChainMethod(value, strings.TrimSuffix(step, "()"))
+		} else {
+			value, err = chainField(value, step)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve %q: step %d (%s): %s", t.Raw, i+1, step, err)
+		}
+	}
+
+	if value.IsValid() == false || (value.Kind() == reflect.Interface && value.IsNil()) {
+		return nil, nil
+	}
+
+	return value.Interface(), nil
+}
+
+func callChainMethod(value reflect.Value, name string) (reflect.Value, error) {
+	method := value.MethodByName(name)
+	if method.IsValid() == false {
+		return reflect.Value{}, fmt.Errorf("%v has no exported method %s", value.Type(), name)
+	}
+
+	methodType := method.Type()
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	if methodType.NumIn() != 0 || methodType.NumOut() == 0 || methodType.NumOut() > 2 || (methodType.NumOut() == 2 && methodType.Out(1) != errorType) {
+		return reflect.Value{}, fmt.Errorf("the method %s %v must not have any arguments and must return a value and an optional error", name, methodType)
+	}
+
+	results := method.Call(nil)
+	if len(results) == 2 && results[1].IsNil() == false {
+		return reflect.Value{}, fmt.Errorf("the method %s returned an error: %s", name, results[1].Interface())
+	}
+
+	return results[0], nil
+}
+
+func chainField(value reflect.Value, name string) (reflect.Value, error) {
+	structValue := value
+	for structValue.Kind() == reflect.Ptr || structValue.Kind() == reflect.Interface {
+		if structValue.IsNil() {
+			return reflect.Value{}, fmt.Errorf("can not read the field %s of nil %v", name, value.Type())
+		}
+		structValue = structValue.Elem()
+	}
+
+	if structValue.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%v is no struct (use %s() to call a method)", value.Type(), name)
+	}
+
+	field, exists := structValue.Type().FieldByName(name)
+	if exists == false {
+		return reflect.Value{}, fmt.Errorf("%v has no field %s (use %s() to call a method)", value.Type(), name, name)
+	}
+
+	if field.PkgPath != "" {
+		return reflect.Value{}, fmt.Errorf("the field %s of %v is not exported", name, value.Type())
+	}
+
+	fieldValue, err := structValue.FieldByIndexErr(field.Index)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("can not read the field %s of %v: %s", name, value.Type(), err)
+	}
+
+	return fieldValue, nil
+}
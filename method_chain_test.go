@@ -0,0 +1,132 @@
+package goldi_test
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type chainConfig struct {
+	Timeout time.Duration
+	Name    string
+	secret  string
+}
+
+func (c *chainConfig) Describe() string {
+	return c.Name + " (" + c.Timeout.String() + ")"
+}
+
+type chainService struct {
+	Config *chainConfig
+	Err    error
+}
+
+type embeddingChainService struct {
+	*chainConfig
+}
+
+func (s *chainService) GetConfig() *chainConfig {
+	return s.Config
+}
+
+func (s *chainService) LoadConfig() (*chainConfig, error) {
+	return s.Config, s.Err
+}
+
+func (s *chainService) Lookup(key string) string {
+	return key
+}
+
+var _ = Describe("method chains", func() {
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+		service   *chainService
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+		service = &chainService{Config: &chainConfig{Timeout: 5 * time.Second, Name: "db", secret: "s3cr3t"}}
+		container.InjectInstance("service", service)
+	})
+
+	It("should parse method chains", func() {
+		t := goldi.NewTypeID("@service::GetConfig()::Timeout")
+		Expect(t.ID).To(Equal("service"))
+		Expect(t.Chain).To(Equal([]string{"GetConfig()", "Timeout"}))
+		Expect(t.IsFuncReference).To(BeFalse())
+		Expect(t.String()).To(Equal("@service::GetConfig()::Timeout"))
+
+		Expect(goldi.NewTypeID("@service::GetConfig()").Chain).To(Equal([]string{"GetConfig()"}))
+		Expect(goldi.NewTypeID("@service::GetConfig").Chain).To(BeNil())
+	})
+
+	It("should call a method and read a field of its result", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("@service::GetConfig()::Timeout"), reflect.TypeOf(time.Duration(0)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(5 * time.Second))
+	})
+
+	It("should read a field and call a method of its value", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("@service::Config::Describe()"), reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal("db (5s)"))
+	})
+
+	It("should support methods that return an error", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("@service::LoadConfig()::Name"), reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal("db"))
+
+		service.Err = errors.New("oops")
+		_, err = resolver.Resolve(reflect.ValueOf("@service::LoadConfig()::Name"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::LoadConfig()::Name": step 1 (LoadConfig()): the method LoadConfig returned an error: oops`))
+	})
+
+	It("should be usable as factory argument", func() {
+		container.Register("mock", goldi.NewType(NewMockTypeWithArgs, "@service::GetConfig()::Name", true))
+		Expect(container.MustGet("mock").(*MockType).StringParameter).To(Equal("db"))
+	})
+
+	It("should return an error if a method does not exist", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@service::Missing()::Name"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::Missing()::Name": step 1 (Missing()): *goldi_test.chainService has no exported method Missing`))
+	})
+
+	It("should return an error if a method is used like a field", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@service::GetConfig::Name"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::GetConfig::Name": step 1 (GetConfig): *goldi_test.chainService has no field GetConfig (use GetConfig() to call a method)`))
+	})
+
+	It("should return an error if a method requires arguments", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@service::Lookup()"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::Lookup()": step 1 (Lookup()): the method Lookup func(string) string must not have any arguments and must return a value and an optional error`))
+	})
+
+	It("should return an error if a field is not exported", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@service::Config::secret"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::Config::secret": step 2 (secret): the field secret of *goldi_test.chainConfig is not exported`))
+	})
+
+	It("should return an error if a field of nil is read", func() {
+		service.Config = nil
+		_, err := resolver.Resolve(reflect.ValueOf("@service::GetConfig()::Name"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`could not resolve "@service::GetConfig()::Name": step 2 (Name): can not read the field Name of nil *goldi_test.chainConfig`))
+	})
+
+	It("should return an error if a field of a nil embedded struct is read", func() {
+		container.InjectInstance("embedding", &embeddingChainService{})
+		_, err := resolver.Resolve(reflect.ValueOf("@embedding::Timeout::String()"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(ContainSubstring(`step 1 (Timeout): can not read the field Timeout of *goldi_test.embeddingChainService: reflect: indirection through nil pointer to embedded struct`)))
+	})
+
+	It("should return an error if the result is not assignable to the expected type", func() {
+		_, err := resolver.Resolve(reflect.ValueOf("@service::GetConfig()::Timeout"), reflect.TypeOf(""))
+		Expect(err).To(MatchError(`the referenced type "@service::GetConfig()::Timeout" (type time.Duration) is not assignable to the expected type string`))
+	})
+})
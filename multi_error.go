@@ -0,0 +1,48 @@
+package goldi
+
+import "strings"
+
+// A MultiError is an error that consists of multiple other errors.
+// It is returned by functions that do not stop at the first error like Container.BuildParallel.
+// All contained errors can be inspected using errors.Is and errors.As.
+type MultiError struct {
+	// Message describes the operation that failed and is used as prefix of the error message.
+	Message string
+	Errors  []error
+}
+
+// NewMultiError creates a new empty MultiError with the given message.
+func NewMultiError(message string) *MultiError {
+	return &MultiError{Message: message}
+}
+
+// Add appends the given error to this MultiError. Nil errors are ignored.
+func (e *MultiError) Add(err error) {
+	if err != nil {
+		e.Errors = append(e.Errors, err)
+	}
+}
+
+// Error implements the error interface by joining the messages of all contained errors.
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return e.Message + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns all contained errors so they can be inspected using errors.Is and errors.As.
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// ErrorOrNil returns this MultiError if it contains any errors or nil otherwise.
+func (e *MultiError) ErrorOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
@@ -0,0 +1,62 @@
+package goldi_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MultiError", func() {
+	It("should join the messages of all errors", func() {
+		err := goldi.NewMultiError("could not do stuff")
+		err.Add(errors.New("first"))
+		err.Add(nil)
+		err.Add(errors.New("second"))
+
+		Expect(err.Errors).To(HaveLen(2))
+		Expect(err).To(MatchError("could not do stuff: first; second"))
+	})
+
+	It("should return nil from ErrorOrNil if it does not contain any errors", func() {
+		err := goldi.NewMultiError("could not do stuff")
+		Expect(err.ErrorOrNil()).To(BeNil())
+
+		err.Add(errors.New("oops"))
+		Expect(err.ErrorOrNil()).To(BeIdenticalTo(err))
+	})
+
+	It("should support inspecting the contained errors with errors.Is and errors.As", func() {
+		sentinel := errors.New("sentinel")
+		container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.RegisterType("foo", NewFoo)
+		var mock *MockType
+		referenceErr := container.GetAssignable("foo", &mock)
+
+		err := goldi.NewMultiError("could not do stuff")
+		err.Add(fmt.Errorf("first: %w", sentinel))
+		err.Add(fmt.Errorf("second: %w", referenceErr))
+
+		var typeReferenceErr goldi.TypeReferenceError
+		Expect(errors.Is(err, sentinel)).To(BeTrue())
+		Expect(errors.As(err, &typeReferenceErr)).To(BeTrue())
+		Expect(typeReferenceErr.TypeID).To(Equal("foo"))
+	})
+
+	It("should be returned when building multiple types fails", func() {
+		container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("foo", goldi.NewAliasType("missing"))
+		container.Register("bar", goldi.NewAliasType("also_missing"))
+
+		err := container.BuildParallel(2)
+		var multiErr *goldi.MultiError
+		Expect(errors.As(err, &multiErr)).To(BeTrue())
+		Expect(multiErr.Errors).To(HaveLen(2))
+
+		var unknownTypeErr goldi.UnknownTypeReferenceError
+		Expect(errors.As(multiErr.Errors[0], &unknownTypeErr)).To(BeTrue())
+		Expect(unknownTypeErr.TypeID).To(Equal("also_missing"))
+	})
+})
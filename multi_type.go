@@ -0,0 +1,91 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+)
+
+type multiTypeOutput struct {
+	factory   TypeFactory
+	fieldName string
+}
+
+// NewMultiType creates type factories for multiple related types that are created by a single factory function.
+// The factory must return a struct (or a pointer to a struct) and optionally an error as second result.
+// The outputs map the names of the struct fields to the type IDs under which the fields should be registered.
+// The factory is called only once and its result is shared by all returned type factories:
+//     registry.RegisterAll(goldi.NewMultiType(NewStorage, map[string]string{
+//         "DB":    "storage.db",
+//         "Cache": "storage.cache",
+//     }, "%storage.dsn%"))
+//
+// If the factory or the outputs are invalid, all returned type factories are invalid.
+//
+// You can not generate this type using goldigen
+func NewMultiType(factory interface{}, outputs map[string]string, arguments ...interface{}) map[string]TypeFactory {
+	factories := map[string]TypeFactory{}
+	singleton := NewSingletonFuncType(factory, arguments...)
+	if err := checkMultiTypeOutputs(factory, outputs); err != nil {
+		singleton = newInvalidType(err)
+	}
+
+	for fieldName, typeID := range outputs {
+		if IsValid(singleton) == false {
+			factories[typeID] = singleton
+			continue
+		}
+
+		factories[typeID] = &multiTypeOutput{factory: singleton, fieldName: fieldName}
+	}
+
+	return factories
+}
+
+func checkMultiTypeOutputs(factory interface{}, outputs map[string]string) error {
+	factoryType := reflect.TypeOf(factory)
+	if factoryType == nil || factoryType.Kind() != reflect.Func || factoryType.NumOut() == 0 {
+		// the function itself is checked by NewSingletonFuncType
+		return nil
+	}
+
+	structType := factoryType.Out(0)
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("the factory of a multi type must return a struct or a pointer to a struct (given %v)", factoryType.Out(0))
+	}
+
+	for fieldName := range outputs {
+		if _, exists := structType.FieldByName(fieldName); exists == false || unicode.IsLower(rune(fieldName[0])) {
+			return fmt.Errorf("the struct %v has no exported field %q", structType, fieldName)
+		}
+	}
+
+	return nil
+}
+
+// Arguments returns all arguments of the shared factory function.
+func (t *multiTypeOutput) Arguments() []interface{} {
+	return t.factory.Arguments()
+}
+
+// Generate calls the shared factory function once and returns the corresponding field of its result.
+func (t *multiTypeOutput) Generate(resolver *ParameterResolver) (interface{}, error) {
+	result, err := t.factory.Generate(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("can not get field %q: the factory returned nil", t.fieldName)
+		}
+		v = v.Elem()
+	}
+
+	return v.FieldByName(t.fieldName).Interface(), nil
+}
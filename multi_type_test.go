@@ -0,0 +1,80 @@
+package goldi_test
+
+import (
+	"errors"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type mockTypeBundle struct {
+	Mock    *MockType
+	Foo     *Foo
+	private string
+}
+
+var _ = Describe("NewMultiType", func() {
+	var (
+		container *goldi.Container
+		calls     int
+	)
+
+	newBundle := func(name string) *mockTypeBundle {
+		calls++
+		return &mockTypeBundle{
+			Mock: &MockType{StringParameter: name},
+			Foo:  &Foo{Value: name},
+		}
+	}
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{"name": "bundle"})
+		calls = 0
+	})
+
+	It("should register each output field as its own type", func() {
+		container.RegisterAll(goldi.NewMultiType(newBundle, map[string]string{
+			"Mock": "bundle.mock",
+			"Foo":  "bundle.foo",
+		}, "%name%"))
+
+		Expect(container.MustGet("bundle.mock").(*MockType).StringParameter).To(Equal("bundle"))
+		Expect(container.MustGet("bundle.foo").(*Foo).Value).To(Equal("bundle"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should support factories that return an error", func() {
+		factory := func() (mockTypeBundle, error) { return mockTypeBundle{}, errors.New("oops") }
+		container.RegisterAll(goldi.NewMultiType(factory, map[string]string{"Mock": "bundle.mock"}))
+
+		_, err := container.Get("bundle.mock")
+		Expect(err).To(MatchError(`goldi: error while generating type "bundle.mock": oops`))
+	})
+
+	It("should return the arguments of the factory", func() {
+		factories := goldi.NewMultiType(newBundle, map[string]string{"Mock": "bundle.mock"}, "%name%")
+		Expect(factories["bundle.mock"].Arguments()).To(Equal([]interface{}{"%name%"}))
+	})
+
+	It("should return invalid types if the factory does not return a struct", func() {
+		factories := goldi.NewMultiType(NewMockTypeWithArgs, map[string]string{"Mock": "bundle.mock"}, "foo", true)
+		Expect(goldi.IsValid(factories["bundle.mock"])).To(BeFalse())
+	})
+
+	It("should return invalid types if an output field does not exist", func() {
+		factories := goldi.NewMultiType(newBundle, map[string]string{
+			"Mock":    "bundle.mock",
+			"private": "bundle.private",
+		}, "%name%")
+
+		Expect(factories).To(HaveLen(2))
+		Expect(goldi.IsValid(factories["bundle.mock"])).To(BeFalse())
+		Expect(goldi.IsValid(factories["bundle.private"])).To(BeFalse())
+	})
+
+	It("should return invalid types if the factory is invalid", func() {
+		factories := goldi.NewMultiType(42, map[string]string{"Mock": "bundle.mock"})
+		Expect(goldi.IsValid(factories["bundle.mock"])).To(BeFalse())
+	})
+})
@@ -0,0 +1,111 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BuildParallel instantiates all registered types using up to concurrency goroutines.
+// Types are only generated after all types they reference have been generated, so types without any dependencies
+// between them are built in parallel while dependent types wait for their dependencies.
+// This can reduce the startup time of applications with many independent and slow (e.g. I/O bound) constructors.
+//
+// All errors that occur while generating the types are aggregated into the returned MultiError.
+// Types that depend on a type that could not be generated are skipped.
+// BuildParallel returns an error if the registered types contain circular references.
+func (c *Container) BuildParallel(concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("goldi: invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	typeIDs := make([]string, 0, len(c.TypeRegistry))
+	for typeID := range c.TypeRegistry {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	missingDependencies := map[string]int{}
+	dependents := map[string][]string{}
+	for _, typeID := range typeIDs {
+		for dependency := range c.dependencies(c.TypeRegistry[typeID]) {
+			if _, isDefined := c.TypeRegistry[dependency]; isDefined == false || dependency == typeID {
+				continue
+			}
+
+			missingDependencies[typeID]++
+			dependents[dependency] = append(dependents[dependency], typeID)
+		}
+	}
+
+	type buildResult struct {
+		typeID string
+		err    error
+	}
+
+	results := make(chan buildResult)
+	semaphore := make(chan struct{}, concurrency)
+	build := func(typeID string) {
+		semaphore <- struct{}{}
+		_, _, err := c.get(typeID)
+		<-semaphore
+		results <- buildResult{typeID, err}
+	}
+
+	running := 0
+	for _, typeID := range typeIDs {
+		if missingDependencies[typeID] == 0 {
+			running++
+			go build(typeID)
+		}
+	}
+
+	built := 0
+	errs := map[string]error{}
+	for running > 0 {
+		result := <-results
+		running--
+		if result.err != nil {
+			errs[result.typeID] = c.handleError(result.typeID, result.err)
+			continue
+		}
+
+		built++
+		for _, dependent := range dependents[result.typeID] {
+			missingDependencies[dependent]--
+			if missingDependencies[dependent] == 0 {
+				running++
+				go build(dependent)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		multiErr := NewMultiError("goldi: could not build all types")
+		for _, typeID := range typeIDs {
+			multiErr.Add(errs[typeID])
+		}
+		return multiErr
+	}
+
+	if built < len(typeIDs) {
+		var unbuilt []string
+		for _, typeID := range typeIDs {
+			if missingDependencies[typeID] > 0 {
+				unbuilt = append(unbuilt, typeID)
+			}
+		}
+		return fmt.Errorf("goldi: could not build types %q because they contain circular references", unbuilt)
+	}
+
+	return nil
+}
+
+// dependencies returns the IDs of all types that are referenced by the given type factory (see Container.References).
+func (c *Container) dependencies(typeFactory TypeFactory) StringSet {
+	dependencies := StringSet{}
+	for _, reference := range c.References(typeFactory) {
+		dependencies.Set(reference.ID)
+	}
+
+	return dependencies
+}
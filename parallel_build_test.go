@@ -0,0 +1,101 @@
+package goldi_test
+
+import (
+	"sync"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.BuildParallel", func() {
+	var (
+		container *goldi.Container
+		mutex     sync.Mutex
+		built     []string
+	)
+
+	// instrumented returns a factory function that records when it has been called.
+	instrumented := func(typeID string, before func()) func() *MockType {
+		return func() *MockType {
+			if before != nil {
+				before()
+			}
+
+			mutex.Lock()
+			built = append(built, typeID)
+			mutex.Unlock()
+			return &MockType{StringParameter: typeID}
+		}
+	}
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		built = nil
+	})
+
+	It("should build independent types in parallel", func() {
+		started := new(sync.WaitGroup)
+		started.Add(2)
+		waitForEachOther := func() {
+			started.Done()
+			started.Wait()
+		}
+
+		container.Register("a", goldi.NewType(instrumented("a", waitForEachOther)))
+		container.Register("b", goldi.NewType(instrumented("b", waitForEachOther)))
+
+		done := make(chan error)
+		go func() { done <- container.BuildParallel(2) }()
+
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(built).To(ConsistOf("a", "b"))
+	})
+
+	It("should build types after their dependencies", func() {
+		container.Register("a", goldi.NewType(instrumented("a", nil)))
+		container.Register("b", goldi.NewType(instrumented("b", nil)))
+		container.Register("c", goldi.NewType(NewTypeForServiceInjection, "@a"))
+		container.Register("d", goldi.NewType(NewTypeForServiceInjection, "@b"))
+		container.Register("e", goldi.NewType(func(c, d *TypeForServiceInjection) *MockType {
+			return instrumented("e", nil)()
+		}, "@c", "@d"))
+
+		Expect(container.BuildParallel(3)).To(Succeed())
+		Expect(built).To(HaveLen(3))
+		Expect(built[2]).To(Equal("e"))
+		Expect(container.MustGet("c").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(container.MustGet("a")))
+	})
+
+	It("should not generate types multiple times", func() {
+		container.Register("a", goldi.NewType(instrumented("a", nil)))
+		container.Register("b", goldi.NewType(NewTypeForServiceInjection, "@a"))
+		container.Register("c", goldi.NewType(NewTypeForServiceInjection, "@a"))
+
+		Expect(container.BuildParallel(4)).To(Succeed())
+		Expect(built).To(Equal([]string{"a"}))
+	})
+
+	It("should aggregate all errors", func() {
+		container.Register("a", goldi.NewProxyType("unknown_1", "DoStuff"))
+		container.Register("b", goldi.NewProxyType("unknown_2", "DoStuff"))
+		container.Register("c", goldi.NewType(NewTypeForServiceInjection, "@a"))
+
+		err := container.BuildParallel(2)
+		Expect(err).To(MatchError(HavePrefix(`goldi: could not build all types: goldi: error while generating type "a": `)))
+		Expect(err.Error()).To(ContainSubstring(`; goldi: error while generating type "b": `))
+		Expect(err.Error()).NotTo(ContainSubstring(`type "c"`))
+	})
+
+	It("should return an error if the types contain circular references", func() {
+		container.Register("a", goldi.NewType(NewTypeForServiceInjection, "@b"))
+		container.Register("b", goldi.NewType(NewTypeForServiceInjection, "@a"))
+		container.Register("c", goldi.NewType(instrumented("c", nil)))
+
+		Expect(container.BuildParallel(2)).To(MatchError(`goldi: could not build types ["a" "b"] because they contain circular references`))
+	})
+
+	It("should return an error if the concurrency is invalid", func() {
+		Expect(container.BuildParallel(0)).To(MatchError("goldi: invalid concurrency 0: must be at least 1"))
+	})
+})
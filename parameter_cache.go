@@ -0,0 +1,138 @@
+package goldi
+
+import (
+	"reflect"
+	"sync"
+)
+
+type parameterCacheKey struct {
+	name         string
+	expectedType reflect.Type
+}
+
+// parameterCache stores resolved parameter values and the resolved arguments of type factories for a certain
+// version of the container parameters.
+type parameterCache struct {
+	mutex     sync.Mutex
+	version   uint64
+	values    map[parameterCacheKey]reflect.Value
+	arguments map[*typeFactory][]reflect.Value
+}
+
+// isCurrent returns true if the cache contains the values of the given version of the container parameters.
+// Otherwise the cache is reset to the given version. The caller must hold the mutex of the cache.
+func (c *parameterCache) isCurrent(version uint64) bool {
+	if c.values != nil && c.version == version {
+		return true
+	}
+
+	c.values = map[parameterCacheKey]reflect.Value{}
+	c.arguments = map[*typeFactory][]reflect.Value{}
+	c.version = version
+	return false
+}
+
+// SetParameter sets the parameter with the given name to the given value.
+// Always use SetParameter instead of modifying the Config directly if the ParameterResolver caches
+// resolved parameters (see ParameterResolver.CacheParameters) because it invalidates that cache.
+//
+// Note that types which have already been generated are not affected by the new parameter value.
+func (c *Container) SetParameter(name string, value interface{}) {
+	c.parametersMutex.Lock()
+	c.Config[name] = value
+	c.parametersMutex.Unlock()
+
+	c.parametersVersion.Add(1)
+}
+
+func (r *ParameterResolver) cachedParameter(name string, expectedType reflect.Type) (reflect.Value, bool) {
+	if r.cache == nil {
+		return reflect.Value{}, false
+	}
+
+	r.cache.mutex.Lock()
+	defer r.cache.mutex.Unlock()
+	if r.cache.isCurrent(r.Container.parametersVersion.Load()) == false {
+		return reflect.Value{}, false
+	}
+
+	value, isCached := r.cache.values[parameterCacheKey{name, expectedType}]
+	return value, isCached
+}
+
+func (r *ParameterResolver) cacheParameter(name string, expectedType reflect.Type, value reflect.Value, version uint64) {
+	if r.cache == nil {
+		return
+	}
+
+	r.cache.mutex.Lock()
+	defer r.cache.mutex.Unlock()
+	if r.cache.values == nil || r.cache.version != version {
+		// the parameters have been changed while the value was resolved
+		return
+	}
+
+	r.cache.values[parameterCacheKey{name, expectedType}] = value
+}
+
+// cachedArguments returns a copy of the resolved arguments of the given type factory if they have been cached for the
+// current version of the container parameters.
+func (r *ParameterResolver) cachedArguments(t *typeFactory) ([]reflect.Value, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+
+	r.cache.mutex.Lock()
+	defer r.cache.mutex.Unlock()
+	if r.cache.isCurrent(r.Container.parametersVersion.Load()) == false {
+		return nil, false
+	}
+
+	args, isCached := r.cache.arguments[t]
+	return append([]reflect.Value(nil), args...), isCached
+}
+
+func (r *ParameterResolver) cacheArguments(t *typeFactory, args []reflect.Value, version uint64) {
+	if r.cache == nil {
+		return
+	}
+
+	r.cache.mutex.Lock()
+	defer r.cache.mutex.Unlock()
+	if r.cache.values == nil || r.cache.version != version {
+		// the parameters have been changed while the arguments were resolved
+		return
+	}
+
+	r.cache.arguments[t] = append([]reflect.Value(nil), args...)
+}
+
+// dependsOnParametersOnly returns true if the given arguments are literals or parameters whose values are no type
+// references. Only the resolved values of such arguments can be cached since all other arguments (e.g. type
+// references, contexts or custom ArgumentResolvers) may resolve to different values without a parameter change.
+func (r *ParameterResolver) dependsOnParametersOnly(arguments []reflect.Value) bool {
+	if len(r.ArgumentResolvers) > 0 {
+		return false
+	}
+
+	for _, argument := range arguments {
+		if argument.IsValid() == false {
+			continue
+		}
+
+		s, isString := argument.Interface().(string)
+		if isString == false || IsParameter(s) == false || FallbackAlternatives(s) != nil {
+			if needsResolution(argument) {
+				return false
+			}
+			continue
+		}
+
+		value, _ := r.Container.parameter(s[1 : len(s)-1])
+		if v, isString := value.(string); isString && IsTypeReference(v) {
+			return false
+		}
+	}
+
+	return true
+}
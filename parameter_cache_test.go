@@ -0,0 +1,171 @@
+package goldi_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParameterResolver.CacheParameters", func() {
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+		intType   = reflect.TypeOf(0)
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.SetParameter("limit", "42")
+		resolver = container.Resolver
+		resolver.CacheParameters = true
+	})
+
+	It("should return the cached value until the parameter is changed via SetParameter", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("%limit%"), intType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(42))
+
+		// modifying the config directly does not invalidate the cache
+		container.Config["limit"] = "43"
+		result, err = resolver.Resolve(reflect.ValueOf("%limit%"), intType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(42))
+
+		container.SetParameter("limit", "44")
+		result, err = resolver.Resolve(reflect.ValueOf("%limit%"), intType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(44))
+	})
+
+	It("should cache the values per expected type", func() {
+		result, err := resolver.Resolve(reflect.ValueOf("%limit%"), intType)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal(42))
+
+		result, err = resolver.Resolve(reflect.ValueOf("%limit%"), reflect.TypeOf(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Interface()).To(Equal("42"))
+	})
+
+	It("should not cache parameters that reference types", func() {
+		container.SetParameter("logger", "@logger")
+		container.InjectInstance("logger", &MockType{StringParameter: "first"})
+		first, err := resolver.Resolve(reflect.ValueOf("%logger%"), reflect.TypeOf(&MockType{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		replacement := &MockType{StringParameter: "second"}
+		container.Replace("logger", replacement)
+		second, err := resolver.Resolve(reflect.ValueOf("%logger%"), reflect.TypeOf(&MockType{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first.Interface()).NotTo(BeIdenticalTo(replacement))
+		Expect(second.Interface()).To(BeIdenticalTo(replacement))
+	})
+
+	It("should be safe to set parameters while they are resolved", func() {
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				container.SetParameter("limit", i)
+			}(i)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := resolver.Resolve(reflect.ValueOf("%limit%"), intType)
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	Describe("argument caching", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			container.Clock = func() time.Time { return now }
+			container.SetParameter("name", "first")
+		})
+
+		It("should reuse the resolved arguments of types until a parameter is changed via SetParameter", func() {
+			container.Register("mock", goldi.NewTTLType(goldi.NewType(NewMockTypeWithArgs, "%name%", true), time.Minute))
+			Expect(container.MustGet("mock").(*MockType).StringParameter).To(Equal("first"))
+
+			// modifying the config directly does not invalidate the cache
+			container.Config["name"] = "second"
+			now = now.Add(time.Minute)
+			Expect(container.MustGet("mock").(*MockType).StringParameter).To(Equal("first"))
+
+			container.SetParameter("name", "third")
+			now = now.Add(time.Minute)
+			Expect(container.MustGet("mock").(*MockType).StringParameter).To(Equal("third"))
+		})
+
+		It("should not reuse the arguments of types that reference other types", func() {
+			container.InjectInstance("injected", &MockType{StringParameter: "first"})
+			container.Register("service", goldi.NewTTLType(goldi.NewType(NewTypeForServiceInjection, "@injected"), time.Minute))
+			container.MustGet("service")
+
+			replacement := &MockType{StringParameter: "second"}
+			container.Replace("injected", replacement)
+			now = now.Add(time.Minute)
+			Expect(container.MustGet("service").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(replacement))
+		})
+	})
+})
+
+func benchmarkParameterResolution(b *testing.B, cacheParameters bool) {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	container.SetParameter("host", "localhost")
+	container.SetParameter("url", "tmpl:http://{{.host}}:8080")
+	container.Resolver.CacheParameters = cacheParameters
+
+	parameter := reflect.ValueOf("%url%")
+	expectedType := reflect.TypeOf("")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := container.Resolver.Resolve(parameter, expectedType); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParameterResolver_Resolve(b *testing.B) {
+	benchmarkParameterResolution(b, false)
+}
+
+func BenchmarkParameterResolver_ResolveCached(b *testing.B) {
+	benchmarkParameterResolution(b, true)
+}
+
+func benchmarkArgumentResolution(b *testing.B, cacheParameters bool) {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	container.SetParameter("host", "localhost")
+	container.SetParameter("url", "tmpl:http://{{.host}}:8080")
+	container.SetParameter("enabled", "true")
+	container.Resolver.CacheParameters = cacheParameters
+
+	factory := goldi.NewType(NewMockTypeWithArgs, "%url%", "%enabled%")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := factory.Generate(container.Resolver); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTypeFactory_Generate(b *testing.B) {
+	benchmarkArgumentResolution(b, false)
+}
+
+func BenchmarkTypeFactory_GenerateCached(b *testing.B) {
+	benchmarkArgumentResolution(b, true)
+}
@@ -1,11 +1,43 @@
 package goldi
 
-import "reflect"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// TemplateParameterPrefix marks a configured parameter value as text/template.
+// Such values are rendered using all other parameters when they are resolved.
+// For example "tmpl:{{.host}}:{{.port}}" combines the "host" and "port" parameters.
+const TemplateParameterPrefix = "tmpl:"
 
 // The ParameterResolver is used by type factories to resolve the values of the dynamic factory arguments
 // (parameters and other type references).
 type ParameterResolver struct {
 	Container *Container
+
+	// ArgumentResolvers are consulted for all arguments that are no parameters or type references.
+	ArgumentResolvers []ArgumentResolver
+
+	// CacheParameters can be set to true to cache the resolved values of parameters. This speeds up types that are
+	// generated repeatedly. The cache is invalidated by Container.SetParameter so parameters must not be modified
+	// directly in the container Config if the cache is enabled. Note that cached slices and maps are shared by all
+	// types that use the same parameter.
+	// The complete resolved arguments of types whose arguments are only literals and parameters are cached as well, so
+	// types that are generated repeatedly (e.g. scoped types or types with a CachePolicy) skip the argument resolution.
+	CacheParameters bool
+
+	// CollectErrors can be set to true to let the factories of NewType and NewStructType resolve all of their
+	// arguments even if some of them can not be resolved. All resolution errors of a type are then returned together
+	// in a MultiError so they can be fixed at once. By default the first error is returned immediately.
+	CollectErrors bool
+
+	cache      *parameterCache
+	ctx        context.Context
+	generating []string // the IDs of the types that are currently generated with this resolver, see withGenerating
 }
 
 // NewParameterResolver creates a new ParameterResolver and initializes it with the given Container.
@@ -13,6 +45,50 @@ type ParameterResolver struct {
 func NewParameterResolver(container *Container) *ParameterResolver {
 	return &ParameterResolver{
 		Container: container,
+		cache:     new(parameterCache),
+	}
+}
+
+// A ResolutionKind describes how the ParameterResolver has resolved a factory argument.
+type ResolutionKind int
+
+const (
+	// LiteralResolution is used for arguments which are neither parameters nor type references.
+	LiteralResolution ResolutionKind = iota
+
+	// ParameterResolution is used for parameters like `%my.param%`.
+	ParameterResolution
+
+	// ReferenceResolution is used for type references like `@my_type`.
+	ReferenceResolution
+
+	// MethodResolution is used for func references like `@my_type::DoStuff`.
+	MethodResolution
+
+	// FactoryResolution is used for arguments that are TypeFactory instances themselves.
+	FactoryResolution
+
+	// CustomResolution is used for arguments that have been resolved by a custom ArgumentResolver.
+	CustomResolution
+)
+
+// String implements the fmt.Stringer interface.
+func (k ResolutionKind) String() string {
+	switch k {
+	case LiteralResolution:
+		return "literal"
+	case ParameterResolution:
+		return "parameter"
+	case ReferenceResolution:
+		return "reference"
+	case MethodResolution:
+		return "method"
+	case FactoryResolution:
+		return "factory"
+	case CustomResolution:
+		return "custom"
+	default:
+		return "unknown"
 	}
 }
 
@@ -21,41 +97,264 @@ func NewParameterResolver(container *Container) *ParameterResolver {
 // Parameters must always have the form `%my.beautiful.param%.
 // Type references must have the form `@my_type.bla`.
 // It is also legal to request an optional type using the syntax `@?my_optional_type`.
+// References like `@my_type:io.Writer` additionally assert that the referenced type implements the given interface
+// (see Container.RegisterInterface).
 // If this type is not registered Resolve will not return an error but instead give you the null value
 // of the expected type.
+// Referenced types whose type is not assignable but convertible into the expected type without losing information
+// (e.g. a named string type that is passed as string) are converted into the expected type.
+// Method chains like `@my_type::Config()::Timeout` call the methods (with parentheses) and read the struct fields
+// (without parentheses) of the referenced type one after another and resolve to the last result.
+// Fallback chains like `@primary|@secondary|%default%` resolve to the first alternative that is available
+// (see FallbackAlternatives). An error is only returned if none of the alternatives can be resolved.
+// Parameters whose configured value is a type reference (e.g. "@my_type") resolve to the referenced type.
+// Prefix the value with a backslash (e.g. `\@not_a_type`) if you want to use it as a literal string instead.
+// Arguments that are a TypeFactory themselves are generated using this resolver and passed as anonymous type.
+// Arguments that have been wrapped using Cast are resolved and then converted into the type of the cast.
+// Maps that are passed to map arguments and contain parameters or type references (e.g. {"a": "@svc_a", "b": "%b%"})
+// are resolved value by value. Their keys are converted into the key type of the expected map if necessary.
+// All other arguments are passed to the custom ArgumentResolvers before they are returned as is.
 func (r *ParameterResolver) Resolve(parameter reflect.Value, expectedType reflect.Type) (reflect.Value, error) {
+	result, _, err := r.resolve(parameter, expectedType)
+	return result, err
+}
+
+// ResolveDetailed behaves exactly like Resolve but additionally returns how the given argument has been resolved.
+// This information can be used by tools that need to distinguish between literals, parameters and references.
+func (r *ParameterResolver) ResolveDetailed(argument interface{}, expectedType reflect.Type) (reflect.Value, ResolutionKind, error) {
+	return r.resolve(reflect.ValueOf(argument), expectedType)
+}
+
+// Context returns the context that has been passed to Container.GetWithContext or context.Background()
+// if the types are generated without a context.
+func (r *ParameterResolver) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
+	return r.ctx
+}
+
+// withContext returns a copy of this resolver that resolves ContextArgument to the given context.
+func (r *ParameterResolver) withContext(ctx context.Context) *ParameterResolver {
+	return &ParameterResolver{
+		Container:         r.Container,
+		ArgumentResolvers: r.ArgumentResolvers,
+		CacheParameters:   r.CacheParameters,
+		CollectErrors:     r.CollectErrors,
+		cache:             r.cache,
+		ctx:               ctx,
+		generating:        r.generating,
+	}
+}
+
+// withGenerating returns a copy of this resolver that records that the type with the given ID is being generated.
+// It is used to detect circular references between types.
+func (r *ParameterResolver) withGenerating(typeID string) *ParameterResolver {
+	resolver := r.withContext(r.ctx)
+	resolver.generating = append(append([]string{}, r.generating...), typeID)
+	return resolver
+}
+
+// isGenerating returns true if the type with the given ID is generated by this resolver or one of its callers.
+func (r *ParameterResolver) isGenerating(typeID string) bool {
+	for _, id := range r.generating {
+		if id == typeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// get retrieves the type with the given ID from the container and uses this resolver if it needs to be generated.
+// Errors are passed to the OnError hook of the container unless this resolver is used by a type that is still being
+// generated since that type reports the error itself if it can not be generated.
+func (r *ParameterResolver) get(typeID string) (interface{}, error) {
+	instance, isDefined, err := r.Container.generate(typeID, r)
+	if err == nil && isDefined == false {
+		err = newUnknownTypeReferenceError(typeID, "no such type has been defined")
+	}
+
+	if err != nil {
+		if r.isNested() {
+			return nil, err
+		}
+		return nil, r.Container.handleError(typeID, err)
+	}
+
+	return instance, nil
+}
+
+// isNested returns true if any of the types this resolver has been created for is still being generated.
+func (r *ParameterResolver) isNested() bool {
+	r.Container.mutex.Lock()
+	defer r.Container.mutex.Unlock()
+	for _, typeID := range r.generating {
+		if _, isGenerating := r.Container.generating[typeID]; isGenerating {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ParameterResolver) resolve(parameter reflect.Value, expectedType reflect.Type) (reflect.Value, ResolutionKind, error) {
+	if parameter.IsValid() {
+		if factory, isFactory := parameter.Interface().(TypeFactory); isFactory {
+			result, err := r.resolveInlineFactory(factory, expectedType)
+			return result, FactoryResolution, err
+		}
+
+		if cast, isCast := parameter.Interface().(*CastArgument); isCast {
+			return r.resolveCast(cast, expectedType)
+		}
+
+		if inlined, isInlined := parameter.Interface().(*InlinedParameter); isInlined {
+			result, err := r.resolveInlinedParameter(inlined, expectedType)
+			return result, ParameterResolution, err
+		}
+
+		if _, isContext := parameter.Interface().(contextArgument); isContext {
+			result, err := r.resolveContext(expectedType)
+			return result, LiteralResolution, err
+		}
+
+		if _, isContainer := parameter.Interface().(containerArgument); isContainer {
+			result, err := r.resolveContainer(expectedType)
+			return result, LiteralResolution, err
+		}
+
+		if parameters, isParameters := parameter.Interface().(parametersArgument); isParameters {
+			result, err := r.resolveParameters(parameters, expectedType)
+			return result, ParameterResolution, err
+		}
+	}
+
+	if isResolvableMap(parameter, expectedType) {
+		result, err := r.resolveMap(parameter, expectedType)
+		return result, LiteralResolution, err
+	}
+
 	if parameter.Kind() != reflect.String {
-		return parameter, nil
+		return r.resolveLiteral(parameter, expectedType)
 	}
 
 	stringParameter := parameter.Interface().(string)
+	if alternatives := FallbackAlternatives(stringParameter); alternatives != nil {
+		return r.resolveFallbackChain(stringParameter, alternatives, expectedType)
+	}
+
 	if IsParameterOrTypeReference(stringParameter) == false {
-		return parameter, nil
+		return r.resolveLiteral(parameter, expectedType)
 	}
 
 	if IsTypeReference(stringParameter) {
-		return r.resolveTypeReference(stringParameter, expectedType)
+		kind := ReferenceResolution
+		if NewTypeID(stringParameter).IsFuncReference {
+			kind = MethodResolution
+		}
+
+		result, err := r.resolveTypeReference(stringParameter, expectedType)
+		return result, kind, err
 	}
 
-	return r.resolveParameter(parameter, stringParameter, expectedType), nil
+	result, err := r.resolveParameter(parameter, stringParameter, expectedType)
+	return result, ParameterResolution, err
 }
 
-func (r *ParameterResolver) resolveParameter(parameter reflect.Value, stringParameter string, expectedType reflect.Type) reflect.Value {
+func (r *ParameterResolver) resolveParameter(parameter reflect.Value, stringParameter string, expectedType reflect.Type) (reflect.Value, error) {
 	parameterName := stringParameter[1 : len(stringParameter)-1]
-	configuredValue, isConfigured := r.Container.Config[parameterName]
+	version := r.Container.parametersVersion.Load()
+	if r.CacheParameters {
+		if cached, isCached := r.cachedParameter(parameterName, expectedType); isCached {
+			return cached, nil
+		}
+	}
+
+	configuredValue, isConfigured := r.Container.parameter(parameterName)
 	if isConfigured == false {
-		return parameter
+		return parameter, nil
+	}
+
+	if s, isString := configuredValue.(string); isString && IsTypeReference(s) {
+		// referenced types are never cached since they do not depend on the parameters only
+		return r.resolveTypeReference(s, expectedType)
+	}
+
+	parameter, err := r.resolveParameterValue(parameterName, configuredValue, expectedType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if r.CacheParameters {
+		r.cacheParameter(parameterName, expectedType, parameter, version)
+	}
+
+	return parameter, nil
+}
+
+// resolveParameterValue converts the value of the parameter with the given name into the expected type.
+// Values which are type references are resolved to the referenced type and template parameters are rendered first.
+func (r *ParameterResolver) resolveParameterValue(parameterName string, value interface{}, expectedType reflect.Type) (reflect.Value, error) {
+	if s, isString := value.(string); isString && strings.HasPrefix(s, `\@`) {
+		// escaped type references are used as literal strings
+		value = s[1:]
+	} else if isString && IsTypeReference(s) {
+		return r.resolveTypeReference(s, expectedType)
+	}
+
+	if s, isString := value.(string); isString && strings.HasPrefix(s, TemplateParameterPrefix) {
+		rendered, err := r.renderTemplateParameter(parameterName, s[len(TemplateParameterPrefix):])
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value = rendered
+	}
+
+	parameter, err := coerce(value, expectedType)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid value of parameter %q: %s", parameterName, err)
+	}
+
+	return parameter, nil
+}
+
+// resolveInlineFactory generates an anonymous type that has been passed as argument instead of a type reference.
+func (r *ParameterResolver) resolveInlineFactory(factory TypeFactory, expectedType reflect.Type) (reflect.Value, error) {
+	instance, err := factory.Generate(r)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("could not generate inline type: %s", err)
+	}
+
+	if instance == nil || reflect.TypeOf(instance).AssignableTo(expectedType) == false {
+		return reflect.Value{}, fmt.Errorf("the inline type (type %T) is not assignable to the expected type %v", instance, expectedType)
+	}
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(reflect.ValueOf(instance))
+	return result, nil
+}
+
+// renderTemplateParameter evaluates the given text/template using all configured parameters as data.
+func (r *ParameterResolver) renderTemplateParameter(parameterName, text string) (string, error) {
+	t, err := template.New(parameterName).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse template of parameter %q: %s", parameterName, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, r.Container.parameters()); err != nil {
+		return "", fmt.Errorf("could not render template of parameter %q: %s", parameterName, err)
 	}
 
-	parameter = reflect.New(expectedType).Elem()
-	parameter.Set(reflect.ValueOf(configuredValue))
-	return parameter
+	return buf.String(), nil
 }
 
 func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expectedType reflect.Type) (reflect.Value, error) {
 	t := NewTypeID(typeIDAndPrefix)
 
-	typeInstance, typeDefined, err := r.Container.get(t.ID)
+	typeInstance, typeDefined, err := r.Container.generate(t.ID, r)
 	if err != nil {
 		return reflect.Zero(expectedType), err
 	}
@@ -68,6 +367,30 @@ func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expecte
 		return reflect.Value{}, newUnknownTypeReferenceError(t.ID, `the referenced type "@%s" has not been defined`, t.ID)
 	}
 
+	if t.HasIndex {
+		if typeInstance, err = referencedElement(t, typeInstance); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	if len(t.Chain) > 0 {
+		if typeInstance, err = resolveChain(t, typeInstance); err != nil {
+			return reflect.Value{}, err
+		}
+
+		if typeInstance == nil {
+			return reflect.Zero(expectedType), nil
+		}
+	}
+
+	if err = r.Container.checkRequiredInterface(t, typeInstance); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if t.AsInterface {
+		return r.resolveInterfaceView(t, typeInstance, expectedType)
+	}
+
 	if t.IsFuncReference {
 		method := reflect.ValueOf(typeInstance).MethodByName(t.FuncReferenceMethod)
 
@@ -78,6 +401,10 @@ func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expecte
 		return method, nil
 	}
 
+	if isLosslessConversion(reflect.TypeOf(typeInstance), expectedType) {
+		return reflect.ValueOf(typeInstance).Convert(expectedType), nil
+	}
+
 	if reflect.TypeOf(typeInstance).AssignableTo(expectedType) == false {
 		return reflect.Value{}, newTypeReferenceError(t.ID, typeInstance,
 			`the referenced type %q (type %T) is not assignable to the expected type %v`, t.Raw, typeInstance, expectedType,
@@ -88,3 +415,21 @@ func (r *ParameterResolver) resolveTypeReference(typeIDAndPrefix string, expecte
 	result.Set(reflect.ValueOf(typeInstance))
 	return result, nil
 }
+
+// resolveInterfaceView returns the type instance typed as the interface of a reference like "@buffer as io.Reader".
+// The interface itself (and not the concrete type of the instance) must be assignable to the expected type.
+func (r *ParameterResolver) resolveInterfaceView(t *TypeID, typeInstance interface{}, expectedType reflect.Type) (reflect.Value, error) {
+	ifaceType := r.Container.interfaces[t.RequiredInterface]
+	if ifaceType.AssignableTo(expectedType) == false {
+		return reflect.Value{}, newTypeReferenceError(t.ID, typeInstance,
+			`the referenced type %q (type %T) is resolved as %v which is not assignable to the expected type %v`, t.Raw, typeInstance, ifaceType, expectedType,
+		)
+	}
+
+	view := reflect.New(ifaceType).Elem()
+	view.Set(reflect.ValueOf(typeInstance))
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(view)
+	return result, nil
+}
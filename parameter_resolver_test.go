@@ -1,6 +1,9 @@
 package goldi_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"reflect"
 
 	"github.com/fgrosse/goldi"
@@ -76,6 +79,177 @@ var _ = Describe("ParameterResolver", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.Interface()).To(Equal(config["bar"]))
 			})
+
+			It("should convert parameters to the expected type", func() {
+				config["bar"] = "42"
+				parameter := reflect.ValueOf("%bar%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal(42))
+			})
+
+			It("should convert list parameters into string slices", func() {
+				config["allowed.origins"] = []interface{}{"example.com", "example.org"}
+				parameter := reflect.ValueOf("%allowed.origins%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf([]string{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal([]string{"example.com", "example.org"}))
+			})
+
+			It("should convert each element of list parameters", func() {
+				config["ports"] = []interface{}{80, "443", 8080.0}
+				parameter := reflect.ValueOf("%ports%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf([]int{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(Equal([]int{80, 443, 8080}))
+			})
+
+			It("should convert empty lists into non-nil empty slices", func() {
+				config["ports"] = []interface{}{}
+				parameter := reflect.ValueOf("%ports%")
+
+				result, err := resolver.Resolve(parameter, reflect.TypeOf([]int{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsNil()).To(BeFalse())
+				Expect(result.Len()).To(BeZero())
+			})
+
+			It("should return an error if an element of a list parameter can not be converted", func() {
+				config["ports"] = []interface{}{80, "https"}
+				parameter := reflect.ValueOf("%ports%")
+
+				_, err := resolver.Resolve(parameter, reflect.TypeOf([]int{}))
+				Expect(err).To(MatchError(`invalid value of parameter "ports": can not convert element 1: can not convert "https" to int: strconv.ParseInt: parsing "https": invalid syntax`))
+			})
+
+			It("should return an error if the parameter can not be converted to the expected type", func() {
+				config["bar"] = true
+				parameter := reflect.ValueOf("%bar%")
+
+				_, err := resolver.Resolve(parameter, reflect.TypeOf(0))
+				Expect(err).To(MatchError(`invalid value of parameter "bar": can not convert true (type bool) to int`))
+			})
+		})
+	})
+
+	Context("with template parameters", func() {
+		It("should render the template using the other parameters", func() {
+			config["host"] = "example.com"
+			config["port"] = 8080
+			config["address"] = "tmpl:{{.host}}:{{.port}}"
+			parameter := reflect.ValueOf("%address%")
+
+			result, err := resolver.Resolve(parameter, parameter.Type())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal("example.com:8080"))
+		})
+
+		It("should return an error if the template references a missing parameter", func() {
+			config["address"] = "tmpl:{{.host}}:{{.port}}"
+			config["host"] = "example.com"
+			parameter := reflect.ValueOf("%address%")
+
+			_, err := resolver.Resolve(parameter, parameter.Type())
+			Expect(err).To(MatchError(ContainSubstring(`could not render template of parameter "address"`)))
+			Expect(err).To(MatchError(ContainSubstring(`map has no entry for key "port"`)))
+		})
+
+		It("should return an error if the template is invalid", func() {
+			config["address"] = "tmpl:{{.host"
+			parameter := reflect.ValueOf("%address%")
+
+			_, err := resolver.Resolve(parameter, parameter.Type())
+			Expect(err).To(MatchError(ContainSubstring(`could not parse template of parameter "address"`)))
+		})
+	})
+
+	Context("with parameters that reference a type", func() {
+		It("should resolve the referenced type", func() {
+			container.Register("foo", goldi.NewType(NewMockTypeWithArgs, "I am foo", true))
+			config["backend"] = "@foo"
+			parameter := reflect.ValueOf("%backend%")
+
+			result, err := resolver.Resolve(parameter, reflect.TypeOf(&MockType{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("foo")))
+		})
+
+		It("should return an error if the referenced type has not been defined", func() {
+			config["backend"] = "@foo"
+			parameter := reflect.ValueOf("%backend%")
+
+			_, err := resolver.Resolve(parameter, reflect.TypeOf(&MockType{}))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should treat escaped references as literal strings", func() {
+			config["twitter_handle"] = `\@fgrosse`
+			parameter := reflect.ValueOf("%twitter_handle%")
+
+			result, err := resolver.Resolve(parameter, parameter.Type())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal("@fgrosse"))
+		})
+	})
+
+	Context("with inline type factories", func() {
+		It("should generate the inline type and return it", func() {
+			parameter := reflect.ValueOf(goldi.NewStructType(new(MockType), "inline", true))
+			expectedType := reflect.TypeOf(&MockType{})
+
+			result, err := resolver.Resolve(parameter, expectedType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal(&MockType{StringParameter: "inline", BoolParameter: true}))
+		})
+
+		It("should return an error if the inline type is not assignable to the expected type", func() {
+			parameter := reflect.ValueOf(goldi.NewType(NewFoo))
+			expectedType := reflect.TypeOf(&MockType{})
+
+			_, err := resolver.Resolve(parameter, expectedType)
+			Expect(err).To(MatchError("the inline type (type *goldi_test.Foo) is not assignable to the expected type *goldi_test.MockType"))
+		})
+
+		It("should return an error if the inline type can not be generated", func() {
+			parameter := reflect.ValueOf(goldi.NewStructType(nil))
+			_, err := resolver.Resolve(parameter, reflect.TypeOf(&MockType{}))
+			Expect(err).To(MatchError("could not generate inline type: the given struct is nil"))
+		})
+	})
+
+	Context("with cast arguments", func() {
+		interfaceType := reflect.TypeOf((*interface{})(nil)).Elem()
+
+		It("should convert parameters into the type of the cast", func() {
+			config["limit"] = 42
+			result, err := resolver.Resolve(reflect.ValueOf(goldi.Cast("%limit%", "int64")), interfaceType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal(int64(42)))
+		})
+
+		It("should convert literals into the type of the cast", func() {
+			result, err := resolver.Resolve(reflect.ValueOf(goldi.Cast(2.5, "float32")), interfaceType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Interface()).To(Equal(float32(2.5)))
+		})
+
+		It("should return an error if the value can not be converted", func() {
+			config["limit"] = "many"
+			_, err := resolver.Resolve(reflect.ValueOf(goldi.Cast("%limit%", "int64")), interfaceType)
+			Expect(err).To(MatchError(ContainSubstring(`invalid value of parameter "limit": can not convert "many" to int64`)))
+		})
+
+		It("should return an error if the type of the cast is unknown", func() {
+			_, err := resolver.Resolve(reflect.ValueOf(goldi.Cast(42, "complex128")), interfaceType)
+			Expect(err).To(MatchError(`can not cast 42 to unknown type "complex128"`))
+		})
+
+		It("should return an error if the cast is not assignable to the expected type", func() {
+			_, err := resolver.Resolve(reflect.ValueOf(goldi.Cast(42, "int64")), reflect.TypeOf(""))
+			Expect(err).To(MatchError(`the cast of 42 (type int64) is not assignable to the expected type string`))
 		})
 	})
 
@@ -96,6 +270,65 @@ var _ = Describe("ParameterResolver", func() {
 				})
 			})
 
+			Context("when the reference requires an interface", func() {
+				interfaceType := reflect.TypeOf((*interface{})(nil)).Elem()
+
+				It("should return the type if it implements the interface", func() {
+					container.InjectInstance("buffer", new(bytes.Buffer))
+
+					result, err := resolver.Resolve(reflect.ValueOf("@buffer:io.Writer"), interfaceType)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("buffer")))
+				})
+
+				It("should return an error if the type does not implement the interface", func() {
+					_, err := resolver.Resolve(reflect.ValueOf("@foo:io.Writer"), interfaceType)
+					Expect(err).To(MatchError(`the referenced type "@foo:io.Writer" (type *goldi_test.Foo) does not implement the required interface io.Writer`))
+				})
+
+				It("should support custom interfaces", func() {
+					Expect(container.RegisterInterface("goldi_test.Stringer", (*fmt.Stringer)(nil))).To(Succeed())
+					_, err := resolver.Resolve(reflect.ValueOf("@foo:goldi_test.Stringer"), interfaceType)
+					Expect(err).To(MatchError(`the referenced type "@foo:goldi_test.Stringer" (type *goldi_test.Foo) does not implement the required interface fmt.Stringer`))
+				})
+
+				It("should not register interfaces whose name is not package qualified", func() {
+					Expect(container.RegisterInterface("Stringer", (*fmt.Stringer)(nil))).To(MatchError(
+						`goldi: can not register interface "Stringer": the name must be package qualified (e.g. "mypkg.Logger")`,
+					))
+				})
+
+				It("should return an error if the interface has not been registered", func() {
+					_, err := resolver.Resolve(reflect.ValueOf("@foo:foo.Bar"), interfaceType)
+					Expect(err).To(MatchError(`the interface "foo.Bar" required by "@foo:foo.Bar" has not been registered`))
+				})
+			})
+
+			Context("when the reference requests the type as a specific interface", func() {
+				BeforeEach(func() {
+					container.InjectInstance("buffer", new(bytes.Buffer))
+				})
+
+				It("should return the type as that interface", func() {
+					readerType := reflect.TypeOf((*io.Reader)(nil)).Elem()
+					result, err := resolver.Resolve(reflect.ValueOf("@buffer as io.Reader"), readerType)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Type()).To(Equal(readerType))
+					Expect(result.Interface()).To(BeIdenticalTo(container.MustGet("buffer")))
+				})
+
+				It("should return an error if the interface is not assignable to the expected type", func() {
+					writerType := reflect.TypeOf((*io.Writer)(nil)).Elem()
+					_, err := resolver.Resolve(reflect.ValueOf("@buffer as io.Reader"), writerType)
+					Expect(err).To(MatchError(`the referenced type "@buffer as io.Reader" (type *bytes.Buffer) is resolved as io.Reader which is not assignable to the expected type io.Writer`))
+				})
+
+				It("should return an error if the type does not implement the interface", func() {
+					_, err := resolver.Resolve(reflect.ValueOf("@foo as io.Reader"), reflect.TypeOf((*io.Reader)(nil)).Elem())
+					Expect(err).To(MatchError(`the referenced type "@foo as io.Reader" (type *goldi_test.Foo) does not implement the required interface io.Reader`))
+				})
+			})
+
 			Context("when the type is not assignable to the expected type", func() {
 				It("should return an error", func() {
 					parameter := reflect.ValueOf("@foo")
@@ -108,6 +341,35 @@ var _ = Describe("ParameterResolver", func() {
 				})
 			})
 
+			Context("when the type is convertible into the expected type", func() {
+				It("should convert named types into their underlying type", func() {
+					container.InjectInstance("key", contextKey("request_id"))
+
+					result, err := resolver.Resolve(reflect.ValueOf("@key"), reflect.TypeOf(""))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Interface()).To(Equal("request_id"))
+				})
+
+				It("should convert underlying types into named types", func() {
+					container.InjectInstance("key", "request_id")
+
+					result, err := resolver.Resolve(reflect.ValueOf("@key"), reflect.TypeOf(contextKey("")))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Interface()).To(Equal(contextKey("request_id")))
+				})
+
+				It("should not convert types if information could be lost", func() {
+					container.InjectInstance("number", 65)
+					container.InjectInstance("float", 1.5)
+
+					_, err := resolver.Resolve(reflect.ValueOf("@number"), reflect.TypeOf(""))
+					Expect(err).To(MatchError(`the referenced type "@number" (type int) is not assignable to the expected type string`))
+
+					_, err = resolver.Resolve(reflect.ValueOf("@float"), reflect.TypeOf(0))
+					Expect(err).To(MatchError(`the referenced type "@float" (type float64) is not assignable to the expected type int`))
+				})
+			})
+
 			Context("when a func reference type is requested", func() {
 				It("should generate the type and return the function", func() {
 					foo := &Foo{Value: "Success!"}
@@ -135,6 +397,56 @@ var _ = Describe("ParameterResolver", func() {
 			})
 		})
 
+		Context("when the reference contains an index", func() {
+			var first, second *Foo
+
+			BeforeEach(func() {
+				first, second = &Foo{Value: "first"}, &Foo{Value: "second"}
+				container.InjectInstance("foos", []*Foo{first, second})
+				container.InjectInstance("foos_by_name", map[string]*Foo{"first": first, "second": second})
+				container.InjectInstance("foos_by_id", &map[int]*Foo{1: first, 2: second})
+			})
+
+			It("should return the element of a slice", func() {
+				result, err := resolver.Resolve(reflect.ValueOf("@foos[1]"), reflect.TypeOf(&Foo{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(BeIdenticalTo(second))
+			})
+
+			It("should return the element of a map", func() {
+				result, err := resolver.Resolve(reflect.ValueOf("@foos_by_name[first]"), reflect.TypeOf(&Foo{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(BeIdenticalTo(first))
+			})
+
+			It("should convert the key into the key type of the map", func() {
+				result, err := resolver.Resolve(reflect.ValueOf("@foos_by_id[2]"), reflect.TypeOf(&Foo{}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Interface()).To(BeIdenticalTo(second))
+			})
+
+			It("should return an error if the index is out of range", func() {
+				_, err := resolver.Resolve(reflect.ValueOf("@foos[2]"), reflect.TypeOf(&Foo{}))
+				Expect(err).To(MatchError(`the index of the referenced type "@foos[2]" is out of range (length 2)`))
+			})
+
+			It("should return an error if the index is no integer", func() {
+				_, err := resolver.Resolve(reflect.ValueOf("@foos[first]"), reflect.TypeOf(&Foo{}))
+				Expect(err).To(MatchError(`the index of the referenced type "@foos[first]" must be an integer`))
+			})
+
+			It("should return an error if the key does not exist", func() {
+				_, err := resolver.Resolve(reflect.ValueOf("@foos_by_name[third]"), reflect.TypeOf(&Foo{}))
+				Expect(err).To(MatchError(`the key of the referenced type "@foos_by_name[third]" does not exist`))
+			})
+
+			It("should return an error if the referenced type can not be indexed", func() {
+				container.InjectInstance("foo", first)
+				_, err := resolver.Resolve(reflect.ValueOf("@foo[0]"), reflect.TypeOf(&Foo{}))
+				Expect(err).To(MatchError(`the referenced type "@foo[0]" (type *goldi_test.Foo) can not be indexed`))
+			})
+		})
+
 		Context("when the type has not been registered", func() {
 			It("should return an error", func() {
 				parameter := reflect.ValueOf("@foo")
@@ -160,4 +472,46 @@ var _ = Describe("ParameterResolver", func() {
 			})
 		})
 	})
+
+	Describe("ResolveDetailed", func() {
+		BeforeEach(func() {
+			config["foo"] = "bar"
+			container.RegisterType("mock", NewMockType)
+		})
+
+		It("should return the kind of each resolved argument", func() {
+			stringType := reflect.TypeOf("")
+			_, kind, err := resolver.ResolveDetailed("foo", stringType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.LiteralResolution))
+
+			_, kind, err = resolver.ResolveDetailed(42, reflect.TypeOf(42))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.LiteralResolution))
+
+			result, kind, err := resolver.ResolveDetailed("%foo%", stringType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.ParameterResolution))
+			Expect(result.Interface()).To(Equal("bar"))
+
+			result, kind, err = resolver.ResolveDetailed("@mock", reflect.TypeOf(&MockType{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.ReferenceResolution))
+			Expect(result.Interface()).To(BeAssignableToTypeOf(&MockType{}))
+
+			_, kind, err = resolver.ResolveDetailed("@mock::DoStuff", reflect.TypeOf(func() string { return "" }))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.MethodResolution))
+
+			_, kind, err = resolver.ResolveDetailed(goldi.NewType(NewMockType), reflect.TypeOf(&MockType{}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind).To(Equal(goldi.FactoryResolution))
+		})
+
+		It("should return the kind even if the resolution failed", func() {
+			_, kind, err := resolver.ResolveDetailed("@unknown", reflect.TypeOf(&MockType{}))
+			Expect(err).To(HaveOccurred())
+			Expect(kind).To(Equal(goldi.ReferenceResolution))
+		})
+	})
 })
@@ -0,0 +1,49 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type parametersArgument struct {
+	prefix string
+}
+
+// ParametersArgument can be used as factory argument to pass a copy of all parameters of the container to a
+// factory that accepts a map[string]interface{}. This is useful for constructors that read many configuration values.
+// Factories whose only argument is such a map receive the parameters automatically if no arguments are given:
+//
+//	container.Register("config", goldi.NewType(NewConfig))
+//	container.Register("server", goldi.NewType(NewServer, goldi.ParametersArgument, "@logger"))
+var ParametersArgument = parametersArgument{}
+
+// ParametersWithPrefix works like ParametersArgument but only passes the parameters whose names start with the
+// given prefix. The prefix is removed from the parameter names, so the parameter "database.host" is passed as "host"
+// when using the prefix "database.".
+func ParametersWithPrefix(prefix string) interface{} {
+	return parametersArgument{prefix: prefix}
+}
+
+var parametersType = reflect.TypeOf(map[string]interface{}{})
+
+func isParametersFactory(factoryType reflect.Type) bool {
+	return factoryType.NumIn() == 1 && factoryType.IsVariadic() == false && factoryType.In(0) == parametersType
+}
+
+func (r *ParameterResolver) resolveParameters(argument parametersArgument, expectedType reflect.Type) (reflect.Value, error) {
+	if parametersType.AssignableTo(expectedType) == false {
+		return reflect.Value{}, fmt.Errorf("the parameters argument can not be passed as %v", expectedType)
+	}
+
+	parameters := map[string]interface{}{}
+	for name, value := range r.Container.parameters() {
+		if strings.HasPrefix(name, argument.prefix) {
+			parameters[strings.TrimPrefix(name, argument.prefix)] = value
+		}
+	}
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(reflect.ValueOf(parameters))
+	return result, nil
+}
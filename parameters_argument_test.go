@@ -0,0 +1,59 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type TypeWithParameters struct {
+	Parameters map[string]interface{}
+}
+
+func NewTypeWithParameters(parameters map[string]interface{}) *TypeWithParameters {
+	return &TypeWithParameters{Parameters: parameters}
+}
+
+var _ = Describe("ParametersArgument", func() {
+	var (
+		container *goldi.Container
+		config    map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		config = map[string]interface{}{
+			"database.host": "localhost",
+			"database.port": 5432,
+			"debug":         true,
+		}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+	})
+
+	It("should pass all parameters to factories whose only argument is a parameter map", func() {
+		container.Register("config", goldi.NewType(NewTypeWithParameters))
+		t := container.MustGet("config").(*TypeWithParameters)
+		Expect(t.Parameters).To(Equal(config))
+	})
+
+	It("should pass a copy of the parameters", func() {
+		container.Register("config", goldi.NewType(NewTypeWithParameters, goldi.ParametersArgument))
+		t := container.MustGet("config").(*TypeWithParameters)
+		t.Parameters["debug"] = false
+		Expect(config["debug"]).To(BeTrue())
+	})
+
+	It("should only pass the parameters with the given prefix", func() {
+		container.Register("database_config", goldi.NewType(NewTypeWithParameters, goldi.ParametersWithPrefix("database.")))
+		t := container.MustGet("database_config").(*TypeWithParameters)
+		Expect(t.Parameters).To(Equal(map[string]interface{}{
+			"host": "localhost",
+			"port": 5432,
+		}))
+	})
+
+	It("should return an error if the parameters are passed to an incompatible argument", func() {
+		container.Register("invalid", goldi.NewType(NewMockTypeWithArgs, goldi.ParametersArgument, true))
+		_, err := container.Get("invalid")
+		Expect(err).To(MatchError(ContainSubstring("the parameters argument can not be passed as string")))
+	})
+})
@@ -0,0 +1,74 @@
+package goldi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadParametersFromFile reads parameters from the file at the given path and sets them on the container via
+// SetParameter, so parameters that have been loaded later override those that have been loaded earlier.
+//
+// Files with the extension ".json", ".yml" or ".yaml" must contain a single object that maps the parameter names to
+// their values. All other files are parsed as simple KEY=VALUE files (e.g. ".env" or ".properties" files). Blank lines
+// and lines starting with "#" are ignored and values may be enclosed in single or double quotes.
+// The values are converted to the types the factories expect when the parameters are resolved.
+func (c *Container) LoadParametersFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("goldi: could not load parameters: %w", err)
+	}
+
+	var parameters map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &parameters)
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(data, &parameters)
+	default:
+		parameters, err = parseKeyValueParameters(data)
+	}
+
+	if err != nil {
+		return fmt.Errorf("goldi: could not load parameters from %q: %w", path, err)
+	}
+
+	for name, value := range parameters {
+		c.SetParameter(name, value)
+	}
+
+	return nil
+}
+
+// parseKeyValueParameters parses KEY=VALUE lines while ignoring blank lines and comments.
+func parseKeyValueParameters(data []byte) (map[string]interface{}, error) {
+	parameters := map[string]interface{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE but got %q", lineNumber, line)
+		}
+
+		value := strings.TrimSpace(parts[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		parameters[name] = value
+	}
+
+	return parameters, scanner.Err()
+}
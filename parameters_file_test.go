@@ -0,0 +1,99 @@
+package goldi_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.LoadParametersFromFile", func() {
+	var (
+		container *goldi.Container
+		config    map[string]interface{}
+		dir       string
+	)
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		config = map[string]interface{}{"timeout": "10"}
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), config)
+
+		var err error
+		dir, err = os.MkdirTemp("", "goldi")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should load KEY=VALUE files", func() {
+		path := writeFile(".env", `
+			# the address of the server
+			host = localhost
+			port=8080
+
+			greeting="Hello = World"
+			empty=
+		`)
+
+		Expect(container.LoadParametersFromFile(path)).To(Succeed())
+		Expect(config).To(Equal(map[string]interface{}{
+			"timeout":  "10",
+			"host":     "localhost",
+			"port":     "8080",
+			"greeting": "Hello = World",
+			"empty":    "",
+		}))
+	})
+
+	It("should convert the loaded parameters when they are resolved", func() {
+		path := writeFile("app.properties", "port=8080\n")
+		Expect(container.LoadParametersFromFile(path)).To(Succeed())
+
+		port, err := container.Resolver.Resolve(reflect.ValueOf("%port%"), reflect.TypeOf(0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(port.Interface()).To(Equal(8080))
+	})
+
+	It("should load YAML and JSON files", func() {
+		Expect(container.LoadParametersFromFile(writeFile("params.yml", "host: localhost\nport: 8080\n"))).To(Succeed())
+		Expect(container.LoadParametersFromFile(writeFile("params.json", `{"debug": true}`))).To(Succeed())
+		Expect(config).To(Equal(map[string]interface{}{
+			"timeout": "10",
+			"host":    "localhost",
+			"port":    8080,
+			"debug":   true,
+		}))
+	})
+
+	It("should let later files override earlier ones", func() {
+		Expect(container.LoadParametersFromFile(writeFile("first.env", "host=first\nport=1\n"))).To(Succeed())
+		Expect(container.LoadParametersFromFile(writeFile("second.env", "host=second\n"))).To(Succeed())
+		Expect(config["host"]).To(Equal("second"))
+		Expect(config["port"]).To(Equal("1"))
+		Expect(config["timeout"]).To(Equal("10"))
+	})
+
+	It("should return an error if a line is invalid", func() {
+		path := writeFile(".env", "host=localhost\n\ninvalid line\n")
+		err := container.LoadParametersFromFile(path)
+		Expect(err).To(MatchError(`goldi: could not load parameters from "` + path + `": line 3: expected KEY=VALUE but got "invalid line"`))
+	})
+
+	It("should return an error if the file does not exist", func() {
+		err := container.LoadParametersFromFile(filepath.Join(dir, "missing.env"))
+		Expect(err).To(MatchError(HavePrefix("goldi: could not load parameters: ")))
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+})
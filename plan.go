@@ -0,0 +1,96 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A Plan describes which types would be generated by the container and in which order without actually
+// generating them. See Container.Plan.
+type Plan struct {
+	Steps []PlanStep
+}
+
+// A PlanStep is a single type of a Plan together with the IDs of the registered types it references directly.
+type PlanStep struct {
+	TypeID       string
+	Dependencies []string
+}
+
+// TypeIDs returns the IDs of all types of the plan in the order in which they would be generated.
+func (p *Plan) TypeIDs() []string {
+	typeIDs := make([]string, len(p.Steps))
+	for i, step := range p.Steps {
+		typeIDs[i] = step.TypeID
+	}
+
+	return typeIDs
+}
+
+// Plan computes which types would be generated if the types with the given IDs were requested from the container.
+// If no type IDs are given the plan contains all registered types (e.g. to preview BuildParallel).
+// No type factory is called to compute the plan.
+//
+// The steps of the plan are ordered such that each type comes after all types it depends on. Types which have
+// already been generated and references to types which have not been registered (e.g. optional types) are omitted.
+// Plan returns an error if one of the requested types has not been registered or if the types contain circular
+// references.
+func (c *Container) Plan(typeIDs ...string) (*Plan, error) {
+	if len(typeIDs) == 0 {
+		for typeID := range c.TypeRegistry {
+			typeIDs = append(typeIDs, typeID)
+		}
+		sort.Strings(typeIDs)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	plan := &Plan{Steps: []PlanStep{}}
+	planned, visiting := StringSet{}, StringSet{}
+	var visit func(typeID string, path []string) error
+	visit = func(typeID string, path []string) error {
+		if planned.Contains(typeID) {
+			return nil
+		}
+
+		if visiting.Contains(typeID) {
+			return fmt.Errorf("goldi: can not plan type %q: detected circular reference %q", path[0], append(path, typeID))
+		}
+		visiting.Set(typeID)
+
+		var dependencies []string
+		for dependency := range c.dependencies(c.TypeRegistry[typeID]) {
+			if _, isDefined := c.TypeRegistry[dependency]; isDefined {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		sort.Strings(dependencies)
+
+		for _, dependency := range dependencies {
+			if err := visit(dependency, append(path, typeID)); err != nil {
+				return err
+			}
+		}
+
+		delete(visiting, typeID)
+		planned.Set(typeID)
+		if _, isCached := c.typeCache[typeID]; isCached == false {
+			plan.Steps = append(plan.Steps, PlanStep{TypeID: typeID, Dependencies: dependencies})
+		}
+
+		return nil
+	}
+
+	for _, typeID := range typeIDs {
+		if _, isDefined := c.TypeRegistry[typeID]; isDefined == false {
+			return nil, newUnknownTypeReferenceError(typeID, "goldi: can not plan type %q: no such type has been defined", typeID)
+		}
+
+		if err := visit(typeID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,81 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Plan", func() {
+	var (
+		container *goldi.Container
+		generated []string
+	)
+
+	instrumented := func(typeID string) func() *MockType {
+		return func() *MockType {
+			generated = append(generated, typeID)
+			return &MockType{StringParameter: typeID}
+		}
+	}
+
+	BeforeEach(func() {
+		generated = nil
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("logger", goldi.NewType(instrumented("logger")))
+		container.Register("database", goldi.NewType(instrumented("database")))
+		container.Register("repository", goldi.NewType(NewTypeForServiceInjection, "@database"))
+		container.Register("service", goldi.NewType(func(*TypeForServiceInjection, *MockType, *MockType) *MockType {
+			return instrumented("service")()
+		}, "@repository", "@logger", "@?metrics"))
+		container.Register("unrelated", goldi.NewType(instrumented("unrelated")))
+	})
+
+	It("should list the types of the requested roots after their dependencies", func() {
+		plan, err := container.Plan("service")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.TypeIDs()).To(Equal([]string{"logger", "database", "repository", "service"}))
+		Expect(plan.Steps[2]).To(Equal(goldi.PlanStep{TypeID: "repository", Dependencies: []string{"database"}}))
+		Expect(plan.Steps[3]).To(Equal(goldi.PlanStep{TypeID: "service", Dependencies: []string{"logger", "repository"}}))
+		Expect(generated).To(BeEmpty())
+	})
+
+	It("should plan all registered types if no roots are given", func() {
+		plan, err := container.Plan()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.TypeIDs()).To(Equal([]string{"database", "logger", "repository", "service", "unrelated"}))
+		Expect(generated).To(BeEmpty())
+	})
+
+	It("should omit types that have already been generated", func() {
+		container.MustGet("repository")
+
+		plan, err := container.Plan("service")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.TypeIDs()).To(Equal([]string{"logger", "service"}))
+	})
+
+	It("should return an error if a requested type has not been defined", func() {
+		_, err := container.Plan("service", "foo")
+		Expect(err).To(MatchError(`goldi: can not plan type "foo": no such type has been defined`))
+	})
+
+	It("should include the references in map arguments, casts and parameters", func() {
+		container.SetParameter("logger_id", "@logger")
+		container.Register("report", goldi.NewType(func(map[string]interface{}, string, *MockType) *MockType {
+			return instrumented("report")()
+		}, map[string]interface{}{"db": "@database"}, goldi.Cast("@repository", "string"), "%logger_id%"))
+
+		plan, err := container.Plan("report")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Steps[len(plan.Steps)-1]).To(Equal(goldi.PlanStep{TypeID: "report", Dependencies: []string{"database", "logger", "repository"}}))
+	})
+
+	It("should return an error if the types contain circular references", func() {
+		container.Register("a", goldi.NewType(NewTypeForServiceInjection, "@b"))
+		container.Register("b", goldi.NewType(NewTypeForServiceInjection, "@a"))
+
+		_, err := container.Plan("a")
+		Expect(err).To(MatchError(`goldi: can not plan type "a": detected circular reference ["a" "b" "a"]`))
+	})
+})
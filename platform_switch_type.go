@@ -0,0 +1,63 @@
+package goldi
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// DefaultPlatform is the key of the type ID that is used by a platform switch type
+// if no type has been defined for the current platform.
+const DefaultPlatform = "default"
+
+type platformSwitchType struct {
+	platform   string
+	typeID     string
+	isSelected bool
+}
+
+// NewPlatformSwitchType creates a new TypeFactory which serves as alias to a platform specific type.
+// The keys of byPlatform are either operating systems like "linux" (see runtime.GOOS) or operating systems and
+// architectures like "linux/arm64". The values are the IDs of the types that should be used on the corresponding platform.
+// The most specific key is used. The type ID of the DefaultPlatform key is used if no other key matches.
+//
+// Goldigen yaml syntax example:
+//     file_watcher:
+//         platforms:
+//             linux:   "@inotify_watcher"
+//             darwin:  "@fsevents_watcher"
+//             default: "@polling_watcher"
+func NewPlatformSwitchType(byPlatform map[string]string) TypeFactory {
+	return NewPlatformSwitchTypeFor(runtime.GOOS, runtime.GOARCH, byPlatform)
+}
+
+// NewPlatformSwitchTypeFor behaves exactly like NewPlatformSwitchType but selects the type for the given
+// operating system and architecture instead of the current platform.
+func NewPlatformSwitchTypeFor(goos, goarch string, byPlatform map[string]string) TypeFactory {
+	t := &platformSwitchType{platform: goos + "/" + goarch}
+	for _, key := range []string{goos + "/" + goarch, goos, DefaultPlatform} {
+		if typeID, isDefined := byPlatform[key]; isDefined {
+			t.typeID, t.isSelected = typeID, true
+			break
+		}
+	}
+
+	return t
+}
+
+// Arguments returns the reference to the type that has been selected for the platform.
+func (t *platformSwitchType) Arguments() []interface{} {
+	if t.isSelected == false {
+		return []interface{}{}
+	}
+
+	return []interface{}{"@" + t.typeID}
+}
+
+// Generate retrieves the type that has been selected for the platform from the container.
+func (t *platformSwitchType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	if t.isSelected == false {
+		return nil, fmt.Errorf("no type has been defined for the platform %q", t.platform)
+	}
+
+	return resolver.get(t.typeID)
+}
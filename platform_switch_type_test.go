@@ -0,0 +1,82 @@
+package goldi_test
+
+import (
+	"runtime"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("platformSwitchType", func() {
+	var (
+		container  *goldi.Container
+		resolver   *goldi.ParameterResolver
+		byPlatform map[string]string
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+		container.Register("linux_type", goldi.NewStructType(Foo{}, "linux"))
+		container.Register("linux_arm_type", goldi.NewStructType(Foo{}, "linux/arm64"))
+		container.Register("default_type", goldi.NewStructType(Foo{}, "default"))
+
+		byPlatform = map[string]string{
+			"linux":         "linux_type",
+			"linux/arm64":   "linux_arm_type",
+			"windows/amd64": "windows_type",
+			"default":       "default_type",
+		}
+	})
+
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewPlatformSwitchType(byPlatform)
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	It("should select the type of the current platform", func() {
+		expected := goldi.NewPlatformSwitchTypeFor(runtime.GOOS, runtime.GOARCH, byPlatform)
+		Expect(goldi.NewPlatformSwitchType(byPlatform).Arguments()).To(Equal(expected.Arguments()))
+	})
+
+	It("should select the type of the operating system", func() {
+		generated, err := goldi.NewPlatformSwitchTypeFor("linux", "amd64", byPlatform).Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("linux"))
+	})
+
+	It("should prefer the type of the operating system and architecture", func() {
+		generated, err := goldi.NewPlatformSwitchTypeFor("linux", "arm64", byPlatform).Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("linux/arm64"))
+	})
+
+	It("should use the default type if no other platform matches", func() {
+		generated, err := goldi.NewPlatformSwitchTypeFor("darwin", "arm64", byPlatform).Generate(resolver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generated.(*Foo).Value).To(Equal("default"))
+	})
+
+	It("should return the reference to the selected type as argument", func() {
+		typeDef := goldi.NewPlatformSwitchTypeFor("linux", "arm64", byPlatform)
+		Expect(typeDef.Arguments()).To(Equal([]interface{}{"@linux_arm_type"}))
+	})
+
+	It("should return an error if the selected type has not been registered", func() {
+		typeDef := goldi.NewPlatformSwitchTypeFor("windows", "amd64", byPlatform)
+		_, err := typeDef.Generate(resolver)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error if no type has been defined for the platform", func() {
+		delete(byPlatform, "default")
+		typeDef := goldi.NewPlatformSwitchTypeFor("darwin", "arm64", byPlatform)
+		Expect(typeDef.Arguments()).To(BeEmpty())
+
+		_, err := typeDef.Generate(resolver)
+		Expect(err).To(MatchError(`no type has been defined for the platform "darwin/arm64"`))
+	})
+})
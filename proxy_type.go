@@ -38,7 +38,7 @@ func (t *proxyType) Arguments() []interface{} {
 }
 
 func (t *proxyType) Generate(resolver *ParameterResolver) (interface{}, error) {
-	referencedType, err := resolver.Container.Get(t.typeID.ID)
+	referencedType, err := resolver.get(t.typeID.ID)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate proxy type %s : type %s does not exist", t.typeID, t.typeID.ID)
 	}
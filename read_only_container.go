@@ -0,0 +1,127 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ReadOnlyContainer is a view of a Container that can only be used to retrieve types.
+// Services that need to look up other types at runtime (e.g. service locators) should depend on this interface
+// instead of *Container so they can not register, override or replace types.
+type ReadOnlyContainer interface {
+	// Get retrieves the type with the given ID just like Container.Get.
+	Get(typeID string) (interface{}, error)
+
+	// Has returns true if a type with the given ID has been defined.
+	Has(typeID string) bool
+
+	// TypeIDs returns the sorted IDs of all defined types.
+	TypeIDs() []string
+}
+
+type containerArgument struct{}
+
+// ContainerArgument can be used as factory argument to pass a ReadOnlyContainer of the container to the factory.
+// Factories receive the read-only container automatically for all parameters of type ReadOnlyContainer if exactly
+// these arguments have been omitted:
+//
+//	container.Register("handler_locator", goldi.NewType(NewHandlerLocator))
+//	container.Register("router", goldi.NewType(NewRouter, "@logger", goldi.ContainerArgument))
+var ContainerArgument = containerArgument{}
+
+var readOnlyContainerType = reflect.TypeOf((*ReadOnlyContainer)(nil)).Elem()
+
+// readOnlyContainer hides the mutating methods of the container so they can not be reached via a type assertion.
+// Types are retrieved using the resolver of the type the view has been injected into, so they are generated
+// with the same context (see Container.GetWithContext).
+type readOnlyContainer struct {
+	container *Container
+	resolver  *ParameterResolver
+}
+
+func (r readOnlyContainer) Get(typeID string) (interface{}, error) {
+	return r.resolver.get(typeID)
+}
+
+func (r readOnlyContainer) Has(typeID string) bool {
+	return r.container.Has(typeID)
+}
+
+func (r readOnlyContainer) TypeIDs() []string {
+	return r.container.TypeIDs()
+}
+
+// ReadOnly returns a view of this container that can only be used to retrieve types.
+func (c *Container) ReadOnly() ReadOnlyContainer {
+	return readOnlyContainer{container: c, resolver: c.Resolver}
+}
+
+// Has returns true if a type with the given ID has been defined in this container or in its fallback container.
+func (c *Container) Has(typeID string) bool {
+	if _, isDefined := c.TypeRegistry[typeID]; isDefined {
+		return true
+	}
+
+	return c.fallback != nil && c.fallback.Has(typeID)
+}
+
+// TypeIDs returns the alphabetically sorted IDs of all types that have been defined in this container or in its
+// fallback container.
+func (c *Container) TypeIDs() []string {
+	typeIDs := StringSet{}
+	for f := c; f != nil; f = f.fallback {
+		for typeID := range f.TypeRegistry {
+			typeIDs.Set(typeID)
+		}
+	}
+
+	sorted := make([]string, 0, len(typeIDs))
+	for typeID := range typeIDs {
+		sorted = append(sorted, typeID)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// withContainerArguments inserts a ContainerArgument for each parameter of type ReadOnlyContainer if the given
+// arguments of the factory lack exactly these parameters.
+func withContainerArguments(factoryType reflect.Type, parameters []interface{}) []interface{} {
+	if factoryType.IsVariadic() {
+		return parameters
+	}
+
+	numContainerParameters := 0
+	for i := 0; i < factoryType.NumIn(); i++ {
+		if factoryType.In(i) == readOnlyContainerType {
+			numContainerParameters++
+		}
+	}
+
+	if numContainerParameters == 0 || len(parameters)+numContainerParameters != factoryType.NumIn() {
+		return parameters
+	}
+
+	arguments := make([]interface{}, 0, factoryType.NumIn())
+	for i := 0; i < factoryType.NumIn(); i++ {
+		if factoryType.In(i) == readOnlyContainerType {
+			arguments = append(arguments, ContainerArgument)
+			continue
+		}
+
+		arguments = append(arguments, parameters[0])
+		parameters = parameters[1:]
+	}
+
+	return arguments
+}
+
+func (r *ParameterResolver) resolveContainer(expectedType reflect.Type) (reflect.Value, error) {
+	if readOnlyContainerType.AssignableTo(expectedType) == false {
+		return reflect.Value{}, fmt.Errorf("the container argument can not be passed as %v", expectedType)
+	}
+
+	result := reflect.New(expectedType).Elem()
+	result.Set(reflect.ValueOf(readOnlyContainer{container: r.Container, resolver: r}))
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package goldi_test
+
+import (
+	"reflect"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ServiceLocator struct {
+	Container goldi.ReadOnlyContainer
+	Name      string
+}
+
+func NewServiceLocator(container goldi.ReadOnlyContainer) *ServiceLocator {
+	return &ServiceLocator{Container: container}
+}
+
+func NewNamedServiceLocator(name string, container goldi.ReadOnlyContainer) *ServiceLocator {
+	return &ServiceLocator{Container: container, Name: name}
+}
+
+var _ = Describe("ReadOnlyContainer", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{"name": "locator"})
+		container.Register("mock", goldi.NewType(NewMockType))
+	})
+
+	It("should be injected automatically into factories that expect a ReadOnlyContainer", func() {
+		container.Register("locator", goldi.NewType(NewServiceLocator))
+		locator := container.MustGet("locator").(*ServiceLocator)
+		Expect(locator.Container).NotTo(BeNil())
+		Expect(locator.Container.Get("mock")).To(BeIdenticalTo(container.MustGet("mock")))
+	})
+
+	It("should be injected between the other arguments", func() {
+		container.Register("locator", goldi.NewType(NewNamedServiceLocator, "%name%"))
+		locator := container.MustGet("locator").(*ServiceLocator)
+		Expect(locator.Name).To(Equal("locator"))
+		Expect(locator.Container.Has("mock")).To(BeTrue())
+	})
+
+	It("should be injected via the ContainerArgument", func() {
+		container.Register("locator", goldi.NewType(NewNamedServiceLocator, "%name%", goldi.ContainerArgument))
+		Expect(container.MustGet("locator").(*ServiceLocator).Container.TypeIDs()).To(Equal([]string{"locator", "mock"}))
+	})
+
+	It("should return an error if the ContainerArgument is passed as another type", func() {
+		container.Register("locator", goldi.NewType(NewNamedServiceLocator, goldi.ContainerArgument, goldi.ContainerArgument))
+		_, err := container.Get("locator")
+		Expect(err).To(MatchError(ContainSubstring("the container argument can not be passed as string")))
+	})
+
+	It("should not expose the methods that modify the container", func() {
+		container.Register("locator", goldi.NewType(NewServiceLocator))
+		view := container.MustGet("locator").(*ServiceLocator).Container
+
+		_, isContainer := view.(*goldi.Container)
+		Expect(isContainer).To(BeFalse())
+
+		_, isRegistry := view.(interface {
+			Register(typeID string, typeDef goldi.TypeFactory)
+		})
+		Expect(isRegistry).To(BeFalse())
+
+		viewType := reflect.TypeOf(view)
+		for _, method := range []string{"Register", "RegisterType", "Override", "Replace", "InjectInstance", "SetParameter"} {
+			_, hasMethod := viewType.MethodByName(method)
+			Expect(hasMethod).To(BeFalse(), "the read-only container should not have the method %s", method)
+		}
+	})
+
+	Describe("Has", func() {
+		It("should return whether a type has been defined in the container or its fallback", func() {
+			fallback := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			fallback.Register("fallback_mock", goldi.NewType(NewMockType))
+			Expect(container.SetFallback(fallback)).To(Succeed())
+
+			Expect(container.Has("mock")).To(BeTrue())
+			Expect(container.Has("fallback_mock")).To(BeTrue())
+			Expect(container.Has("foo")).To(BeFalse())
+		})
+	})
+
+	Describe("TypeIDs", func() {
+		It("should return the sorted IDs of the types of the container and its fallback", func() {
+			fallback := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			fallback.Register("a", goldi.NewType(NewMockType))
+			fallback.Register("mock", goldi.NewType(NewMockType))
+			Expect(container.SetFallback(fallback)).To(Succeed())
+
+			Expect(container.TypeIDs()).To(Equal([]string{"a", "mock"}))
+		})
+	})
+})
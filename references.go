@@ -0,0 +1,91 @@
+package goldi
+
+import "reflect"
+
+// A Reference is a reference to another type that has been found in the arguments of a TypeFactory.
+type Reference struct {
+	*TypeID
+
+	// IsAlternative is true if the reference is an alternative of a fallback chain like "@foo|@bar".
+	// Such references may be undefined as long as another alternative of the chain can be resolved.
+	IsAlternative bool
+
+	// Parameter is the name of the parameter whose value is the reference (e.g. "logger" for the argument "%logger%"
+	// if that parameter has the value "@my_logger"). It is empty if the reference is used as argument directly.
+	Parameter string
+}
+
+// References returns all references to other types that are used by the arguments of the given TypeFactory.
+// This includes the type references in the arguments of inline type factories, of cast arguments and in the
+// values of map arguments as well as the alternatives of fallback chains and parameters whose value is a type
+// reference. The references are returned in the order of the arguments and may contain duplicates.
+func (c *Container) References(factory TypeFactory) []Reference {
+	var references []Reference
+	for _, argument := range factory.Arguments() {
+		references = c.argumentReferences(argument, references)
+	}
+
+	return references
+}
+
+func (c *Container) argumentReferences(argument interface{}, references []Reference) []Reference {
+	switch a := argument.(type) {
+	case string:
+		alternatives := FallbackAlternatives(a)
+		if alternatives == nil {
+			if reference, isReference := c.stringReference(a); isReference {
+				references = append(references, reference)
+			}
+			return references
+		}
+
+		for _, alternative := range alternatives {
+			if reference, isReference := c.stringReference(alternative); isReference {
+				reference.IsAlternative = true
+				references = append(references, reference)
+			}
+		}
+		return references
+	case *CastArgument:
+		return c.argumentReferences(a.Argument, references)
+	case *InlinedParameter:
+		if v, isString := a.Value.(string); isString && IsTypeReference(v) {
+			references = append(references, Reference{TypeID: NewTypeID(v), Parameter: a.Name})
+		}
+		return references
+	case TypeFactory:
+		for _, inlineArgument := range a.Arguments() {
+			references = c.argumentReferences(inlineArgument, references)
+		}
+		return references
+	}
+
+	if value := reflect.ValueOf(argument); value.Kind() == reflect.Map {
+		iter := value.MapRange()
+		for iter.Next() {
+			references = c.argumentReferences(iter.Value().Interface(), references)
+		}
+	}
+
+	return references
+}
+
+// stringReference returns the reference of the given string argument if it is a type reference or a parameter whose
+// value is a type reference.
+func (c *Container) stringReference(s string) (Reference, bool) {
+	if IsTypeReference(s) {
+		return Reference{TypeID: NewTypeID(s)}, true
+	}
+
+	if IsParameter(s) == false {
+		return Reference{}, false
+	}
+
+	name := s[1 : len(s)-1]
+	value, _ := c.parameter(name)
+	if v, isString := value.(string); isString && IsTypeReference(v) {
+		return Reference{TypeID: NewTypeID(v), Parameter: name}, true
+	}
+
+	return Reference{}, false
+}
@@ -0,0 +1,198 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RenameType moves the type that has been registered with oldID to newID and rewrites all references to the old ID
+// in the arguments of the other registered types (e.g. "@old_id" becomes "@new_id" and "@?old_id::Method" becomes
+// "@?new_id::Method"). Aliases, proxies, configurators, declared dependencies and interface bindings are updated as
+// well. An instance of the type that has already been generated is kept under the new ID.
+//
+// RenameType returns an error if no type has been registered with oldID, if newID has already been registered or
+// if the container has been frozen.
+// Note that references to oldID in the containers that use this container as fallback are not rewritten.
+func (c *Container) RenameType(oldID, newID string) error {
+	if err := c.checkNotFrozen("rename", oldID); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	factory, isDefined := c.TypeRegistry[oldID]
+	if isDefined == false {
+		return newUnknownTypeReferenceError(oldID, "goldi: can not rename type %q: no such type has been defined", oldID)
+	}
+
+	if _, exists := c.TypeRegistry[newID]; exists {
+		return fmt.Errorf("goldi: can not rename type %q to %q: a type with that ID has already been defined", oldID, newID)
+	}
+
+	delete(c.TypeRegistry, oldID)
+	c.TypeRegistry[newID] = factory
+	for _, registered := range c.TypeRegistry {
+		renameFactoryReferences(registered, oldID, newID)
+	}
+
+	if instance, isCached := c.typeCache[oldID]; isCached {
+		delete(c.typeCache, oldID)
+		c.typeCache[newID] = instance
+	}
+
+	if cachedAt, isCached := c.cachedAt[oldID]; isCached {
+		delete(c.cachedAt, oldID)
+		c.cachedAt[newID] = cachedAt
+	}
+
+	if stats, hasStats := c.stats[oldID]; hasStats {
+		delete(c.stats, oldID)
+		c.stats[newID] = stats
+	}
+
+	for i, typeID := range c.generated {
+		if typeID == oldID {
+			c.generated[i] = newID
+		}
+	}
+
+	for ifaceType, typeID := range c.bindings {
+		if typeID == oldID {
+			c.bindings[ifaceType] = newID
+		}
+	}
+
+	return nil
+}
+
+// renameFactoryReferences rewrites all references to oldID in the given type factory and its inline type factories.
+func renameFactoryReferences(factory TypeFactory, oldID, newID string) {
+	switch f := factory.(type) {
+	case *typeFactory:
+		renameArgumentValues(f.factoryArguments, oldID, newID)
+	case *singletonFuncType:
+		renameFactoryReferences(f.function, oldID, newID)
+	case *structType:
+		renameArgumentValues(f.structFields, oldID, newID)
+	case *proxyType:
+		f.typeID = renameTypeID(f.typeID, oldID, newID)
+		for i, argument := range f.args {
+			f.args[i] = renameArgument(argument, oldID, newID)
+		}
+	case *funcReferenceType:
+		f.typeID = renameTypeID(f.typeID, oldID, newID)
+	case *aliasType:
+		f.typeID = renameReference(f.typeID, oldID, newID)
+	case *platformSwitchType:
+		f.typeID = renameReference(f.typeID, oldID, newID)
+	case *envSwitchType:
+		f.typeID = renameReference(f.typeID, oldID, newID)
+	case *configuredType:
+		f.ConfiguratorTypeID = renameReference(f.ConfiguratorTypeID, oldID, newID)
+		renameFactoryReferences(f.embeddedType, oldID, newID)
+	case *declaredDependenciesType:
+		for i, dependency := range f.dependencies {
+			f.dependencies[i] = renameReference(dependency, oldID, newID)
+		}
+		renameFactoryReferences(f.embeddedType, oldID, newID)
+	case decorator:
+		renameFactoryReferences(f.embedded(), oldID, newID)
+	case *conditionalType:
+		renameFactoryReferences(f.ifTrue, oldID, newID)
+		renameFactoryReferences(f.ifFalse, oldID, newID)
+	case *multiTypeOutput:
+		renameFactoryReferences(f.factory, oldID, newID)
+	}
+}
+
+func renameArgumentValues(arguments []reflect.Value, oldID, newID string) {
+	for i, argument := range arguments {
+		switch {
+		case argument.Kind() == reflect.String && IsTypeReference(argument.String()):
+			renamed := reflect.New(argument.Type()).Elem()
+			renamed.SetString(renameReference(argument.String(), oldID, newID))
+			arguments[i] = renamed
+		case argument.IsValid() && argument.CanInterface():
+			renameArgument(argument.Interface(), oldID, newID)
+		}
+	}
+}
+
+// renameArgument returns the given argument with all references to oldID replaced by references to newID.
+// Inline type factories, cast arguments and the values of map arguments are modified in place.
+func renameArgument(argument interface{}, oldID, newID string) interface{} {
+	switch a := argument.(type) {
+	case string:
+		if IsTypeReference(a) {
+			return renameReference(a, oldID, newID)
+		}
+	case *CastArgument:
+		a.Argument = renameArgument(a.Argument, oldID, newID)
+	case *InlinedParameter:
+		if v, isString := a.Value.(string); isString && IsTypeReference(v) {
+			a.Value = renameReference(v, oldID, newID)
+		}
+	case TypeFactory:
+		renameFactoryReferences(a, oldID, newID)
+	default:
+		if value := reflect.ValueOf(argument); value.Kind() == reflect.Map {
+			renameMapValues(value, oldID, newID)
+		}
+	}
+
+	return argument
+}
+
+func renameMapValues(m reflect.Value, oldID, newID string) {
+	for _, key := range m.MapKeys() {
+		value := m.MapIndex(key)
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+
+		if value.IsValid() == false || value.CanInterface() == false {
+			continue
+		}
+
+		renamed, isString := renameArgument(value.Interface(), oldID, newID).(string)
+		if isString && renamed != value.String() && reflect.TypeOf(renamed).AssignableTo(m.Type().Elem()) {
+			m.SetMapIndex(key, reflect.ValueOf(renamed))
+		}
+	}
+}
+
+func renameTypeID(t *TypeID, oldID, newID string) *TypeID {
+	if t.ID != oldID {
+		return t
+	}
+
+	return NewTypeID(renameReference(t.String(), oldID, newID))
+}
+
+// renameReference replaces oldID in the given type reference with newID while keeping the prefixes and suffixes
+// of the reference. The reference may be given with or without the leading @ sign. The type references of fallback
+// chains are renamed individually.
+func renameReference(reference, oldID, newID string) string {
+	if alternatives := FallbackAlternatives(reference); alternatives != nil {
+		for i, alternative := range alternatives {
+			alternatives[i] = renameReference(alternative, oldID, newID)
+		}
+		return strings.Join(alternatives, "|")
+	}
+
+	if reference == "" || NewTypeID(reference).ID != oldID {
+		return reference
+	}
+
+	prefix, rest := "", reference
+	if strings.HasPrefix(rest, "@") {
+		prefix, rest = prefix+"@", rest[1:]
+	}
+	if strings.HasPrefix(rest, "?") {
+		prefix, rest = prefix+"?", rest[1:]
+	}
+
+	return prefix + newID + strings.TrimPrefix(rest, oldID)
+}
@@ -0,0 +1,80 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.RenameType", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("mock", goldi.NewType(NewMockType))
+	})
+
+	It("should move the type to the new ID", func() {
+		Expect(container.RenameType("mock", "renamed_mock")).To(Succeed())
+		Expect(container.TypeRegistry).NotTo(HaveKey("mock"))
+		Expect(container.MustGet("renamed_mock")).To(BeAssignableToTypeOf(&MockType{}))
+	})
+
+	It("should update the references of other types", func() {
+		container.Register("service", goldi.NewType(NewTypeForServiceInjection, "@mock"))
+		container.Register("optional_service", goldi.NewStructType(TypeForServiceInjection{}, "@?mock"))
+		container.Register("inline_service", goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewMockType)))
+		container.Register("alias", goldi.NewAliasType("mock"))
+		container.Register("stuff", goldi.NewFuncReferenceType("mock", "DoStuff"))
+
+		Expect(container.RenameType("mock", "renamed_mock")).To(Succeed())
+		Expect(container.TypeRegistry["service"].Arguments()).To(Equal([]interface{}{"@renamed_mock"}))
+		Expect(container.TypeRegistry["optional_service"].Arguments()).To(Equal([]interface{}{"@?renamed_mock"}))
+
+		mock := container.MustGet("renamed_mock")
+		Expect(container.MustGet("service").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(mock))
+		Expect(container.MustGet("optional_service").(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(mock))
+		Expect(container.MustGet("alias")).To(BeIdenticalTo(mock))
+		Expect(container.MustGet("stuff").(func() string)()).To(Equal("I did stuff"))
+	})
+
+	It("should update the references in map arguments", func() {
+		container.Register("registry", goldi.NewType(func(map[string]*MockType) *MockType { return nil },
+			map[string]interface{}{"a": "@mock", "b": "@?mock", "c": "hello"},
+		))
+
+		Expect(container.RenameType("mock", "renamed_mock")).To(Succeed())
+		Expect(container.TypeRegistry["registry"].Arguments()).To(Equal([]interface{}{
+			map[string]interface{}{"a": "@renamed_mock", "b": "@?renamed_mock", "c": "hello"},
+		}))
+	})
+
+	It("should not update references to types with a similar ID", func() {
+		container.Register("mock_2", goldi.NewType(NewMockType))
+		container.Register("service", goldi.NewType(NewTypeForServiceInjection, "@mock_2"))
+
+		Expect(container.RenameType("mock", "renamed_mock")).To(Succeed())
+		Expect(container.TypeRegistry["service"].Arguments()).To(Equal([]interface{}{"@mock_2"}))
+	})
+
+	It("should keep an instance that has already been generated", func() {
+		mock := container.MustGet("mock")
+		Expect(container.RenameType("mock", "renamed_mock")).To(Succeed())
+		Expect(container.MustGet("renamed_mock")).To(BeIdenticalTo(mock))
+	})
+
+	It("should return an error if the new type ID has already been defined", func() {
+		container.Register("other", goldi.NewType(NewMockType))
+		Expect(container.RenameType("mock", "other")).To(MatchError(`goldi: can not rename type "mock" to "other": a type with that ID has already been defined`))
+		Expect(container.TypeRegistry).To(HaveKey("mock"))
+	})
+
+	It("should return an error if the type has not been defined", func() {
+		Expect(container.RenameType("foo", "bar")).To(MatchError(`goldi: can not rename type "foo": no such type has been defined`))
+	})
+
+	It("should return an error if the container has been frozen", func() {
+		container.Freeze()
+		Expect(container.RenameType("mock", "renamed_mock")).To(MatchError(`goldi: can not rename type "mock": the container has been frozen`))
+	})
+})
@@ -0,0 +1,65 @@
+package goldi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// defaultInterfaces returns the interfaces that can be used in required interface assertions of every container.
+func defaultInterfaces() map[string]reflect.Type {
+	return map[string]reflect.Type{
+		"error":              reflect.TypeOf((*error)(nil)).Elem(),
+		"fmt.Stringer":       reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+		"io.Reader":          reflect.TypeOf((*io.Reader)(nil)).Elem(),
+		"io.Writer":          reflect.TypeOf((*io.Writer)(nil)).Elem(),
+		"io.Closer":          reflect.TypeOf((*io.Closer)(nil)).Elem(),
+		"io.ReadWriter":      reflect.TypeOf((*io.ReadWriter)(nil)).Elem(),
+		"io.ReadCloser":      reflect.TypeOf((*io.ReadCloser)(nil)).Elem(),
+		"io.WriteCloser":     reflect.TypeOf((*io.WriteCloser)(nil)).Elem(),
+		"io.ReadWriteCloser": reflect.TypeOf((*io.ReadWriteCloser)(nil)).Elem(),
+		"http.Handler":       reflect.TypeOf((*http.Handler)(nil)).Elem(),
+	}
+}
+
+// RegisterInterface makes the interface ifacePtr points to available under the given name so it can be used
+// to assert that a referenced type implements it (e.g. "@my_type:mypkg.Logger"):
+//
+//	container.RegisterInterface("mypkg.Logger", (*mypkg.Logger)(nil))
+//
+// The interfaces error, fmt.Stringer, http.Handler and all reader, writer and closer interfaces of the io package
+// are registered by default. RegisterInterface returns an error if ifacePtr is no pointer to an interface or if the
+// name is not package qualified since it could not be distinguished from a type ID that contains a colon otherwise.
+func (c *Container) RegisterInterface(name string, ifacePtr interface{}) error {
+	if isInterfaceName(name) == false {
+		return fmt.Errorf("goldi: can not register interface %q: the name must be package qualified (e.g. \"mypkg.Logger\")", name)
+	}
+
+	ifaceType, err := boundInterfaceType(ifacePtr)
+	if err != nil {
+		return fmt.Errorf("goldi: can not register interface %q: %s", name, err)
+	}
+
+	c.interfaces[name] = ifaceType
+	return nil
+}
+
+// checkRequiredInterface returns an error if the given instance does not implement the interface
+// that is required by the given type ID.
+func (c *Container) checkRequiredInterface(t *TypeID, instance interface{}) error {
+	if t.RequiredInterface == "" {
+		return nil
+	}
+
+	ifaceType, isRegistered := c.interfaces[t.RequiredInterface]
+	if isRegistered == false {
+		return fmt.Errorf("the interface %q required by %q has not been registered", t.RequiredInterface, t.Raw)
+	}
+
+	if instance == nil || reflect.TypeOf(instance).Implements(ifaceType) == false {
+		return fmt.Errorf("the referenced type %q (type %T) does not implement the required interface %v", t.Raw, instance, ifaceType)
+	}
+
+	return nil
+}
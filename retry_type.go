@@ -0,0 +1,59 @@
+package goldi
+
+import (
+	"fmt"
+	"time"
+)
+
+type retryType struct {
+	embeddedType TypeFactory
+	attempts     int
+	backoff      time.Duration
+}
+
+// NewRetryType creates a new TypeFactory that decorates a given TypeFactory and retries its generation if it fails.
+// The embedded type is generated up to `attempts` times and the retry type waits for the given backoff duration between
+// two attempts. If all attempts fail the error of the last attempt is returned.
+//
+// Only errors that are returned by the Generate function of the embedded type are retried. This is mainly useful for
+// type factories that contact external systems while they are generated.
+//
+// NewRetryType will return an invalid type when embeddedType is nil, attempts is smaller than one or backoff is negative.
+//
+// You can not generate this type using goldigen
+func NewRetryType(embeddedType TypeFactory, attempts int, backoff time.Duration) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new RetryType with nil as embedded type"))
+	}
+
+	if attempts < 1 {
+		return newInvalidType(fmt.Errorf("can not create a new RetryType with %d attempts", attempts))
+	}
+
+	if backoff < 0 {
+		return newInvalidType(fmt.Errorf("can not create a new RetryType with negative backoff %s", backoff))
+	}
+
+	return &retryType{embeddedType, attempts, backoff}
+}
+
+func (t *retryType) Arguments() []interface{} {
+	return t.embeddedType.Arguments()
+}
+
+func (t *retryType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
+	var err error
+	for i := 0; i < t.attempts; i++ {
+		if i > 0 {
+			time.Sleep(t.backoff)
+		}
+
+		var instance interface{}
+		instance, err = t.embeddedType.Generate(parameterResolver)
+		if err == nil {
+			return instance, nil
+		}
+	}
+
+	return nil, fmt.Errorf("giving up after %d attempts: %s", t.attempts, err)
+}
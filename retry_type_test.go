@@ -0,0 +1,104 @@
+package goldi_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// flakyTypeFactory fails a configured number of times before it generates a MockType.
+type flakyTypeFactory struct {
+	Failures int
+	Calls    int
+}
+
+func (f *flakyTypeFactory) Arguments() []interface{} {
+	return []interface{}{"@some_type"}
+}
+
+func (f *flakyTypeFactory) Generate(_ *goldi.ParameterResolver) (interface{}, error) {
+	f.Calls++
+	if f.Calls <= f.Failures {
+		return nil, fmt.Errorf("attempt %d failed", f.Calls)
+	}
+
+	return &MockType{}, nil
+}
+
+func ExampleNewRetryType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+
+	// this type factory fails twice before it succeeds
+	flaky := &flakyTypeFactory{Failures: 2}
+	container.Register("client", goldi.NewRetryType(flaky, 3, 10*time.Millisecond))
+
+	fmt.Printf("%T", container.MustGet("client"))
+	// Output:
+	// *goldi_test.MockType
+}
+
+var _ = Describe("retryType", func() {
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+	})
+
+	Describe("NewRetryType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewRetryType(nil, 3, 0))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the number of attempts is smaller than one", func() {
+			Expect(goldi.IsValid(goldi.NewRetryType(goldi.NewType(NewMockType), 0, 0))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the backoff is negative", func() {
+			Expect(goldi.IsValid(goldi.NewRetryType(goldi.NewType(NewMockType), 3, -time.Second))).To(BeFalse())
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return the arguments of the embedded type", func() {
+			typeDef := goldi.NewRetryType(&flakyTypeFactory{}, 3, 0)
+			Expect(typeDef.Arguments()).To(Equal([]interface{}{"@some_type"}))
+		})
+	})
+
+	Describe("Generate()", func() {
+		It("should retry the generation until it succeeds", func() {
+			flaky := &flakyTypeFactory{Failures: 2}
+			typeDef := goldi.NewRetryType(flaky, 3, time.Millisecond)
+
+			generatedType, err := typeDef.Generate(resolver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generatedType).To(BeAssignableToTypeOf(&MockType{}))
+			Expect(flaky.Calls).To(Equal(3))
+		})
+
+		It("should return the last error if all attempts failed", func() {
+			flaky := &flakyTypeFactory{Failures: 5}
+			typeDef := goldi.NewRetryType(flaky, 3, time.Millisecond)
+
+			_, err := typeDef.Generate(resolver)
+			Expect(err).To(MatchError("giving up after 3 attempts: attempt 3 failed"))
+			Expect(flaky.Calls).To(Equal(3))
+		})
+
+		It("should wait between the attempts", func() {
+			typeDef := goldi.NewRetryType(&flakyTypeFactory{Failures: 2}, 3, 5*time.Millisecond)
+
+			start := time.Now()
+			_, err := typeDef.Generate(resolver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(time.Since(start)).To(BeNumerically(">=", 10*time.Millisecond))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package goldi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// A scope caches the instances of the scoped types of a single context.
+type scope struct {
+	mutex     sync.Mutex
+	instances map[string]interface{}
+	order     []string // the type IDs in the order in which they have been generated
+	closed    bool
+}
+
+// ScopedGet retrieves a type whose instance lives for the duration of the given context (e.g. one instance per HTTP request).
+// The first call for a context generates a new instance of the type which is reused by all further calls with the same
+// context. Distinct contexts get distinct instances. The types the scoped type depends on are generated and cached
+// like any other type. The context is passed to the factory of the type (see ContextArgument).
+//
+// Once the context is canceled all of its instances are dropped and those that implement io.Closer are closed
+// in the reverse order of their creation. Errors returned by Close are ignored.
+//
+// ScopedGet returns an error if the context can never be canceled (e.g. context.Background())
+// because its instances could never be released.
+func (c *Container) ScopedGet(ctx context.Context, typeID string) (interface{}, error) {
+	if ctx.Done() == nil {
+		return nil, fmt.Errorf("goldi: can not get scoped type %q: the context can never be canceled", typeID)
+	}
+
+	s, err := c.scope(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("goldi: can not get scoped type %q: %w", typeID, err)
+	}
+
+	s.mutex.Lock()
+	instance, isCached := s.instances[typeID]
+	s.mutex.Unlock()
+
+	c.mutex.Lock()
+	generator, isDefined := c.TypeRegistry[typeID]
+	if isCached || isDefined {
+		c.recordStats(typeID, isCached == false)
+	}
+	c.mutex.Unlock()
+	if isCached {
+		return instance, nil
+	}
+
+	if isDefined == false {
+		return nil, c.handleError(typeID, newUnknownTypeReferenceError(typeID, "no such type has been defined"))
+	}
+
+	instance, err = c.generateInstance(typeID, generator, c.Resolver.withContext(ctx))
+	if err != nil {
+		return nil, c.handleError(typeID, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		// the context has been canceled while the type was generated
+		if closer, isCloser := instance.(io.Closer); isCloser {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("goldi: can not get scoped type %q: %w", typeID, ctx.Err())
+	}
+
+	if cached, isCached := s.instances[typeID]; isCached {
+		// another goroutine generated the type concurrently
+		return cached, nil
+	}
+
+	s.instances[typeID] = instance
+	s.order = append(s.order, typeID)
+	return instance, nil
+}
+
+// scope returns the scope of the given context and creates it if necessary.
+func (c *Container) scope(ctx context.Context) (*scope, error) {
+	c.scopeMutex.Lock()
+	defer c.scopeMutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if s, exists := c.scopes[ctx]; exists {
+		return s, nil
+	}
+
+	if c.scopes == nil {
+		c.scopes = map[context.Context]*scope{}
+	}
+
+	s := &scope{instances: map[string]interface{}{}}
+	c.scopes[ctx] = s
+
+	go func() {
+		<-ctx.Done()
+		c.scopeMutex.Lock()
+		delete(c.scopes, ctx)
+		c.scopeMutex.Unlock()
+		s.close()
+	}()
+
+	return s, nil
+}
+
+// close closes all instances of this scope that implement io.Closer in the reverse order of their creation.
+func (s *scope) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	for i := len(s.order) - 1; i >= 0; i-- {
+		if closer, isCloser := s.instances[s.order[i]].(io.Closer); isCloser {
+			closer.Close()
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package goldi_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.ScopedGet", func() {
+	var (
+		container *goldi.Container
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("mock", goldi.NewType(NewMockType))
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should reuse the instance within the same context", func() {
+		first, err := container.ScopedGet(ctx, "mock")
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := container.ScopedGet(ctx, "mock")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+	})
+
+	It("should return distinct instances for distinct contexts", func() {
+		otherCtx, cancelOther := context.WithCancel(context.Background())
+		defer cancelOther()
+
+		first, err := container.ScopedGet(ctx, "mock")
+		Expect(err).NotTo(HaveOccurred())
+
+		second, err := container.ScopedGet(otherCtx, "mock")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(container.MustGet("mock")).NotTo(BeIdenticalTo(first))
+	})
+
+	It("should generate the dependencies of scoped types only once", func() {
+		container.Register("dependent", goldi.NewType(NewTypeForServiceInjection, "@mock"))
+		otherCtx, cancelOther := context.WithCancel(context.Background())
+		defer cancelOther()
+
+		first, err := container.ScopedGet(ctx, "dependent")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := container.ScopedGet(otherCtx, "dependent")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(second.(*TypeForServiceInjection).InjectedType).To(BeIdenticalTo(first.(*TypeForServiceInjection).InjectedType))
+	})
+
+	It("should pass the context to the factory", func() {
+		container.Register("with_context", goldi.NewType(NewTypeWithContext, goldi.ContextArgument))
+		t, err := container.ScopedGet(ctx, "with_context")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.(*TypeWithContext).Ctx).To(Equal(ctx))
+	})
+
+	It("should close the instances in reverse order when the context is canceled", func() {
+		var mutex sync.Mutex
+		var closed []string
+		newRecorder := func(name string) func() *closeRecorder {
+			return func() *closeRecorder {
+				return &closeRecorder{mutex: &mutex, closed: &closed, name: name}
+			}
+		}
+		container.Register("first", goldi.NewType(newRecorder("first")))
+		container.Register("second", goldi.NewType(newRecorder("second")))
+
+		first, err := container.ScopedGet(ctx, "first")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = container.ScopedGet(ctx, "second")
+		Expect(err).NotTo(HaveOccurred())
+
+		cancel()
+		Eventually(func() []string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([]string{}, closed...)
+		}).Should(Equal([]string{"second", "first"}))
+
+		_, err = container.ScopedGet(ctx, "first")
+		Expect(err).To(MatchError(`goldi: can not get scoped type "first": context canceled`))
+		Expect(first).NotTo(BeNil())
+	})
+
+	It("should return an error if the context can never be canceled", func() {
+		_, err := container.ScopedGet(context.Background(), "mock")
+		Expect(err).To(MatchError(`goldi: can not get scoped type "mock": the context can never be canceled`))
+	})
+
+	It("should return an error if the type has not been defined", func() {
+		_, err := container.ScopedGet(ctx, "unknown")
+		Expect(err).To(BeAssignableToTypeOf(goldi.UnknownTypeReferenceError{}))
+	})
+})
@@ -0,0 +1,93 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type singletonFuncType struct {
+	function *typeFactory
+
+	mutex       sync.Mutex
+	isGenerated bool
+	result      interface{}
+}
+
+// NewSingletonFuncType creates a new TypeFactory that calls the given function with the resolved arguments
+// and returns its result. The function is called only once and its result is reused for every
+// subsequent call to Generate, even if the factory is registered in multiple containers.
+//
+// In contrast to NewFuncType which returns the function itself, this type returns the result of the function.
+// Unlike NewType the function may return any type and it may additionally return an error as second result.
+// If the function returns an error, Generate returns it and the function will be called again next time.
+//
+// This function will return an invalid type if:
+//   - the function is nil or no function,
+//   - the function does not return exactly one result or a result and an error,
+//   - the number of given arguments does not match the number of arguments of the function
+//
+// You can not generate this type using goldigen
+func NewSingletonFuncType(function interface{}, arguments ...interface{}) TypeFactory {
+	if function == nil {
+		return newInvalidType(fmt.Errorf("the given function is nil"))
+	}
+
+	functionType := reflect.TypeOf(function)
+	if functionType.Kind() != reflect.Func {
+		return newInvalidType(fmt.Errorf("the given type must be a function (given %T)", function))
+	}
+
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	switch {
+	case functionType.NumOut() == 1:
+	case functionType.NumOut() == 2 && functionType.Out(1) == errorType:
+	default:
+		return newInvalidType(fmt.Errorf("the given function must return a single result or a result and an error (given %T)", function))
+	}
+
+	if err := checkNumberOfArguments(functionType, arguments); err != nil {
+		return newInvalidType(err)
+	}
+
+	args, err := buildFactoryCallArguments(functionType, arguments)
+	if err != nil {
+		return newInvalidType(err)
+	}
+
+	return &singletonFuncType{
+		function: &typeFactory{
+			factory:          reflect.ValueOf(function),
+			factoryType:      functionType,
+			factoryArguments: args,
+		},
+	}
+}
+
+// Arguments returns all function arguments from NewSingletonFuncType
+func (t *singletonFuncType) Arguments() []interface{} {
+	return t.function.Arguments()
+}
+
+// Generate calls the function once and returns its memoized result.
+func (t *singletonFuncType) Generate(resolver *ParameterResolver) (interface{}, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.isGenerated {
+		return t.result, nil
+	}
+
+	result, err := t.function.call(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 2 && result[1].IsNil() == false {
+		return nil, result[1].Interface().(error)
+	}
+
+	t.result = result[0].Interface()
+	t.isGenerated = true
+	return t.result, nil
+}
@@ -0,0 +1,98 @@
+package goldi_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func ExampleNewSingletonFuncType() {
+	container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{
+		"host": "example.com",
+	})
+
+	// the registered type is the result of the function and not the function itself
+	container.Register("base_url", goldi.NewSingletonFuncType(func(host string) string {
+		return "https://" + host
+	}, "%host%"))
+
+	fmt.Println(container.MustGet("base_url"))
+	// Output:
+	// https://example.com
+}
+
+var _ = Describe("singletonFuncType", func() {
+	var (
+		container *goldi.Container
+		resolver  *goldi.ParameterResolver
+		calls     int
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		resolver = goldi.NewParameterResolver(container)
+		calls = 0
+	})
+
+	Describe("NewSingletonFuncType()", func() {
+		It("should return an invalid type if the argument is no function", func() {
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(nil))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(42))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the function has an invalid number of results", func() {
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(func() {}))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(func() (int, int) { return 1, 2 }))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(func() (int, error) { return 1, nil }))).To(BeTrue())
+		})
+
+		It("should return an invalid type if the number of arguments does not match", func() {
+			Expect(goldi.IsValid(goldi.NewSingletonFuncType(func(a, b int) int { return a + b }, 1))).To(BeFalse())
+		})
+	})
+
+	Describe("Arguments()", func() {
+		It("should return all function arguments", func() {
+			typeDef := goldi.NewSingletonFuncType(func(a string, b int) int { return b }, "%foo%", 42)
+			Expect(typeDef.Arguments()).To(Equal([]interface{}{"%foo%", 42}))
+		})
+	})
+
+	Describe("Generate()", func() {
+		It("should call the function only once", func() {
+			typeDef := goldi.NewSingletonFuncType(func(a, b int) int {
+				calls++
+				return a + b
+			}, 40, 2)
+
+			Expect(typeDef.Generate(resolver)).To(Equal(42))
+			Expect(typeDef.Generate(resolver)).To(Equal(42))
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should resolve type references", func() {
+			container.RegisterType("mock", NewMockTypeWithArgs, "foo", true)
+			typeDef := goldi.NewSingletonFuncType(func(m *MockType) string { return m.StringParameter }, "@mock")
+			Expect(typeDef.Generate(resolver)).To(Equal("foo"))
+		})
+
+		It("should return the error of the function and call it again on the next attempt", func() {
+			typeDef := goldi.NewSingletonFuncType(func() (string, error) {
+				calls++
+				if calls == 1 {
+					return "", errors.New("first call failed")
+				}
+				return "success", nil
+			})
+
+			_, err := typeDef.Generate(resolver)
+			Expect(err).To(MatchError("first call failed"))
+			Expect(typeDef.Generate(resolver)).To(Equal("success"))
+			Expect(typeDef.Generate(resolver)).To(Equal("success"))
+			Expect(calls).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package goldi
+
+import "reflect"
+
+// StaticType returns the type of the instance the type with the given ID generates without actually generating it.
+// The second return value is false if the type is not defined or if its concrete type can not be determined
+// without generating it (e.g. if the factory function returns an interface).
+func (c *Container) StaticType(typeID string) (reflect.Type, bool) {
+	return c.staticType(typeID, StringSet{})
+}
+
+func (c *Container) staticType(typeID string, visited StringSet) (reflect.Type, bool) {
+	if visited.Contains(typeID) {
+		return nil, false
+	}
+	visited.Set(typeID)
+
+	factory, isDefined := c.TypeRegistry[typeID]
+	if isDefined == false {
+		return nil, false
+	}
+
+	return c.staticTypeOfFactory(factory, visited)
+}
+
+func (c *Container) staticTypeOfFactory(factory TypeFactory, visited StringSet) (reflect.Type, bool) {
+	var t reflect.Type
+	switch f := undecorated(factory).(type) {
+	case *typeFactory:
+		t = f.factoryType.Out(0)
+	case *singletonFuncType:
+		t = f.function.factoryType.Out(0)
+	case *structType:
+		t = reflect.PtrTo(f.structType)
+	case *instanceType:
+		t = reflect.TypeOf(f.Instance)
+	case *funcType:
+		t = reflect.TypeOf(f.function)
+	case *aliasType:
+		return c.staticTypeOfReference(NewTypeID(f.typeID), visited)
+	case *platformSwitchType:
+		if f.isSelected == false {
+			return nil, false
+		}
+		return c.staticType(f.typeID, visited)
+	case *envSwitchType:
+		if f.isSelected == false {
+			return nil, false
+		}
+		return c.staticType(f.typeID, visited)
+	case *funcReferenceType:
+		return c.staticTypeOfReference(f.typeID, visited)
+	case *proxyType:
+		methodType, isKnown := c.staticTypeOfReference(f.typeID, visited)
+		if isKnown == false || methodType.NumOut() == 0 {
+			return nil, false
+		}
+		t = methodType.Out(0)
+	default:
+		return nil, false
+	}
+
+	if t.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	return t, true
+}
+
+// staticTypeOfReference returns the static type of the referenced type or the type of its method value if the
+// type ID is a func reference.
+func (c *Container) staticTypeOfReference(typeID *TypeID, visited StringSet) (reflect.Type, bool) {
+	t, isKnown := c.staticType(typeID.ID, visited)
+	if isKnown == false || typeID.IsFuncReference == false {
+		return t, isKnown
+	}
+
+	method, exists := t.MethodByName(typeID.FuncReferenceMethod)
+	if exists == false {
+		return nil, false
+	}
+
+	// the method type of a concrete type contains the receiver as first argument
+	in := make([]reflect.Type, method.Type.NumIn()-1)
+	for i := range in {
+		in[i] = method.Type.In(i + 1)
+	}
+
+	out := make([]reflect.Type, method.Type.NumOut())
+	for i := range out {
+		out[i] = method.Type.Out(i)
+	}
+
+	return reflect.FuncOf(in, out, method.Type.IsVariadic()), true
+}
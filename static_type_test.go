@@ -0,0 +1,91 @@
+package goldi_test
+
+import (
+	"reflect"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.StaticType", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should return the types without generating them", func() {
+		factory := &MockTypeFactory{}
+		container.RegisterType("type", factory.NewMockType)
+		container.Register("struct", goldi.NewStructType(Foo{}))
+		container.InjectInstance("instance", &Bar{})
+		container.Register("configured", goldi.NewConfiguredType(goldi.NewStructType(Foo{}), "instance", "Configure"))
+		container.Register("alias", goldi.NewAliasType("struct"))
+
+		expected := map[string]reflect.Type{
+			"type":       reflect.TypeOf(&MockType{}),
+			"struct":     reflect.TypeOf(&Foo{}),
+			"instance":   reflect.TypeOf(&Bar{}),
+			"configured": reflect.TypeOf(&Foo{}),
+			"alias":      reflect.TypeOf(&Foo{}),
+		}
+
+		for typeID, expectedType := range expected {
+			t, isKnown := container.StaticType(typeID)
+			Expect(isKnown).To(BeTrue(), typeID)
+			Expect(t).To(Equal(expectedType), typeID)
+		}
+
+		Expect(factory.HasBeenUsed).To(BeFalse())
+	})
+
+	It("should return the types of func references and proxy types", func() {
+		container.Register("foo", goldi.NewStructType(Foo{}))
+		container.Register("func", goldi.NewFuncReferenceType("foo", "ReturnString"))
+		container.Register("alias", goldi.NewAliasType("foo::ReturnString"))
+		container.Register("proxy", goldi.NewProxyType("foo", "ReturnString", "bar"))
+
+		for _, typeID := range []string{"func", "alias"} {
+			t, isKnown := container.StaticType(typeID)
+			Expect(isKnown).To(BeTrue(), typeID)
+			Expect(t).To(Equal(reflect.TypeOf(func(string) string { return "" })), typeID)
+		}
+
+		t, isKnown := container.StaticType("proxy")
+		Expect(isKnown).To(BeTrue())
+		Expect(t).To(Equal(reflect.TypeOf("")))
+	})
+
+	It("should return false if the type can not be determined", func() {
+		container.RegisterType("interface", func() interface{} { return &MockType{} })
+		container.Register("alias_1", goldi.NewAliasType("alias_2"))
+		container.Register("alias_2", goldi.NewAliasType("alias_1"))
+
+		for _, typeID := range []string{"interface", "alias_1", "undefined"} {
+			_, isKnown := container.StaticType(typeID)
+			Expect(isKnown).To(BeFalse(), typeID)
+		}
+	})
+})
+
+var _ = Describe("MethodReferences", func() {
+	It("should return all method references of a type factory", func() {
+		typeFactory := goldi.NewConfiguredType(
+			goldi.NewType(NewMockTypeFromStringFunc, "foo", "@foo::ReturnString"),
+			"configurator", "Configure",
+		)
+
+		references := goldi.MethodReferences(typeFactory)
+		Expect(references).To(HaveLen(2))
+		Expect(references[0].String()).To(Equal("@configurator::Configure"))
+		Expect(references[1].String()).To(Equal("@foo::ReturnString"))
+	})
+
+	It("should return the references of func reference, proxy and alias types", func() {
+		Expect(goldi.MethodReferences(goldi.NewFuncReferenceType("foo", "DoStuff"))).To(HaveLen(1))
+		Expect(goldi.MethodReferences(goldi.NewProxyType("foo", "DoStuff"))).To(HaveLen(1))
+		Expect(goldi.MethodReferences(goldi.NewAliasType("foo::DoStuff"))).To(HaveLen(1))
+		Expect(goldi.MethodReferences(goldi.NewAliasType("foo"))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,49 @@
+package goldi
+
+// TypeStats contains the counters of a single type if Container.CollectStats is enabled.
+type TypeStats struct {
+	// Generated is the number of times the type factory has been called.
+	Generated int
+
+	// CacheHits is the number of times the type has been returned from the type cache (or the cache of a scope)
+	// instead of being generated.
+	CacheHits int
+}
+
+// Stats returns the counters of all types that have been requested since Container.CollectStats has been enabled.
+// Types that have never been requested are not contained in the result.
+// Stats can be used to verify that singletons are only generated once or to identify frequently generated types.
+func (c *Container) Stats() map[string]TypeStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := make(map[string]TypeStats, len(c.stats))
+	for typeID, s := range c.stats {
+		stats[typeID] = *s
+	}
+
+	return stats
+}
+
+// recordStats increments the counters of the given type. The caller must hold the mutex of the container.
+func (c *Container) recordStats(typeID string, generated bool) {
+	if c.CollectStats == false {
+		return
+	}
+
+	if c.stats == nil {
+		c.stats = map[string]*TypeStats{}
+	}
+
+	s, exists := c.stats[typeID]
+	if exists == false {
+		s = &TypeStats{}
+		c.stats[typeID] = s
+	}
+
+	if generated {
+		s.Generated++
+	} else {
+		s.CacheHits++
+	}
+}
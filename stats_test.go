@@ -0,0 +1,59 @@
+package goldi_test
+
+import (
+	"context"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Container.Stats", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		container.Register("mock", goldi.NewType(NewMockType))
+		container.Register("dependent", goldi.NewType(NewTypeForServiceInjection, "@mock"))
+		container.CollectStats = true
+	})
+
+	It("should count how often each type has been generated and retrieved from the cache", func() {
+		container.MustGet("mock")
+		container.MustGet("mock")
+		container.MustGet("dependent")
+		container.MustGet("dependent")
+		container.MustGet("dependent")
+
+		Expect(container.Stats()).To(Equal(map[string]goldi.TypeStats{
+			"mock":      {Generated: 1, CacheHits: 2}, // the dependent type also retrieves "mock" from the cache
+			"dependent": {Generated: 1, CacheHits: 2},
+		}))
+	})
+
+	It("should count the types that are generated per scope", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		otherCtx, cancelOther := context.WithCancel(context.Background())
+		defer cancelOther()
+
+		container.ScopedGet(ctx, "mock")
+		container.ScopedGet(ctx, "mock")
+		container.ScopedGet(otherCtx, "mock")
+
+		Expect(container.Stats()).To(Equal(map[string]goldi.TypeStats{
+			"mock": {Generated: 2, CacheHits: 1},
+		}))
+	})
+
+	It("should not count unknown types", func() {
+		container.Get("unknown")
+		Expect(container.Stats()).To(BeEmpty())
+	})
+
+	It("should not collect any stats if it is disabled", func() {
+		container.CollectStats = false
+		container.MustGet("dependent")
+		Expect(container.Stats()).To(BeEmpty())
+	})
+})
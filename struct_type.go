@@ -18,6 +18,7 @@ type structType struct {
 //   - structT is no struct or pointer to a struct,
 //   - the number of given structParameters exceed the number of field of structT
 //   - the structParameters types do not match the fields of structT
+//   - a field of structT that is tagged with `required:"true"` is not provided by the structParameters
 //
 // Goldigen yaml syntax example:
 //     logger:
@@ -48,6 +49,12 @@ func newTypeFromStruct(generatedType reflect.Type, parameters []interface{}) Typ
 		))
 	}
 
+	if missing := missingRequiredFields(generatedType, len(parameters)); len(missing) > 0 {
+		return newInvalidType(fmt.Errorf("the struct %s requires the fields %v but only %d arguments where provided",
+			generatedType.Name(), missing, len(parameters),
+		))
+	}
+
 	args := make([]reflect.Value, len(parameters))
 	for i, argument := range parameters {
 		// TODO: check argument types
@@ -60,11 +67,25 @@ func newTypeFromStruct(generatedType reflect.Type, parameters []interface{}) Typ
 	}
 }
 
+// missingRequiredFields returns the names of all fields of the given struct type that are tagged with
+// `required:"true"` but are not set by the given number of struct parameters.
+func missingRequiredFields(generatedType reflect.Type, numParameters int) []string {
+	var missing []string
+	for i := numParameters; i < generatedType.NumField(); i++ {
+		field := generatedType.Field(i)
+		if field.Tag.Get("required") == "true" {
+			missing = append(missing, field.Name)
+		}
+	}
+
+	return missing
+}
+
 // Arguments returns all struct parameters from NewStructType
 func (t *structType) Arguments() []interface{} {
 	args := make([]interface{}, len(t.structFields))
 	for i, argument := range t.structFields {
-		args[i] = argument.Interface()
+		args[i] = argumentInterface(argument)
 	}
 	return args
 }
@@ -86,29 +107,41 @@ func (t *structType) Generate(parameterResolver *ParameterResolver) (interface{}
 
 func (t *structType) generateTypeFields(parameterResolver *ParameterResolver) ([]reflect.Value, error) {
 	args := make([]reflect.Value, len(t.structFields))
-	var err error
+	errs := NewMultiError(fmt.Sprintf("could not resolve all fields of struct type %v", t.structType))
 
 	for i, argument := range t.structFields {
+		var err error
 		expectedArgument := t.structType.Field(i).Type
 		args[i], err = parameterResolver.Resolve(argument, expectedArgument)
 
 		switch errorType := err.(type) {
 		case nil:
-			continue
+			if args[i].IsValid() {
+				continue
+			}
+			err = newInvalidArgumentError(i, "field %d of struct type %v resolved to no value that can be assigned to %v", i+1, t.structType, expectedArgument)
 		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
-		default:
+			err = t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
+		}
+
+		if parameterResolver.CollectErrors == false {
 			return nil, err
 		}
+		errs.Add(err)
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
 	}
 
 	return args, nil
 }
 
 func (t *structType) invalidReferencedTypeErr(typeID string, typeInstance interface{}, i int) error {
-	err := fmt.Errorf("the referenced type \"@%s\" (type %T) can not be used as field %d for struct type %v",
+	err := newTypeReferenceError(typeID, typeInstance, "the referenced type \"@%s\" (type %T) can not be used as field %d for struct type %v",
 		typeID, typeInstance, i+1, t.structType,
 	)
 
+	err.ArgumentIndex = i
 	return err
 }
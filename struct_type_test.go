@@ -68,6 +68,31 @@ var _ = Describe("structType", func() {
 			Expect(goldi.IsValid(t)).To(BeFalse())
 			Expect(t).To(MatchError("the struct MockType has only 2 fields but 3 arguments where provided"))
 		})
+
+		Context("when the struct has fields that are tagged as required", func() {
+			type Config struct {
+				Name    string `required:"true"`
+				Verbose bool
+				Logger  *SimpleLogger `required:"true"`
+				Debug   bool          `required:"false"`
+			}
+
+			It("should create the type if all required fields are provided", func() {
+				t := goldi.NewStructType(Config{}, "foo", true, "@logger")
+				Expect(goldi.IsValid(t)).To(BeTrue())
+			})
+
+			It("should return an invalid type if a required field is missing", func() {
+				t := goldi.NewStructType(Config{}, "foo", true)
+				Expect(goldi.IsValid(t)).To(BeFalse())
+				Expect(t).To(MatchError("the struct Config requires the fields [Logger] but only 2 arguments where provided"))
+			})
+
+			It("should return an invalid type if no arguments are given at all", func() {
+				t := goldi.NewStructType(&Config{})
+				Expect(t).To(MatchError("the struct Config requires the fields [Name Logger] but only 0 arguments where provided"))
+			})
+		})
 	})
 
 	Describe("Arguments()", func() {
@@ -175,6 +200,31 @@ var _ = Describe("structType", func() {
 					})
 				})
 			})
+
+			Context("when a field resolves to no value", func() {
+				It("should return an InvalidArgumentError", func() {
+					typeDef := goldi.NewStructType(TypeForServiceInjection{}, nil)
+					Expect(typeDef.Arguments()).To(Equal([]interface{}{nil}))
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError("field 1 of struct type goldi_test.TypeForServiceInjection resolved to no value that can be assigned to *goldi_test.MockType"))
+					Expect(err).To(BeAssignableToTypeOf(goldi.InvalidArgumentError{}))
+				})
+			})
+
+			Context("when the resolver collects all errors", func() {
+				It("should return the errors of all fields", func() {
+					resolver.CollectErrors = true
+					container.RegisterType("foo", NewFoo)
+					typeDef := goldi.NewStructType(MockType{}, "@foo", "@undefined")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError(`could not resolve all fields of struct type goldi_test.MockType: ` +
+						`the referenced type "@foo" (type *goldi_test.Foo) can not be used as field 1 for struct type goldi_test.MockType; ` +
+						`the referenced type "@undefined" has not been defined`,
+					))
+				})
+			})
 		})
 	})
 })
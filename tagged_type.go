@@ -0,0 +1,96 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+type taggedType struct {
+	embeddedType TypeFactory
+	tags         []string
+}
+
+// NewTaggedType creates a new TypeFactory that decorates a given TypeFactory with a set of tags.
+// Tags do not change how the type is generated but they can be used to retrieve all types with
+// a certain tag via Container.GetByTag (e.g. to collect all event listeners or health checks).
+//
+// NewTaggedType will return an invalid type when embeddedType is nil and the embedded type itself if it is invalid.
+//
+// Goldigen yaml syntax example:
+//     my_listener:
+//         package: github.com/fgrosse/foobar
+//         factory: NewMyListener
+//         tags:    [ event_listener ]
+func NewTaggedType(embeddedType TypeFactory, tags ...string) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new TaggedType with nil as embedded type"))
+	}
+
+	if IsValid(embeddedType) == false {
+		return embeddedType
+	}
+
+	return &taggedType{embeddedType: embeddedType, tags: tags}
+}
+
+// Tags returns the tags of the given TypeFactory or nil if it has not been created by NewTaggedType.
+// The tags of tagged types that are decorated by other types (e.g. NewDeclaredDependenciesType) are returned as well.
+func Tags(t TypeFactory) []string {
+	var tags []string
+	for _, factory := range decorationChain(t) {
+		if tagged, isTagged := factory.(*taggedType); isTagged {
+			tags = append(tags, tagged.tags...)
+		}
+	}
+
+	return tags
+}
+
+func (t *taggedType) Arguments() []interface{} {
+	return t.embeddedType.Arguments()
+}
+
+func (t *taggedType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
+	return t.embeddedType.Generate(parameterResolver)
+}
+
+// RegisterWithTags registers the given TypeFactory decorated with the given tags.
+// See NewTaggedType and Container.GetByTag.
+func (r TypeRegistry) RegisterWithTags(typeID string, typeDef TypeFactory, tags ...string) {
+	r.Register(typeID, NewTaggedType(typeDef, tags...))
+}
+
+// TypeIDsByTag returns the alphabetically sorted IDs of all registered types that have the given tag.
+func (r TypeRegistry) TypeIDsByTag(tag string) []string {
+	var typeIDs []string
+	for typeID, factory := range r {
+		for _, t := range Tags(factory) {
+			if t == tag {
+				typeIDs = append(typeIDs, typeID)
+				break
+			}
+		}
+	}
+
+	sort.Strings(typeIDs)
+	return typeIDs
+}
+
+// GetByTag retrieves all types that have been registered with the given tag (see RegisterWithTags).
+// All types that could be generated are returned even if an error occurs.
+// The returned MultiError contains the errors of all types that could not be generated.
+func (c *Container) GetByTag(tag string) (map[string]interface{}, error) {
+	instances := map[string]interface{}{}
+	errs := NewMultiError(fmt.Sprintf("goldi: could not get all types with tag %q", tag))
+	for _, typeID := range c.TypeIDsByTag(tag) {
+		instance, err := c.Get(typeID)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+
+		instances[typeID] = instance
+	}
+
+	return instances, errs.ErrorOrNil()
+}
@@ -0,0 +1,78 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("taggedType", func() {
+	It("should implement the TypeFactory interface", func() {
+		var factory goldi.TypeFactory
+		factory = goldi.NewTaggedType(goldi.NewType(NewMockType), "foo")
+		// if this compiles the test passes (next expectation only to make compiler happy)
+		Expect(factory).NotTo(BeNil())
+	})
+
+	Describe("NewTaggedType", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewTaggedType(nil, "foo"))).To(BeFalse())
+		})
+
+		It("should return the embedded type if it is invalid", func() {
+			invalid := goldi.NewType(42)
+			Expect(goldi.NewTaggedType(invalid, "foo")).To(BeIdenticalTo(invalid))
+		})
+	})
+
+	Describe("Generate", func() {
+		It("should generate the embedded type", func() {
+			container := goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			factory := goldi.NewTaggedType(goldi.NewType(NewMockTypeWithArgs, "hello", true), "foo")
+
+			generated, err := factory.Generate(container.Resolver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(generated.(*MockType).StringParameter).To(Equal("hello"))
+			Expect(goldi.Tags(factory)).To(Equal([]string{"foo"}))
+		})
+	})
+
+	Describe("Container.GetByTag", func() {
+		var container *goldi.Container
+
+		BeforeEach(func() {
+			container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+			container.RegisterWithTags("listener_1", goldi.NewType(NewMockType), "event_listener")
+			container.RegisterWithTags("listener_2", goldi.NewType(NewMockType), "event_listener", "health_check")
+			container.RegisterWithTags("checker", goldi.NewType(NewMockType), "health_check")
+			container.Register("untagged", goldi.NewType(NewMockType))
+		})
+
+		It("should return the IDs of all types with the given tag", func() {
+			Expect(container.TypeIDsByTag("event_listener")).To(Equal([]string{"listener_1", "listener_2"}))
+			Expect(container.TypeIDsByTag("health_check")).To(Equal([]string{"checker", "listener_2"}))
+			Expect(container.TypeIDsByTag("unknown")).To(BeEmpty())
+		})
+
+		It("should find tagged types that are decorated by other types", func() {
+			container.RegisterWithDeps("declared_listener", goldi.NewTaggedType(goldi.NewType(NewMockType), "event_listener"))
+			Expect(container.TypeIDsByTag("event_listener")).To(Equal([]string{"declared_listener", "listener_1", "listener_2"}))
+		})
+
+		It("should generate all types with the given tag", func() {
+			instances, err := container.GetByTag("event_listener")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(2))
+			Expect(instances["listener_1"]).To(BeIdenticalTo(container.MustGet("listener_1")))
+			Expect(instances["listener_2"]).To(BeIdenticalTo(container.MustGet("listener_2")))
+		})
+
+		It("should return the types that could be generated together with the errors", func() {
+			container.RegisterWithTags("broken", goldi.NewProxyType("unknown", "DoStuff"), "event_listener")
+
+			instances, err := container.GetByTag("event_listener")
+			Expect(err).To(MatchError(HavePrefix(`goldi: could not get all types with tag "event_listener": goldi: error while generating type "broken": `)))
+			Expect(instances).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package goldi
+
+import (
+	"fmt"
+	"time"
+)
+
+type ttlType struct {
+	embeddedType TypeFactory
+	ttl          time.Duration
+}
+
+// NewTTLType creates a new TypeFactory that decorates a given TypeFactory and limits how long the container caches
+// its instance. Once the given time to live has passed since the instance has been generated the container generates
+// the embedded type again on the next request. This is useful for types like access tokens or credentials that must be
+// refreshed periodically. The time is taken from Container.Clock.
+//
+// NewTTLType will return an invalid type when embeddedType is nil or ttl is not positive.
+//
+// You can not generate this type using goldigen
+func NewTTLType(embeddedType TypeFactory, ttl time.Duration) TypeFactory {
+	if embeddedType == nil {
+		return newInvalidType(fmt.Errorf("refusing to create a new TTLType with nil as embedded type"))
+	}
+
+	if ttl <= 0 {
+		return newInvalidType(fmt.Errorf("can not create a new TTLType with non-positive TTL %s", ttl))
+	}
+
+	return &ttlType{embeddedType, ttl}
+}
+
+func (t *ttlType) Arguments() []interface{} {
+	return t.embeddedType.Arguments()
+}
+
+func (t *ttlType) Generate(parameterResolver *ParameterResolver) (interface{}, error) {
+	return t.embeddedType.Generate(parameterResolver)
+}
+
+// IsExpired implements the CachePolicy interface.
+func (t *ttlType) IsExpired(generatedAt, now time.Time) bool {
+	return now.Sub(generatedAt) >= t.ttl
+}
@@ -0,0 +1,104 @@
+package goldi_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ttlType", func() {
+	var (
+		container *goldi.Container
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		now = time.Date(2016, 1, 1, 12, 0, 0, 0, time.UTC)
+		container.Clock = func() time.Time { return now }
+	})
+
+	Describe("NewTTLType()", func() {
+		It("should return an invalid type if the embedded type is nil", func() {
+			Expect(goldi.IsValid(goldi.NewTTLType(nil, time.Minute))).To(BeFalse())
+		})
+
+		It("should return an invalid type if the TTL is not positive", func() {
+			Expect(goldi.IsValid(goldi.NewTTLType(goldi.NewType(NewMockType), 0))).To(BeFalse())
+			Expect(goldi.IsValid(goldi.NewTTLType(goldi.NewType(NewMockType), -time.Second))).To(BeFalse())
+		})
+
+		It("should return the arguments of the embedded type", func() {
+			typeDef := goldi.NewTTLType(goldi.NewType(NewTypeForServiceInjection, "@foo"), time.Minute)
+			Expect(typeDef.Arguments()).To(Equal([]interface{}{"@foo"}))
+		})
+	})
+
+	It("should cache the instance until the TTL has passed", func() {
+		container.Register("token", goldi.NewTTLType(goldi.NewType(NewMockType), time.Minute))
+
+		token := container.MustGet("token")
+		now = now.Add(59 * time.Second)
+		Expect(container.MustGet("token")).To(BeIdenticalTo(token))
+	})
+
+	It("should generate the type again after the TTL has passed", func() {
+		container.Register("token", goldi.NewTTLType(goldi.NewType(NewMockType), time.Minute))
+
+		token := container.MustGet("token")
+		now = now.Add(time.Minute)
+		refreshedToken := container.MustGet("token")
+		Expect(refreshedToken).NotTo(BeIdenticalTo(token))
+
+		now = now.Add(30 * time.Second)
+		Expect(container.MustGet("token")).To(BeIdenticalTo(refreshedToken))
+	})
+
+	It("should close expired instances that implement io.Closer", func() {
+		var (
+			mutex     sync.Mutex
+			closed    []string
+			generated int
+		)
+		container.Register("token", goldi.NewTTLType(goldi.NewType(func() *closeRecorder {
+			generated++
+			return &closeRecorder{mutex: &mutex, closed: &closed, name: fmt.Sprintf("token %d", generated)}
+		}), time.Minute))
+
+		container.MustGet("token")
+		now = now.Add(time.Minute)
+		container.MustGet("token")
+		Expect(closed).To(Equal([]string{"token 1"}))
+
+		Expect(container.Close()).To(Succeed())
+		Expect(closed).To(Equal([]string{"token 1", "token 2"}))
+	})
+
+	It("should respect the TTL of tagged types", func() {
+		container.RegisterWithTags("token", goldi.NewTTLType(goldi.NewType(NewMockType), time.Minute), "credentials")
+
+		token := container.MustGet("token")
+		now = now.Add(time.Hour)
+		Expect(container.MustGet("token")).NotTo(BeIdenticalTo(token))
+	})
+
+	It("should respect the TTL of types that are decorated by a retry type", func() {
+		container.Register("token", goldi.NewRetryType(goldi.NewTTLType(goldi.NewType(NewMockType), time.Minute), 3, 0))
+
+		token := container.MustGet("token")
+		now = now.Add(time.Hour)
+		Expect(container.MustGet("token")).NotTo(BeIdenticalTo(token))
+	})
+
+	It("should not expire other types", func() {
+		container.Register("mock", goldi.NewType(NewMockType))
+
+		mock := container.MustGet("mock")
+		now = now.Add(24 * time.Hour)
+		Expect(container.MustGet("mock")).To(BeIdenticalTo(mock))
+	})
+})
@@ -23,6 +23,12 @@ type typeFactory struct {
 //   - the factoryFunctions return parameter is no pointer, interface  or function type.
 //   - the number of given factoryParameters does not match the number of arguments of the factoryFunction
 //
+// The last arguments of a variadic factoryFunction may be spread references like "@listeners..." which pass each element
+// of the referenced slice as individual variadic argument.
+//
+// The factoryFunction may also be a bound method of an existing instance (e.g. myBuilder.Build).
+// This is useful to wire up types using stateful builders.
+//
 // Goldigen yaml syntax example:
 //     my_type:
 //         package: github.com/fgrosse/foobar
@@ -55,14 +61,14 @@ func newTypeFromFactoryFunction(function interface{}, factoryType reflect.Type,
 		return newInvalidType(fmt.Errorf("return parameter is no interface, pointer or function but a %v", kindOfGeneratedType))
 	}
 
-	if factoryType.IsVariadic() {
-		if factoryType.NumIn() > len(parameters) {
-			return newInvalidType(fmt.Errorf("invalid number of input parameters for variadic function: got %d but expected at least %d", len(parameters), factoryType.NumIn()))
-		}
-	} else {
-		if factoryType.NumIn() != len(parameters) {
-			return newInvalidType(fmt.Errorf("invalid number of input parameters: got %d but expected %d", len(parameters), factoryType.NumIn()))
-		}
+	if len(parameters) == 0 && isParametersFactory(factoryType) {
+		parameters = []interface{}{ParametersArgument}
+	}
+
+	parameters = withContainerArguments(factoryType, parameters)
+
+	if err := checkNumberOfArguments(factoryType, parameters); err != nil {
+		return newInvalidType(err)
 	}
 
 	t := &typeFactory{
@@ -79,6 +85,20 @@ func newTypeFromFactoryFunction(function interface{}, factoryType reflect.Type,
 	return t
 }
 
+func checkNumberOfArguments(factoryType reflect.Type, parameters []interface{}) error {
+	if factoryType.IsVariadic() {
+		if factoryType.NumIn() > len(parameters) {
+			return fmt.Errorf("invalid number of input parameters for variadic function: got %d but expected at least %d", len(parameters), factoryType.NumIn())
+		}
+	} else {
+		if factoryType.NumIn() != len(parameters) {
+			return fmt.Errorf("invalid number of input parameters: got %d but expected %d", len(parameters), factoryType.NumIn())
+		}
+	}
+
+	return nil
+}
+
 func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}) ([]reflect.Value, error) {
 	actualNumberOfArgs := t.NumIn()
 	args := make([]reflect.Value, len(allParameters))
@@ -93,6 +113,15 @@ func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}) ([]r
 		}
 
 		args[i] = reflect.ValueOf(argument)
+		if isSpreadReference(argument) && (t.IsVariadic() == false || i < actualNumberOfArgs-1) {
+			return nil, fmt.Errorf("input argument %d is the spread reference %q but only variadic arguments can be spread", i+1, argument)
+		}
+
+		if _, isFactory := argument.(TypeFactory); isFactory {
+			// inline type factories are checked when the type is generated
+			continue
+		}
+
 		if args[i].Kind() != expectedArgumentType.Kind() {
 			if stringArg, isString := argument.(string); isString && !IsParameterOrTypeReference(stringArg) {
 				return nil, fmt.Errorf("input argument %d is of type %s but needs to be a %s", i+1, args[i].Kind(), expectedArgumentType.Kind())
@@ -107,98 +136,205 @@ func buildFactoryCallArguments(t reflect.Type, allParameters []interface{}) ([]r
 func (t *typeFactory) Arguments() []interface{} {
 	args := make([]interface{}, len(t.factoryArguments))
 	for i, argument := range t.factoryArguments {
-		args[i] = argument.Interface()
+		args[i] = argumentInterface(argument)
 	}
 	return args
 }
 
 // Generate will instantiate a new instance of the according type.
 func (t *typeFactory) Generate(resolver *ParameterResolver) (interface{}, error) {
+	result, err := t.call(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	// we check the number of return arguments in NewType so there is always exactly one result
+	return result[0].Interface(), nil
+}
+
+// call resolves all factory arguments and calls the factory function with them.
+func (t *typeFactory) call(resolver *ParameterResolver) ([]reflect.Value, error) {
 	args, err := t.generateFactoryArguments(resolver)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []reflect.Value
 	if t.factoryType.IsVariadic() {
-		result = t.factory.CallSlice(args)
-	} else {
-		result = t.factory.Call(args)
+		return t.factory.CallSlice(args), nil
 	}
 
-	// we check the number of return arguments in NewType so there is always exactly one result
-	return result[0].Interface(), nil
+	return t.factory.Call(args), nil
 }
 
+// generateFactoryArguments resolves all factory arguments or returns the cached arguments if the resolver caches
+// parameters and the arguments only depend on the parameters of the container (see ParameterResolver.CacheParameters).
 func (t *typeFactory) generateFactoryArguments(resolver *ParameterResolver) ([]reflect.Value, error) {
+	version := resolver.Container.parametersVersion.Load()
+	isCacheable := resolver.CacheParameters && resolver.dependsOnParametersOnly(t.factoryArguments)
+	if isCacheable {
+		if args, isCached := resolver.cachedArguments(t); isCached {
+			return args, nil
+		}
+	}
+
+	args, err := t.resolveFactoryArguments(resolver)
+	if err == nil && isCacheable {
+		resolver.cacheArguments(t, args, version)
+	}
+
+	return args, err
+}
+
+func (t *typeFactory) resolveFactoryArguments(resolver *ParameterResolver) ([]reflect.Value, error) {
 	if t.factoryType.IsVariadic() {
 		return t.generateVariadicFactoryArguments(resolver)
 	}
 
 	args := make([]reflect.Value, len(t.factoryArguments))
-	var err error
+	errs := t.newArgumentsError()
 
 	for i, argument := range t.factoryArguments {
+		var err error
 		args[i], err = resolver.Resolve(argument, t.factoryType.In(i))
 
 		switch errorType := err.(type) {
 		case nil:
-			continue
+			if args[i].IsValid() {
+				continue
+			}
+			err = newInvalidArgumentError(i, "argument %d of %s resolved to no value that can be passed as %v", i+1, t.factoryName(), t.factoryType.In(i))
 		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
-		default:
+			err = t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
+		}
+
+		if resolver.CollectErrors == false {
 			return nil, err
 		}
+		errs.Add(err)
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
 	}
 
 	return args, nil
 }
 
+// newArgumentsError creates the MultiError that collects all argument errors if ParameterResolver.CollectErrors is set.
+func (t *typeFactory) newArgumentsError() *MultiError {
+	return NewMultiError(fmt.Sprintf("could not resolve all arguments of %s", t.factoryName()))
+}
+
 func (t *typeFactory) generateVariadicFactoryArguments(resolver *ParameterResolver) ([]reflect.Value, error) {
 	args := make([]reflect.Value, t.factoryType.NumIn())
-	var err error
+	errs := t.newArgumentsError()
 
 	actualNumberOfArgs := t.factoryType.NumIn()
 	for i, argument := range t.factoryArguments[:actualNumberOfArgs-1] {
+		var err error
 		args[i], err = resolver.Resolve(argument, t.factoryType.In(i))
 
 		switch errorType := err.(type) {
 		case nil:
-			continue
+			if args[i].IsValid() {
+				continue
+			}
+			err = newInvalidArgumentError(i, "argument %d of %s resolved to no value that can be passed as %v", i+1, t.factoryName(), t.factoryType.In(i))
 		case TypeReferenceError:
-			return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
-		default:
+			err = t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
+		}
+
+		if resolver.CollectErrors == false {
 			return nil, err
 		}
+		errs.Add(err)
 	}
 
 	n := len(t.factoryArguments) - actualNumberOfArgs + 1
 	variadicType := t.factoryType.In(actualNumberOfArgs - 1)
-	variadicSlice := reflect.MakeSlice(variadicType, n, n)
+	variadicSlice := reflect.MakeSlice(variadicType, 0, n)
 	expectedType := variadicType.Elem()
 	for i, argument := range t.factoryArguments[actualNumberOfArgs-1:] {
-		resolvedArgument, err := resolver.Resolve(argument, expectedType)
-		if err != nil {
+		var err error
+		if isSpreadReference(argumentInterface(argument)) {
+			var elements reflect.Value
+			if elements, err = resolver.Resolve(argument, variadicType); err == nil {
+				variadicSlice = reflect.AppendSlice(variadicSlice, elements)
+				continue
+			}
+
+			err = fmt.Errorf("could not spread variadic argument %d: %s", i+1, err)
+		} else {
+			var resolvedArgument reflect.Value
+			resolvedArgument, err = resolver.Resolve(argument, expectedType)
 			switch errorType := err.(type) {
+			case nil:
+				if resolvedArgument.IsValid() == false {
+					err = newInvalidArgumentError(actualNumberOfArgs-1+i, "variadic argument %d of %s resolved to no value that can be passed as %v", i+1, t.factoryName(), expectedType)
+					break
+				}
+
+				if resolvedArgument.Type().AssignableTo(expectedType) {
+					variadicSlice = reflect.Append(variadicSlice, resolvedArgument)
+					continue
+				}
+
+				err = fmt.Errorf("variadic argument %d (type %v) is not assignable to the expected type %v",
+					i+1, resolvedArgument.Type(), expectedType,
+				)
 			case TypeReferenceError:
-				return nil, t.invalidReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i)
-			default:
-				return nil, err
+				err = t.invalidVariadicReferencedTypeErr(errorType.TypeID, errorType.TypeInstance, i, expectedType)
 			}
 		}
 
-		variadicSlice.Index(i).Set(resolvedArgument)
+		if resolver.CollectErrors == false {
+			return nil, err
+		}
+		errs.Add(err)
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
 	}
 
 	args[actualNumberOfArgs-1] = variadicSlice
 	return args, nil
 }
 
-func (t *typeFactory) invalidReferencedTypeErr(typeID string, typeInstance interface{}, i int) error {
-	factoryName := runtime.FuncForPC(t.factory.Pointer()).Name()
-	factoryNameParts := strings.Split(factoryName, "/")
-	factoryName = factoryNameParts[len(factoryNameParts)-1]
+// argumentInterface returns the value of the given factory argument or nil if the argument is an untyped nil
+// which is stored as invalid reflect.Value.
+func argumentInterface(argument reflect.Value) interface{} {
+	if argument.IsValid() == false {
+		return nil
+	}
+
+	return argument.Interface()
+}
+
+// isSpreadReference returns true if the given factory argument is a type reference like "@listeners..." whose
+// elements should be passed as individual variadic arguments.
+func isSpreadReference(argument interface{}) bool {
+	s, isString := argument.(string)
+	return isString && IsTypeReference(s) && NewTypeID(s).IsSpread
+}
+
+// invalidVariadicReferencedTypeErr returns the error for the i-th variadic argument if it references a type that is not
+// assignable to the element type of the variadic parameter.
+func (t *typeFactory) invalidVariadicReferencedTypeErr(typeID string, typeInstance interface{}, i int, elementType reflect.Type) error {
+	if elementType.Kind() != reflect.Interface {
+		return t.invalidReferencedTypeErr(typeID, typeInstance, t.factoryType.NumIn()-1+i)
+	}
+
+	err := newTypeReferenceError(typeID, typeInstance, "the referenced type \"@%s\" (type %T) can not be passed as variadic argument %d to %s because it does not implement %v",
+		typeID, typeInstance, i+1, t.factoryName(), elementType,
+	)
+
+	err.ArgumentIndex = t.factoryType.NumIn() - 1 + i
+	return err
+}
 
+func (t *typeFactory) invalidReferencedTypeErr(typeID string, typeInstance interface{}, i int) error {
+	factoryName := t.factoryName()
 	n := t.factoryType.NumIn()
 	factoryArguments := make([]string, n)
 	for i := 0; i < n; i++ {
@@ -206,9 +342,20 @@ func (t *typeFactory) invalidReferencedTypeErr(typeID string, typeInstance inter
 		factoryArguments[i] = arg.String()
 	}
 
-	err := fmt.Errorf("the referenced type \"@%s\" (type %T) can not be passed as argument %d to the function signature %s(%s)",
+	err := newTypeReferenceError(typeID, typeInstance, "the referenced type \"@%s\" (type %T) can not be passed as argument %d to the function signature %s(%s)",
 		typeID, typeInstance, i+1, factoryName, strings.Join(factoryArguments, ", "),
 	)
 
+	err.ArgumentIndex = i
 	return err
 }
+
+// factoryName returns the package qualified name of the factory function (e.g. "goldi.NewType").
+func (t *typeFactory) factoryName() string {
+	factoryName := runtime.FuncForPC(t.factory.Pointer()).Name()
+	factoryNameParts := strings.Split(factoryName, "/")
+	factoryName = factoryNameParts[len(factoryNameParts)-1]
+
+	// the runtime marks bound method values (e.g. builder.Build) with this suffix
+	return strings.TrimSuffix(factoryName, "-fm")
+}
@@ -35,11 +35,17 @@ func NewTypeConfigurator(configuratorTypeID, methodName string) *TypeConfigurato
 // The method returns an error if thing is nil, the configurator type is not defined or
 // the configurators function does not exist.
 func (c *TypeConfigurator) Configure(thing interface{}, container *Container) error {
+	return c.configure(thing, container.Resolver)
+}
+
+// configure behaves like Configure but generates the configurator type using the given resolver,
+// so it uses the same context as the type that is configured (see Container.GetWithContext).
+func (c *TypeConfigurator) configure(thing interface{}, resolver *ParameterResolver) error {
 	if thing == nil {
 		return fmt.Errorf("can not configure nil")
 	}
 
-	configurator, typeDefined, err := container.get(c.ConfiguratorTypeID)
+	configurator, typeDefined, err := resolver.Container.generate(c.ConfiguratorTypeID, resolver)
 	if err != nil {
 		return err
 	}
@@ -11,3 +11,39 @@ type TypeFactory interface {
 	// Generate will instantiate a new instance of the according type or return an error.
 	Generate(parameterResolver *ParameterResolver) (interface{}, error)
 }
+
+// MethodReferences returns all references to methods of other types that are used by the given TypeFactory.
+// This includes func reference, proxy and alias types as well as configurators and arguments like "@foo::DoStuff"
+// of the factory and all its inline type factories.
+func MethodReferences(t TypeFactory) []*TypeID {
+	var references []*TypeID
+	switch f := t.(type) {
+	case *aliasType:
+		if typeID := NewTypeID(f.typeID); typeID.IsFuncReference {
+			references = append(references, typeID)
+		}
+		return references
+	case *funcReferenceType:
+		return append(references, f.typeID)
+	case *proxyType:
+		references = append(references, f.typeID)
+	case *configuredType:
+		references = append(references, NewTypeID("@"+f.ConfiguratorTypeID+"::"+f.MethodName))
+		return append(references, MethodReferences(f.embeddedType)...)
+	case decorator:
+		return MethodReferences(f.embedded())
+	}
+
+	for _, argument := range t.Arguments() {
+		switch a := argument.(type) {
+		case string:
+			if IsTypeReference(a) && NewTypeID(a).IsFuncReference {
+				references = append(references, NewTypeID(a))
+			}
+		case TypeFactory:
+			references = append(references, MethodReferences(a)...)
+		}
+	}
+
+	return references
+}
@@ -1,6 +1,9 @@
 package goldi
 
-import "strings"
+import (
+	"go/token"
+	"strings"
+)
 
 // TypeID represents a parsed type identifier and associated meta data.
 type TypeID struct {
@@ -8,9 +11,40 @@ type TypeID struct {
 	FuncReferenceMethod string
 	IsOptional          bool
 	IsFuncReference     bool
+
+	// RequiredInterface is the name of the interface the referenced type must implement (e.g. "@writer:io.Writer").
+	// See Container.RegisterInterface.
+	RequiredInterface string
+
+	// AsInterface is true if the referenced type should be resolved as its RequiredInterface instead of
+	// its concrete type (e.g. "@buffer as io.Reader").
+	AsInterface bool
+
+	// Index is the slice index or map key of the element of the referenced type that should be used
+	// (e.g. "@services[0]" or "@handlers[users]"). HasIndex is true if the type ID contains an index.
+	Index    string
+	HasIndex bool
+
+	// IsSpread is true if the referenced type is a slice whose elements should be passed as individual
+	// variadic arguments (e.g. "@listeners...").
+	IsSpread bool
+
+	// Chain contains the steps of a method chain like "@service::GetConfig()::Timeout" that is applied to the
+	// referenced type. Steps with parentheses are method calls and all other steps are struct fields.
+	// A single step without parentheses is a func reference instead (see IsFuncReference).
+	Chain []string
 }
 
-// NewTypeID creates a new TypeId. Trying to create a type ID from an empty string will panic
+// NewTypeID creates a new TypeId. Trying to create a type ID from an empty string will panic.
+//
+// The modifiers of a type reference are parsed in the order in which they must appear in the reference:
+//
+//	@?my_type[index]::Method()::Field:pkg.Iface...
+//
+// An optional "?" prefix, followed by the ID, an index or a method chain (resp. func reference), an interface
+// assertion and the spread suffix. An interface assertion can either be written as ":pkg.Iface" or as " as pkg.Iface"
+// to resolve the interface view of the type. Only "error" and package qualified names like "io.Writer" are treated as
+// interface names so type IDs may contain colons as long as they do not end in such a name (e.g. "cache:redis").
 func NewTypeID(s string) *TypeID {
 	if s == "" {
 		panic("can not create typeID from empty string")
@@ -25,32 +59,109 @@ func NewTypeID(s string) *TypeID {
 		t.ID = t.ID[1:]
 	}
 
-	if t.ID[0] == '?' {
+	if t.ID != "" && t.ID[0] == '?' {
 		t.IsOptional = true
 		t.ID = t.ID[1:]
 	}
 
-	funcReferenceParts := strings.SplitN(t.ID, "::", 2)
-	if len(funcReferenceParts) == 2 {
-		t.IsFuncReference = true
-		t.ID = funcReferenceParts[0]
-		t.FuncReferenceMethod = funcReferenceParts[1]
+	if len(t.ID) > 3 && strings.HasSuffix(t.ID, "...") {
+		t.IsSpread = true
+		t.ID = t.ID[:len(t.ID)-3]
+	}
+
+	if i := strings.LastIndex(t.ID, " as "); i > 0 && isInterfaceName(t.ID[i+4:]) {
+		t.AsInterface = true
+		t.RequiredInterface = t.ID[i+4:]
+		t.ID = strings.TrimSpace(t.ID[:i])
+	} else if i := strings.LastIndex(t.ID, ":"); i > 0 && t.ID[i-1] != ':' && isInterfaceName(t.ID[i+1:]) {
+		t.RequiredInterface = t.ID[i+1:]
+		t.ID = t.ID[:i]
+	}
+
+	if i := chainIndex(t.ID); i > 0 {
+		steps := strings.Split(t.ID[i+2:], "::")
+		t.ID = t.ID[:i]
+		if len(steps) > 1 || strings.HasSuffix(steps[0], "()") {
+			t.Chain = steps
+		} else {
+			t.IsFuncReference = true
+			t.FuncReferenceMethod = steps[0]
+		}
+	}
+
+	if i := strings.Index(t.ID, "["); i > 0 && strings.HasSuffix(t.ID, "]") {
+		t.HasIndex = true
+		t.Index = t.ID[i+1 : len(t.ID)-1]
+		t.ID = t.ID[:i]
 	}
 
 	return t
 }
 
+// chainIndex returns the position of the first "::" in the given type ID that is not part of an index
+// or -1 if the type ID contains no method chain or func reference.
+func chainIndex(id string) int {
+	depth := 0
+	for i := 0; i < len(id)-1; i++ {
+		switch {
+		case id[i] == '[':
+			depth++
+		case id[i] == ']' && depth > 0:
+			depth--
+		case depth == 0 && id[i] == ':' && id[i+1] == ':':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isInterfaceName returns true if the given name can be used in an interface assertion of a type ID.
+// This is the case for "error" and all package qualified identifiers like "io.Writer".
+func isInterfaceName(name string) bool {
+	if name == "error" {
+		return true
+	}
+
+	parts := strings.Split(name, ".")
+	return len(parts) == 2 && token.IsIdentifier(parts[0]) && token.IsIdentifier(parts[1])
+}
+
 // String implements the fmt.Stringer interface by returning the raw representation of this type ID.
 func (t *TypeID) String() string {
 	if t.Raw != "" {
 		return t.Raw
 	}
 
-	if t.FuncReferenceMethod != "" {
-		return "@" + t.ID + "::" + t.FuncReferenceMethod
+	s := "@"
+	if t.IsOptional {
+		s += "?"
+	}
+
+	s += t.ID
+	if t.HasIndex {
+		s += "[" + t.Index + "]"
+	}
+
+	switch {
+	case t.FuncReferenceMethod != "":
+		s += "::" + t.FuncReferenceMethod
+	case len(t.Chain) > 0:
+		s += "::" + strings.Join(t.Chain, "::")
+	}
+
+	switch {
+	case t.AsInterface:
+		s += " as " + t.RequiredInterface
+	case t.RequiredInterface != "":
+		s += ":" + t.RequiredInterface
+	}
+
+	if t.IsSpread {
+		s += "..."
 	}
 
-	return "@" + t.ID
+	return s
 }
 
 // IsParameterOrTypeReference is a utility function that returns whether the given string represents a parameter or a reference to a type.
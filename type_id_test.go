@@ -11,6 +11,86 @@ var _ = Describe("TypeID", func() {
 		It("should panic if given an empty string", func() {
 			Expect(func() { goldi.NewTypeID("") }).To(Panic())
 		})
+
+		It("should parse the required interface", func() {
+			t := goldi.NewTypeID("@?foo:io.Writer")
+			Expect(t.ID).To(Equal("foo"))
+			Expect(t.IsOptional).To(BeTrue())
+			Expect(t.IsFuncReference).To(BeFalse())
+			Expect(t.RequiredInterface).To(Equal("io.Writer"))
+		})
+
+		It("should not confuse func references with required interfaces", func() {
+			t := goldi.NewTypeID("@foo::DoStuff")
+			Expect(t.ID).To(Equal("foo"))
+			Expect(t.FuncReferenceMethod).To(Equal("DoStuff"))
+			Expect(t.RequiredInterface).To(BeEmpty())
+		})
+
+		It("should parse interface views", func() {
+			t := goldi.NewTypeID("@?buffer as io.Reader")
+			Expect(t.ID).To(Equal("buffer"))
+			Expect(t.IsOptional).To(BeTrue())
+			Expect(t.AsInterface).To(BeTrue())
+			Expect(t.RequiredInterface).To(Equal("io.Reader"))
+			Expect(goldi.NewTypeID("@buffer:io.Reader").AsInterface).To(BeFalse())
+		})
+
+		It("should parse the index", func() {
+			t := goldi.NewTypeID("@?handlers[users:admin]")
+			Expect(t.ID).To(Equal("handlers"))
+			Expect(t.IsOptional).To(BeTrue())
+			Expect(t.HasIndex).To(BeTrue())
+			Expect(t.Index).To(Equal("users:admin"))
+			Expect(t.RequiredInterface).To(BeEmpty())
+		})
+
+		It("should parse spread references", func() {
+			t := goldi.NewTypeID("@?listeners[0]...")
+			Expect(t.ID).To(Equal("listeners"))
+			Expect(t.IsOptional).To(BeTrue())
+			Expect(t.IsSpread).To(BeTrue())
+			Expect(t.Index).To(Equal("0"))
+			Expect(goldi.NewTypeID("@listeners").IsSpread).To(BeFalse())
+		})
+
+		It("should not treat colons in the ID as required interfaces", func() {
+			t := goldi.NewTypeID("@cache:redis")
+			Expect(t.ID).To(Equal("cache:redis"))
+			Expect(t.RequiredInterface).To(BeEmpty())
+
+			t = goldi.NewTypeID("@cache:redis:io.Writer")
+			Expect(t.ID).To(Equal("cache:redis"))
+			Expect(t.RequiredInterface).To(Equal("io.Writer"))
+
+			t = goldi.NewTypeID("@cache:error")
+			Expect(t.ID).To(Equal("cache"))
+			Expect(t.RequiredInterface).To(Equal("error"))
+		})
+
+		It("should parse combined modifiers in a defined order", func() {
+			t := goldi.NewTypeID("@?handlers[0] as io.Reader")
+			Expect(t.ID).To(Equal("handlers"))
+			Expect(t.IsOptional).To(BeTrue())
+			Expect(t.Index).To(Equal("0"))
+			Expect(t.AsInterface).To(BeTrue())
+			Expect(t.RequiredInterface).To(Equal("io.Reader"))
+
+			t = goldi.NewTypeID("@writers:io.Writer...")
+			Expect(t.ID).To(Equal("writers"))
+			Expect(t.IsSpread).To(BeTrue())
+			Expect(t.RequiredInterface).To(Equal("io.Writer"))
+
+			t = goldi.NewTypeID("@service::GetConfig()::Writer:io.Writer")
+			Expect(t.ID).To(Equal("service"))
+			Expect(t.Chain).To(Equal([]string{"GetConfig()", "Writer"}))
+			Expect(t.RequiredInterface).To(Equal("io.Writer"))
+
+			t = goldi.NewTypeID("@services[a::b]::Name")
+			Expect(t.ID).To(Equal("services"))
+			Expect(t.Index).To(Equal("a::b"))
+			Expect(t.FuncReferenceMethod).To(Equal("Name"))
+		})
 	})
 
 	Describe("String", func() {
@@ -28,5 +108,25 @@ var _ = Describe("TypeID", func() {
 			t := goldi.TypeID{ID: "foo", FuncReferenceMethod: "DoStuff"}
 			Expect(t.String()).To(Equal("@foo::DoStuff"))
 		})
+
+		It("should use RequiredInterface if it is not empty", func() {
+			t := goldi.TypeID{ID: "foo", RequiredInterface: "io.Writer"}
+			Expect(t.String()).To(Equal("@foo:io.Writer"))
+		})
+
+		It("should use the interface view if AsInterface is set", func() {
+			t := goldi.TypeID{ID: "foo", RequiredInterface: "io.Reader", AsInterface: true}
+			Expect(t.String()).To(Equal("@foo as io.Reader"))
+		})
+
+		It("should add the spread suffix if IsSpread is set", func() {
+			t := goldi.TypeID{ID: "foo", IsSpread: true}
+			Expect(t.String()).To(Equal("@foo..."))
+		})
+
+		It("should use the Index if HasIndex is set", func() {
+			t := goldi.TypeID{ID: "foo", Index: "0", HasIndex: true}
+			Expect(t.String()).To(Equal("@foo[0]"))
+		})
 	})
 })
@@ -3,6 +3,7 @@ package goldi
 import (
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 // The TypeRegistry is effectively a map of typeID strings to TypeFactory
@@ -17,22 +18,22 @@ func NewTypeRegistry() TypeRegistry {
 // It tries to create the correct TypeFactory and passes this to TypeRegistry.Register
 // This function panics if the given generator function and arguments can not be used to create a new type factory.
 func (r TypeRegistry) RegisterType(typeID string, factory interface{}, arguments ...interface{}) {
-	var typeFactory TypeFactory
+	r.Register(typeID, newTypeFactory(typeID, factory, arguments))
+}
 
+func newTypeFactory(typeID string, factory interface{}, arguments []interface{}) TypeFactory {
 	factoryType := reflect.TypeOf(factory)
 	kind := factoryType.Kind()
 	switch {
 	case kind == reflect.Struct:
 		fallthrough
 	case kind == reflect.Ptr && factoryType.Elem().Kind() == reflect.Struct:
-		typeFactory = NewStructType(factory, arguments...)
+		return NewStructType(factory, arguments...)
 	case kind == reflect.Func:
-		typeFactory = NewType(factory, arguments...)
+		return NewType(factory, arguments...)
 	default:
 		panic(fmt.Errorf("could not register type %q: could not determine TypeFactory for factory type %T", typeID, factory))
 	}
-
-	r.Register(typeID, typeFactory)
 }
 
 // Register saves a type under the given symbolic typeID so it can be retrieved later.
@@ -50,6 +51,31 @@ func (r TypeRegistry) RegisterAll(factories map[string]TypeFactory) {
 	}
 }
 
+// RegisterAllStrict registers all given type factories just like RegisterAll but only if none of the type IDs
+// has been registered before. Otherwise no type is registered at all and a MultiError is returned that
+// contains an error for each conflicting type ID.
+func (r TypeRegistry) RegisterAllStrict(factories map[string]TypeFactory) error {
+	typeIDs := make([]string, 0, len(factories))
+	for typeID := range factories {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	errs := NewMultiError("goldi: could not register types")
+	for _, typeID := range typeIDs {
+		if _, isDefined := r[typeID]; isDefined {
+			errs.Add(fmt.Errorf("type %q has already been registered", typeID))
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	r.RegisterAll(factories)
+	return nil
+}
+
 // InjectInstance enables you to inject type instances.
 // If instance is nil an error is returned
 func (r TypeRegistry) InjectInstance(typeID string, instance interface{}) {
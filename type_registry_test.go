@@ -145,4 +145,35 @@ var _ = Describe("TypeRegistry", func() {
 			Expect(typeIsRegistered).To(BeTrue())
 		})
 	})
+
+	Describe("RegisterAllStrict", func() {
+		It("should register all factories if there are no conflicts", func() {
+			registry.RegisterType("existing", NewFoo)
+			err := registry.RegisterAllStrict(map[string]goldi.TypeFactory{
+				"test_type_1": goldi.NewType(NewFoo),
+				"test_type_2": goldi.NewType(NewBar),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry).To(HaveKey("test_type_1"))
+			Expect(registry).To(HaveKey("test_type_2"))
+			Expect(registry).To(HaveLen(3))
+		})
+
+		It("should not register any factory if there are conflicts", func() {
+			existing := goldi.NewType(NewFoo)
+			registry.Register("test_type_1", existing)
+			registry.Register("test_type_3", existing)
+
+			err := registry.RegisterAllStrict(map[string]goldi.TypeFactory{
+				"test_type_1": goldi.NewType(NewBar),
+				"test_type_2": goldi.NewType(NewBar),
+				"test_type_3": goldi.NewType(NewBar),
+			})
+
+			Expect(err).To(MatchError(`goldi: could not register types: type "test_type_1" has already been registered; type "test_type_3" has already been registered`))
+			Expect(registry).NotTo(HaveKey("test_type_2"))
+			Expect(registry["test_type_1"]).To(BeIdenticalTo(existing))
+		})
+	})
 })
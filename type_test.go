@@ -1,7 +1,10 @@
 package goldi_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/fgrosse/goldi"
 	. "github.com/onsi/ginkgo/v2"
@@ -107,6 +110,12 @@ var _ = Describe("type", func() {
 					Expect(goldi.IsValid(t)).To(BeFalse())
 					Expect(t).To(MatchError("invalid number of input parameters for variadic function: got 1 but expected at least 3"))
 				})
+
+				It("should return an invalid type if a regular argument is a spread reference", func() {
+					t := goldi.NewType(NewVariadicMockType, true, "@names...", "foo")
+					Expect(goldi.IsValid(t)).To(BeFalse())
+					Expect(t).To(MatchError(`input argument 2 is the spread reference "@names..." but only variadic arguments can be spread`))
+				})
 			})
 		})
 	})
@@ -177,6 +186,33 @@ var _ = Describe("type", func() {
 				})
 			})
 
+			Context("when the factory function is a bound method", func() {
+				It("should generate the type using the bound instance", func() {
+					builder := &mockTypeBuilder{prefix: "built by"}
+					typeDef := goldi.NewType(builder.Build, "the builder", true)
+					Expect(goldi.IsValid(typeDef)).To(BeTrue())
+
+					generatedType, err := typeDef.Generate(resolver)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(generatedType.(*MockType).StringParameter).To(Equal("built by the builder"))
+					Expect(builder.calls).To(Equal(1))
+				})
+
+				It("should return an invalid type if the number of arguments does not match", func() {
+					builder := &mockTypeBuilder{}
+					Expect(goldi.IsValid(goldi.NewType(builder.Build, "foo"))).To(BeFalse())
+				})
+
+				It("should use the method name in errors", func() {
+					container.RegisterType("foo", NewFoo)
+					builder := &mockTypeBuilder{}
+					typeDef := goldi.NewType(builder.BuildFromMock, "@foo")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError(`the referenced type "@foo" (type *goldi_test.Foo) can not be passed as argument 1 to the function signature goldi_test.(*mockTypeBuilder).BuildFromMock(*goldi_test.MockType)`))
+				})
+			})
+
 			Context("when the arguments are variadic", func() {
 				It("should generate the type", func() {
 					typeDef := goldi.NewType(NewVariadicMockType, true, "ignored", "1", "two", "drei")
@@ -192,6 +228,93 @@ var _ = Describe("type", func() {
 				})
 			})
 
+			Context("when the variadic arguments are of an interface type", func() {
+				It("should resolve each referenced type", func() {
+					container.InjectInstance("buffer_1", new(bytes.Buffer))
+					container.InjectInstance("buffer_2", new(bytes.Buffer))
+					typeDef := goldi.NewType(NewMultiWriter, "multi", "@buffer_1", "@buffer_2")
+
+					generatedType, err := typeDef.Generate(resolver)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(generatedType.(*MultiWriter).Writers).To(Equal([]io.Writer{container.MustGet("buffer_1").(io.Writer), container.MustGet("buffer_2").(io.Writer)}))
+				})
+
+				It("should return an error if a referenced type does not implement the interface", func() {
+					container.InjectInstance("buffer", new(bytes.Buffer))
+					container.InjectInstance("mock", NewMockType())
+					typeDef := goldi.NewType(NewMultiWriter, "multi", "@buffer", "@mock")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError(`the referenced type "@mock" (type *goldi_test.MockType) can not be passed as variadic argument 2 to goldi_test.NewMultiWriter because it does not implement io.Writer`))
+				})
+			})
+
+			Context("when a variadic argument is a spread reference", func() {
+				It("should pass each element of the referenced slice as variadic argument", func() {
+					buffers := []io.Writer{new(bytes.Buffer), new(bytes.Buffer)}
+					container.InjectInstance("buffer", new(bytes.Buffer))
+					container.InjectInstance("buffers", buffers)
+					typeDef := goldi.NewType(NewMultiWriter, "multi", "@buffer", "@buffers...")
+
+					generatedType, err := typeDef.Generate(resolver)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(generatedType.(*MultiWriter).Writers).To(Equal([]io.Writer{container.MustGet("buffer").(io.Writer), buffers[0], buffers[1]}))
+				})
+
+				It("should not pass any arguments if an optional spread reference has not been defined", func() {
+					typeDef := goldi.NewType(NewVariadicMockType, true, "bar", "one", "@?names...")
+
+					generatedType, err := typeDef.Generate(resolver)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(generatedType.(*MockType).StringParameter).To(Equal("one"))
+				})
+
+				It("should return an error if the referenced type is no slice of the variadic type", func() {
+					container.InjectInstance("names", []int{1, 2})
+					typeDef := goldi.NewType(NewVariadicMockType, true, "bar", "@names...")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError(`could not spread variadic argument 1: the referenced type "@names..." (type []int) is not assignable to the expected type []string`))
+				})
+			})
+
+			Context("when an argument references a type of the wrong type", func() {
+				It("should return a TypeReferenceError with the producer and consumer of the reference", func() {
+					container.InjectInstance("foo", NewFoo())
+					container.Register("consumer", goldi.NewType(NewMockTypeWithArgs, "bar", "@foo"))
+
+					_, err := container.Get("consumer")
+					Expect(err).To(MatchError(`goldi: error while generating type "consumer": the referenced type "@foo" (type *goldi_test.Foo) can not be passed as argument 2 to the function signature goldi_test.NewMockTypeWithArgs(string, bool)`))
+
+					var referenceErr goldi.TypeReferenceError
+					Expect(errors.As(err, &referenceErr)).To(BeTrue())
+					Expect(referenceErr.TypeID).To(Equal("foo"))
+					Expect(referenceErr.TypeInstance).To(BeIdenticalTo(container.MustGet("foo")))
+					Expect(referenceErr.ConsumerTypeID).To(Equal("consumer"))
+					Expect(referenceErr.ArgumentIndex).To(Equal(1))
+				})
+
+				It("should not set the consumer if the type is generated without the container", func() {
+					container.InjectInstance("foo", NewFoo())
+					_, err := goldi.NewType(NewVariadicMockType, true, "bar", "baz", "@foo").Generate(resolver)
+
+					referenceErr, isReferenceErr := err.(goldi.TypeReferenceError)
+					Expect(isReferenceErr).To(BeTrue())
+					Expect(referenceErr.ConsumerTypeID).To(BeEmpty())
+					Expect(referenceErr.ArgumentIndex).To(Equal(3))
+				})
+			})
+
+			Context("when a variadic argument references a type of the wrong concrete type", func() {
+				It("should return an error with the position of the argument", func() {
+					container.InjectInstance("foo", NewFoo())
+					typeDef := goldi.NewType(NewVariadicMockType, true, "bar", "@foo")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError(`the referenced type "@foo" (type *goldi_test.Foo) can not be passed as argument 3 to the function signature goldi_test.NewVariadicMockType(bool, string, []string)`))
+				})
+			})
+
 			Context("when a func reference type is given", func() {
 				It("should generate the type", func() {
 					foo := &MockType{StringParameter: "Success!"}
@@ -217,6 +340,84 @@ var _ = Describe("type", func() {
 					Expect(generatedType.(*MockType).StringParameter).To(Equal("Success! Success! "))
 				})
 			})
+
+			Context("when an inline type factory is given as argument", func() {
+				It("should generate the type", func() {
+					typeDef := goldi.NewType(NewTypeForServiceInjection, goldi.NewStructType(new(MockType), "inline", true))
+					Expect(goldi.IsValid(typeDef)).To(BeTrue())
+
+					generatedType, err := typeDef.Generate(resolver)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(generatedType.(*TypeForServiceInjection).InjectedType.StringParameter).To(Equal("inline"))
+				})
+			})
+
+			Context("when a variadic argument is not assignable to the expected type", func() {
+				It("should return an error", func() {
+					typeDef := goldi.NewType(NewVariadicMockTypeFuncs, func(i int) int { return i })
+					Expect(goldi.IsValid(typeDef)).To(BeTrue())
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError("variadic argument 1 (type func(int) int) is not assignable to the expected type goldi_test.someFunc"))
+				})
+			})
+
+			Context("when an argument resolves to no value", func() {
+				It("should return an InvalidArgumentError for regular arguments", func() {
+					typeDef := goldi.NewType(NewTypeForServiceInjection, nil)
+					Expect(goldi.IsValid(typeDef)).To(BeTrue())
+					Expect(typeDef.Arguments()).To(Equal([]interface{}{nil}))
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError("argument 1 of goldi_test.NewTypeForServiceInjection resolved to no value that can be passed as *goldi_test.MockType"))
+					Expect(err).To(BeAssignableToTypeOf(goldi.InvalidArgumentError{}))
+					Expect(err.(goldi.InvalidArgumentError).ArgumentIndex).To(Equal(0))
+				})
+
+				It("should return an InvalidArgumentError for variadic arguments", func() {
+					typeDef := goldi.NewType(NewVariadicMockTypeFuncs, func(s string) string { return s }, nil)
+					Expect(goldi.IsValid(typeDef)).To(BeTrue())
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(MatchError("variadic argument 2 of goldi_test.NewVariadicMockTypeFuncs resolved to no value that can be passed as goldi_test.someFunc"))
+					Expect(err).To(BeAssignableToTypeOf(goldi.InvalidArgumentError{}))
+					Expect(err.(goldi.InvalidArgumentError).ArgumentIndex).To(Equal(1))
+				})
+			})
+
+			Context("when the resolver collects all errors", func() {
+				BeforeEach(func() {
+					resolver.CollectErrors = true
+					container.InjectInstance("foo", NewFoo())
+				})
+
+				It("should return the errors of all arguments", func() {
+					typeDef := goldi.NewType(NewTypeForServiceInjectionWithArgs, "@foo", "@undefined", "@foo", true)
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err).To(BeAssignableToTypeOf(&goldi.MultiError{}))
+					Expect(err.(*goldi.MultiError).Errors).To(HaveLen(3))
+					Expect(err).To(MatchError(HavePrefix(`could not resolve all arguments of goldi_test.NewTypeForServiceInjectionWithArgs: ` +
+						`the referenced type "@foo" (type *goldi_test.Foo) can not be passed as argument 1 to the function signature `,
+					)))
+					Expect(err.Error()).To(ContainSubstring(`; the referenced type "@undefined" has not been defined; `))
+					Expect(err.Error()).To(ContainSubstring(`can not be passed as argument 3 to the function signature`))
+				})
+
+				It("should return the errors of all variadic arguments", func() {
+					typeDef := goldi.NewType(NewVariadicMockType, "@undefined", "bar", "@foo", "baz", "@foo")
+
+					_, err := typeDef.Generate(resolver)
+					Expect(err.(*goldi.MultiError).Errors).To(HaveLen(3))
+					Expect(err.Error()).To(ContainSubstring("argument 3 "))
+					Expect(err.Error()).To(ContainSubstring("argument 5 "))
+				})
+
+				It("should generate the type if all arguments can be resolved", func() {
+					typeDef := goldi.NewType(NewVariadicMockType, true, "bar", "baz")
+					Expect(typeDef.Generate(resolver)).To(BeAssignableToTypeOf(&MockType{}))
+				})
+			})
 		})
 	})
 })
@@ -0,0 +1,52 @@
+package goldi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NewTypedType creates a new TypeFactory just like NewType but additionally checks that the factory function
+// returns exactly the type T. This documents the type of the registered service where it is registered and lets
+// the registration fail early if the factory function is changed to return something else.
+// Instances of such types can be retrieved without manual type assertions using Get.
+//
+// NewTypedType returns an invalid type if NewType would do so or if the factory function does not return T.
+//     container.Register("logger", goldi.NewTypedType[Logger](NewLogger, "%log_level%"))
+//     logger, err := goldi.Get[Logger](container, "logger")
+func NewTypedType[T any](factoryFunction interface{}, factoryParameters ...interface{}) TypeFactory {
+	t := NewType(factoryFunction, factoryParameters...)
+	factory, isFactory := t.(*typeFactory)
+	if isFactory == false {
+		return t
+	}
+
+	expectedType := reflect.TypeOf((*T)(nil)).Elem()
+	if returnType := factory.factoryType.Out(0); returnType != expectedType {
+		return newInvalidType(fmt.Errorf("the factory function returns %v but the typed type expects %v", returnType, expectedType))
+	}
+
+	return t
+}
+
+// Get retrieves a previously defined type from the container just like Container.Get and asserts that it is of type T.
+// Get returns an error if the type can not be retrieved or if it is not of type T.
+func Get[T any](c *Container, typeID string) (T, error) {
+	var zero T
+	instance, err := c.Get(typeID)
+	if err != nil {
+		return zero, err
+	}
+
+	if instance == nil {
+		return zero, nil
+	}
+
+	typed, isTyped := instance.(T)
+	if isTyped == false {
+		return zero, newTypeReferenceError(typeID, instance, "goldi: type %q (type %T) is not assignable to %v",
+			typeID, instance, reflect.TypeOf((*T)(nil)).Elem(),
+		)
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,60 @@
+package goldi_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewTypedType", func() {
+	var container *goldi.Container
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+	})
+
+	It("should register a type that can be retrieved without type assertions", func() {
+		container.Register("mock", goldi.NewTypedType[*MockType](NewMockTypeWithArgs, "hello", true))
+
+		mock, err := goldi.Get[*MockType](container, "mock")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mock.StringParameter).To(Equal("hello"))
+		Expect(mock).To(BeIdenticalTo(container.MustGet("mock")))
+	})
+
+	It("should return an invalid type if the factory does not return the expected type", func() {
+		t := goldi.NewTypedType[fmt.Stringer](NewMockType)
+		Expect(goldi.IsValid(t)).To(BeFalse())
+		Expect(t).To(MatchError("the factory function returns *goldi_test.MockType but the typed type expects fmt.Stringer"))
+	})
+
+	It("should return the invalid type of NewType", func() {
+		Expect(goldi.IsValid(goldi.NewTypedType[*MockType](42))).To(BeFalse())
+	})
+
+	Describe("Get", func() {
+		It("should return interfaces that are implemented by the type", func() {
+			container.InjectInstance("buffer", new(bytes.Buffer))
+
+			writer, err := goldi.Get[io.Writer](container, "buffer")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer).To(BeIdenticalTo(container.MustGet("buffer")))
+		})
+
+		It("should return an error if the type is not of the requested type", func() {
+			container.Register("mock", goldi.NewType(NewMockType))
+
+			_, err := goldi.Get[*Foo](container, "mock")
+			Expect(err).To(MatchError(`goldi: type "mock" (type *goldi_test.MockType) is not assignable to *goldi_test.Foo`))
+		})
+
+		It("should return an error if the type has not been defined", func() {
+			_, err := goldi.Get[*Foo](container, "foo")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package goldi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidateFactory checks a single TypeFactory against the types of the given registry without building
+// the container that would normally be validated by the validation package. This is useful to unit test
+// individual type registrations.
+//
+// ValidateFactory returns a MultiError if the factory or one of its inline type factories is invalid,
+// references types that have not been defined in the registry or methods that the referenced types do not have.
+// Just like the MethodReferencesConstraint, methods of types whose concrete type can not be determined
+// statically are not checked. Parameters are not checked since they are not part of the registry.
+func ValidateFactory(f TypeFactory, registry TypeRegistry) error {
+	if f == nil {
+		return fmt.Errorf("goldi: invalid type factory: the factory is nil")
+	}
+
+	c := NewContainer(registry, map[string]interface{}{})
+	errs := NewMultiError("goldi: invalid type factory")
+	addInvalidFactoryErrors(f, errs)
+
+	var dangling []string
+	for typeID := range c.danglingReferences(f, StringSet{}) {
+		dangling = append(dangling, typeID)
+	}
+	sort.Strings(dangling)
+	for _, typeID := range dangling {
+		errs.Add(fmt.Errorf("the referenced type %q has not been defined", "@"+typeID))
+	}
+
+	for _, reference := range MethodReferences(f) {
+		referencedType, isKnown := c.StaticType(reference.ID)
+		if isKnown == false {
+			continue
+		}
+
+		if _, exists := referencedType.MethodByName(reference.FuncReferenceMethod); exists == false {
+			errs.Add(fmt.Errorf("the referenced method %q does not exist because %v has no such method", reference.String(), referencedType))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// addInvalidFactoryErrors adds the errors of the given type factory and all its inline type factories if they are invalid.
+func addInvalidFactoryErrors(f TypeFactory, errs *MultiError) {
+	if invalid, isInvalid := f.(*invalidType); isInvalid {
+		errs.Add(invalid.error)
+		return
+	}
+
+	for _, argument := range f.Arguments() {
+		if inlineFactory, isFactory := argument.(TypeFactory); isFactory {
+			addInvalidFactoryErrors(inlineFactory, errs)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package goldi_test
+
+import (
+	"github.com/fgrosse/goldi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateFactory", func() {
+	var registry goldi.TypeRegistry
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		registry.RegisterType("mock", NewMockType)
+	})
+
+	It("should not return an error for valid factories", func() {
+		Expect(goldi.ValidateFactory(goldi.NewType(NewTypeForServiceInjection, "@mock"), registry)).To(Succeed())
+		Expect(goldi.ValidateFactory(goldi.NewFuncReferenceType("mock", "ReturnString"), registry)).To(Succeed())
+		Expect(goldi.ValidateFactory(goldi.NewType(NewTypeForServiceInjection, "@?missing"), registry)).To(Succeed())
+	})
+
+	It("should return an error if the factory references a type that has not been defined", func() {
+		err := goldi.ValidateFactory(goldi.NewType(NewTypeForServiceInjection, "@missing"), registry)
+		Expect(err).To(MatchError(`goldi: invalid type factory: the referenced type "@missing" has not been defined`))
+	})
+
+	It("should return an error if the factory references a method that does not exist", func() {
+		err := goldi.ValidateFactory(goldi.NewFuncReferenceType("mock", "DoesNotExist"), registry)
+		Expect(err).To(MatchError(`goldi: invalid type factory: the referenced method "@mock::DoesNotExist" does not exist because *goldi_test.MockType has no such method`))
+	})
+
+	It("should return an error if the factory or one of its inline factories is invalid", func() {
+		err := goldi.ValidateFactory(goldi.NewType(NewTypeForServiceInjection, goldi.NewType(42)), registry)
+		Expect(err).To(MatchError(HavePrefix("goldi: invalid type factory: ")))
+
+		err = goldi.ValidateFactory(goldi.NewType(42), registry)
+		Expect(err).To(MatchError(HavePrefix("goldi: invalid type factory: ")))
+	})
+
+	It("should return an error if the factory is nil", func() {
+		Expect(goldi.ValidateFactory(nil, registry)).To(MatchError("goldi: invalid type factory: the factory is nil"))
+	})
+})
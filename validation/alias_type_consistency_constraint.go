@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/fgrosse/goldi"
+)
+
+// The AliasTypeConsistencyConstraint checks that all alias types point to registered types.
+// Aliases of aliases are followed until the actual type is found. Additionally aliases that reference a method
+// (e.g. "@foo::DoStuff") must not resolve to a function reference because functions do not have any methods.
+type AliasTypeConsistencyConstraint struct{}
+
+// Validate implements the Constraint interface by checking the targets of all alias types.
+func (c *AliasTypeConsistencyConstraint) Validate(container *goldi.Container) (err error) {
+	for typeID, typeFactory := range container.TypeRegistry {
+		aliasedTypeID, isAlias := goldi.AliasedTypeID(typeFactory)
+		if isAlias == false {
+			continue
+		}
+
+		if err = c.validateAlias(typeID, aliasedTypeID, container); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *AliasTypeConsistencyConstraint) validateAlias(typeID, aliasedTypeID string, container *goldi.Container) error {
+	target := goldi.NewTypeID(aliasedTypeID)
+	visited := goldi.StringSet{}
+	visited.Set(typeID)
+
+	currentID := target.ID
+	for {
+		if visited.Contains(currentID) {
+			return fmt.Errorf("alias %q references itself via %q", typeID, currentID)
+		}
+		visited.Set(currentID)
+
+		typeFactory, isDefined := container.TypeRegistry[currentID]
+		if isDefined == false {
+			return fmt.Errorf("alias %q references unknown type %q", typeID, currentID)
+		}
+
+		nextID, isAlias := goldi.AliasedTypeID(typeFactory)
+		if isAlias == false {
+			return nil
+		}
+
+		next := goldi.NewTypeID(nextID)
+		if target.IsFuncReference && next.IsFuncReference {
+			return fmt.Errorf("alias %q references method %q of type %q but this type is an alias for the function %q",
+				typeID, target.FuncReferenceMethod, target.ID, nextID,
+			)
+		}
+
+		currentID = next.ID
+	}
+}
@@ -0,0 +1,62 @@
+package validation_test
+
+import (
+	"github.com/fgrosse/goldi"
+	"github.com/fgrosse/goldi/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AliasTypeConsistencyConstraint", func() {
+	var (
+		registry   goldi.TypeRegistry
+		container  *goldi.Container
+		constraint *validation.AliasTypeConsistencyConstraint
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		container = goldi.NewContainer(registry, map[string]interface{}{})
+		constraint = new(validation.AliasTypeConsistencyConstraint)
+	})
+
+	It("should not return an error if all aliases point to registered types", func() {
+		registry.Register("foo", goldi.NewType(NewMockTypeWithArgs, "hello world", true))
+		registry.Register("alias", goldi.NewAliasType("foo"))
+		registry.Register("alias_of_alias", goldi.NewAliasType("alias"))
+		registry.Register("method_alias", goldi.NewAliasType("alias::DoStuff"))
+
+		Expect(constraint.Validate(container)).To(Succeed())
+	})
+
+	It("should return an error if an alias points to an unknown type", func() {
+		registry.Register("alias", goldi.NewAliasType("foo"))
+
+		Expect(constraint.Validate(container)).To(MatchError(`alias "alias" references unknown type "foo"`))
+	})
+
+	It("should return an error if an alias of an alias points to an unknown type", func() {
+		registry.Register("alias_1", goldi.NewAliasType("alias_2::DoStuff"))
+		registry.Register("alias_2", goldi.NewAliasType("foo"))
+		registry.Register("foo", goldi.NewAliasType("bar"))
+
+		err := constraint.Validate(container)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HaveSuffix(`references unknown type "bar"`))
+	})
+
+	It("should return an error if aliases reference each other", func() {
+		registry.Register("alias_1", goldi.NewAliasType("alias_2"))
+		registry.Register("alias_2", goldi.NewAliasType("alias_1"))
+
+		Expect(constraint.Validate(container)).To(MatchError(ContainSubstring("references itself")))
+	})
+
+	It("should return an error if a method alias resolves to a function", func() {
+		registry.Register("foo", goldi.NewType(NewMockTypeWithArgs, "hello world", true))
+		registry.Register("func", goldi.NewAliasType("foo::DoStuff"))
+		registry.Register("alias", goldi.NewAliasType("func::DoStuff"))
+
+		Expect(constraint.Validate(container)).To(MatchError(`alias "alias" references method "DoStuff" of type "func" but this type is an alias for the function "foo::DoStuff"`))
+	})
+})
@@ -18,13 +18,17 @@ type ContainerValidator struct {
 }
 
 // NewContainerValidator creates a new ContainerValidator.
-// The validator will be initialized with the NoInvalidTypesConstraint, TypeParametersConstraint and TypeReferencesConstraint
+// The validator will be initialized with the NoInvalidTypesConstraint, TypeParametersConstraint, TypeReferencesConstraint,
+// AliasTypeConsistencyConstraint, MethodReferencesConstraint and DeclaredDependenciesConstraint
 func NewContainerValidator() *ContainerValidator {
 	return &ContainerValidator{
 		Constraints: []Constraint{
 			new(NoInvalidTypesConstraint),
 			new(TypeParametersConstraint),
 			new(TypeReferencesConstraint),
+			new(AliasTypeConsistencyConstraint),
+			new(MethodReferencesConstraint),
+			new(DeclaredDependenciesConstraint),
 		},
 	}
 }
@@ -57,3 +61,15 @@ func (v *ContainerValidator) Validate(container *goldi.Container) (err error) {
 
 	return nil
 }
+
+// allArguments returns the arguments of the given type factory including the arguments of all inline type factories.
+func allArguments(typeFactory goldi.TypeFactory) []interface{} {
+	var arguments []interface{}
+	for _, argument := range typeFactory.Arguments() {
+		arguments = append(arguments, argument)
+		if inlineFactory, isFactory := argument.(goldi.TypeFactory); isFactory {
+			arguments = append(arguments, allArguments(inlineFactory)...)
+		}
+	}
+	return arguments
+}
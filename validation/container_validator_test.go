@@ -35,6 +35,13 @@ var _ = Describe("ContainerValidator", func() {
 		Expect(validator.Validate(container)).NotTo(Succeed())
 	})
 
+	It("should not return an error when a parameter has a default value", func() {
+		container.SetDefaultParameter("param", true)
+		registry.Register("main_type", goldi.NewType(NewMockTypeWithArgs, "hello world", "%param%"))
+
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
 	It("should return an error when a dependend type has not been registered", func() {
 		typeDef := goldi.NewType(NewTypeForServiceInjection, "@injected_type")
 		registry.Register("main_type", typeDef)
@@ -42,6 +49,47 @@ var _ = Describe("ContainerValidator", func() {
 		Expect(validator.Validate(container)).NotTo(Succeed())
 	})
 
+	It("should not return an error when an optional type has not been registered", func() {
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@?injected_type"))
+
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
+	It("should return an error when a parameter references a type that has not been registered", func() {
+		config["injected"] = "@injected_type"
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "%injected%"))
+
+		Expect(validator.Validate(container)).To(MatchError(`container validation failed: type "main_type" references unknown type "injected_type"`))
+	})
+
+	It("should not treat escaped type references in parameters as references", func() {
+		config["injected"] = `\@injected_type`
+		registry.Register("main_type", goldi.NewType(NewMockTypeWithArgs, "%injected%", true))
+
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
+	It("should not return an error when an alternative of a fallback chain has not been registered", func() {
+		registry.Register("injected_type", goldi.NewStructType(MockType{}))
+		registry.Register("main_type", goldi.NewType(NewTypeForServiceInjection, "@primary_type|@injected_type"))
+
+		Expect(validator.Validate(container)).To(Succeed())
+	})
+
+	It("should return an error when an inline type references a type that has not been registered", func() {
+		typeDef := goldi.NewType(NewTypeForServiceInjection, goldi.NewType(NewTypeForServiceInjection, "@injected_type"))
+		registry.Register("main_type", typeDef)
+
+		Expect(validator.Validate(container)).To(MatchError(`container validation failed: type "main_type" references unknown type "injected_type"`))
+	})
+
+	It("should return an error when a map argument references a type that has not been registered", func() {
+		typeDef := goldi.NewType(func(map[string]interface{}) *MockType { return nil }, map[string]interface{}{"foo": "@injected_type"})
+		registry.Register("main_type", typeDef)
+
+		Expect(validator.Validate(container)).To(MatchError(`container validation failed: type "main_type" references unknown type "injected_type"`))
+	})
+
 	It("should return an error when a direct circular type dependency exists", func() {
 		injectedTypeID := "type_1"
 		typeDef1 := goldi.NewType(NewTypeForServiceInjection, "@type_2")
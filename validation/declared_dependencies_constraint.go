@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fgrosse/goldi"
+)
+
+// The DeclaredDependenciesConstraint checks that the dependencies which have been declared via
+// Container.RegisterWithDeps match the types that are actually referenced by the arguments of each type.
+// Types without declared dependencies are not checked.
+type DeclaredDependenciesConstraint struct{}
+
+// Validate implements the Constraint interface by comparing the declared and referenced dependencies of all types.
+func (c *DeclaredDependenciesConstraint) Validate(container *goldi.Container) error {
+	typeIDs := make([]string, 0, len(container.TypeRegistry))
+	for typeID := range container.TypeRegistry {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Strings(typeIDs)
+
+	for _, typeID := range typeIDs {
+		typeFactory := container.TypeRegistry[typeID]
+		declared, isDeclared := goldi.DeclaredDependencies(typeFactory)
+		if isDeclared == false {
+			continue
+		}
+
+		if err := c.validateDependencies(container, typeID, declared, typeFactory); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *DeclaredDependenciesConstraint) validateDependencies(container *goldi.Container, typeID string, declared []string, typeFactory goldi.TypeFactory) error {
+	declaredIDs := goldi.StringSet{}
+	for _, dependency := range declared {
+		declaredIDs.Set(goldi.NewTypeID(dependency).ID)
+	}
+
+	referencedIDs := goldi.StringSet{}
+	var referenced []string
+	for _, reference := range container.References(typeFactory) {
+		if referencedIDs.Contains(reference.ID) == false {
+			referencedIDs.Set(reference.ID)
+			referenced = append(referenced, reference.ID)
+		}
+	}
+
+	for _, referencedID := range referenced {
+		if declaredIDs.Contains(referencedID) == false {
+			return fmt.Errorf("type %q references type %q but does not declare it as dependency", typeID, referencedID)
+		}
+	}
+
+	for _, dependency := range declared {
+		if referencedIDs.Contains(goldi.NewTypeID(dependency).ID) == false {
+			return fmt.Errorf("type %q declares the dependency %q but does not reference it", typeID, dependency)
+		}
+	}
+
+	return nil
+}
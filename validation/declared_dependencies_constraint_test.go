@@ -0,0 +1,58 @@
+package validation_test
+
+import (
+	"github.com/fgrosse/goldi"
+	"github.com/fgrosse/goldi/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeclaredDependenciesConstraint", func() {
+	var (
+		container  *goldi.Container
+		constraint *validation.DeclaredDependenciesConstraint
+	)
+
+	BeforeEach(func() {
+		container = goldi.NewContainer(goldi.NewTypeRegistry(), map[string]interface{}{})
+		constraint = new(validation.DeclaredDependenciesConstraint)
+		container.Register("foo", goldi.NewType(NewMockTypeWithArgs, "hello world", true))
+		container.Register("bar", goldi.NewType(NewMockTypeWithArgs, "hello world", true))
+	})
+
+	It("should not return an error if the declared dependencies match the references", func() {
+		container.RegisterWithDeps("service", goldi.NewType(NewTypeForServiceInjection, "@foo"), "foo")
+		container.RegisterWithDeps("optional", goldi.NewType(NewTypeForServiceInjection, "@?foo"), "@foo")
+		container.Register("undeclared", goldi.NewType(NewTypeForServiceInjection, "@bar"))
+
+		Expect(constraint.Validate(container)).To(Succeed())
+	})
+
+	It("should return an error if a referenced type has not been declared", func() {
+		container.RegisterWithDeps("service", goldi.NewType(NewTypeForServiceInjection, "@bar"), "foo")
+
+		Expect(constraint.Validate(container)).To(MatchError(`type "service" references type "bar" but does not declare it as dependency`))
+	})
+
+	It("should return an error if a declared dependency is not referenced", func() {
+		container.RegisterWithDeps("service", goldi.NewType(NewTypeForServiceInjection, "@foo"), "foo", "bar")
+
+		Expect(constraint.Validate(container)).To(MatchError(`type "service" declares the dependency "bar" but does not reference it`))
+	})
+
+	It("should consider the references of inline type factories", func() {
+		container.RegisterWithDeps("service", goldi.NewType(NewTypeForServiceInjection,
+			goldi.NewType(NewTypeForServiceInjection, "@bar"),
+		))
+
+		Expect(constraint.Validate(container)).To(MatchError(`type "service" references type "bar" but does not declare it as dependency`))
+	})
+
+	It("should consider the references in map arguments", func() {
+		container.RegisterWithDeps("service", goldi.NewType(func(map[string]interface{}) *MockType { return nil },
+			map[string]interface{}{"foo": "@foo", "bar": "@bar"},
+		), "foo")
+
+		Expect(constraint.Validate(container)).To(MatchError(`type "service" references type "bar" but does not declare it as dependency`))
+	})
+})
@@ -0,0 +1,32 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/fgrosse/goldi"
+)
+
+// The MethodReferencesConstraint checks that all referenced methods (e.g. "@foo::DoStuff") exist on the referenced types.
+// The types are not generated for this check so types whose concrete type can not be determined statically
+// (e.g. because their factory returns an interface) are skipped.
+type MethodReferencesConstraint struct{}
+
+// Validate implements the Constraint interface by checking if all referenced methods exist.
+func (c *MethodReferencesConstraint) Validate(container *goldi.Container) (err error) {
+	for typeID, typeFactory := range container.TypeRegistry {
+		for _, reference := range goldi.MethodReferences(typeFactory) {
+			referencedType, isKnown := container.StaticType(reference.ID)
+			if isKnown == false {
+				continue
+			}
+
+			if _, exists := referencedType.MethodByName(reference.FuncReferenceMethod); exists == false {
+				return fmt.Errorf("type %q references method %q of type %q but %v has no such method",
+					typeID, reference.FuncReferenceMethod, reference.ID, referencedType,
+				)
+			}
+		}
+	}
+
+	return nil
+}
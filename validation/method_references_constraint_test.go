@@ -0,0 +1,67 @@
+package validation_test
+
+import (
+	"github.com/fgrosse/goldi"
+	"github.com/fgrosse/goldi/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MethodReferencesConstraint", func() {
+	var (
+		registry   goldi.TypeRegistry
+		container  *goldi.Container
+		constraint *validation.MethodReferencesConstraint
+	)
+
+	BeforeEach(func() {
+		registry = goldi.NewTypeRegistry()
+		container = goldi.NewContainer(registry, map[string]interface{}{})
+		constraint = new(validation.MethodReferencesConstraint)
+		registry.Register("foo", goldi.NewType(NewMockTypeWithArgs, "hello world", true))
+	})
+
+	It("should not return an error if all referenced methods exist", func() {
+		registry.Register("alias", goldi.NewAliasType("foo::ReturnString"))
+		registry.Register("func", goldi.NewFuncReferenceType("foo", "DoStuff"))
+		registry.Register("proxy", goldi.NewProxyType("foo", "DoStuff"))
+
+		Expect(constraint.Validate(container)).To(Succeed())
+	})
+
+	It("should return an error if an alias references a missing method", func() {
+		registry.Register("alias", goldi.NewAliasType("foo::Renamed"))
+
+		Expect(constraint.Validate(container)).To(MatchError(`type "alias" references method "Renamed" of type "foo" but *validation_test.MockType has no such method`))
+	})
+
+	It("should return an error if a func reference type references a missing method", func() {
+		registry.Register("func", goldi.NewFuncReferenceType("foo", "Renamed"))
+
+		Expect(constraint.Validate(container)).To(MatchError(ContainSubstring(`type "func" references method "Renamed"`)))
+	})
+
+	It("should return an error if an argument references a missing method", func() {
+		registry.Register("bar", goldi.NewType(NewTypeForServiceInjection, goldi.NewProxyType("foo", "Renamed")))
+
+		Expect(constraint.Validate(container)).To(MatchError(ContainSubstring(`type "bar" references method "Renamed"`)))
+	})
+
+	It("should not generate any type", func() {
+		factory := func() *MockType {
+			Fail("the type should not be generated")
+			return nil
+		}
+		registry.Register("lazy", goldi.NewType(factory))
+		registry.Register("alias", goldi.NewAliasType("lazy::DoStuff"))
+
+		Expect(constraint.Validate(container)).To(Succeed())
+	})
+
+	It("should skip types whose concrete type is unknown", func() {
+		registry.Register("interface", goldi.NewType(func() interface{} { return &MockType{} }))
+		registry.Register("alias", goldi.NewAliasType("interface::DoStuff"))
+
+		Expect(constraint.Validate(container)).To(Succeed())
+	})
+})
@@ -7,13 +7,14 @@ import (
 )
 
 // The TypeParametersConstraint is used in a ContainerValidator to check if all used parameters do exist.
+// Parameters that have not been configured but have a default value (see goldi.Container.SetDefaultParameter) are valid.
 type TypeParametersConstraint struct{}
 
 // Validate implements the Constraint interface by checking if all referenced parameters have been defined.
 func (c *TypeParametersConstraint) Validate(container *goldi.Container) (err error) {
 	for typeID, typeFactory := range container.TypeRegistry {
-		allArguments := typeFactory.Arguments()
-		if err = c.validateTypeParameters(typeID, container, allArguments); err != nil {
+		arguments := allArguments(typeFactory)
+		if err = c.validateTypeParameters(typeID, container, arguments); err != nil {
 			return err
 		}
 	}
@@ -24,8 +25,7 @@ func (c *TypeParametersConstraint) Validate(container *goldi.Container) (err err
 func (c *TypeParametersConstraint) validateTypeParameters(typeID string, container *goldi.Container, allArguments []interface{}) error {
 	typeParameters := c.parameterArguments(allArguments)
 	for _, parameterName := range typeParameters {
-		_, isParameterDefined := container.Config[parameterName]
-		if isParameterDefined == false {
+		if container.HasParameter(parameterName) == false {
 			return fmt.Errorf(`the parameter "%%%s%%" is required by type %q but has not been defined`, parameterName, typeID)
 		}
 	}
@@ -35,6 +35,10 @@ func (c *TypeParametersConstraint) validateTypeParameters(typeID string, contain
 func (c *TypeParametersConstraint) parameterArguments(allArguments []interface{}) []string {
 	var parameterArguments []string
 	for _, argument := range allArguments {
+		if cast, isCast := argument.(*goldi.CastArgument); isCast {
+			argument = cast.Argument
+		}
+
 		stringArgument, isString := argument.(string)
 		if isString && goldi.IsParameter(stringArgument) {
 			parameterArguments = append(parameterArguments, stringArgument[1:len(stringArgument)-1])
@@ -2,11 +2,14 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fgrosse/goldi"
 )
 
 // The TypeReferencesConstraint is used in a ContainerValidator to check if all referenced types in the container have been defined.
+// Optional references like "@?foo" may reference types that have not been defined since they are resolved to nil.
+// The same applies to the alternatives of fallback chains like "@foo|@bar" (see goldi.FallbackAlternatives).
 type TypeReferencesConstraint struct {
 	checkedTypes               goldi.StringSet
 	circularDependencyCheckMap goldi.StringSet
@@ -17,9 +20,7 @@ func (c *TypeReferencesConstraint) Validate(container *goldi.Container) (err err
 	for typeID, typeFactory := range container.TypeRegistry {
 		// reset the validation type cache
 		c.checkedTypes = goldi.StringSet{}
-		allArguments := typeFactory.Arguments()
-
-		if err = c.validateTypeReferences(typeID, container, allArguments); err != nil {
+		if err = c.validateTypeReferences(typeID, container, typeFactory); err != nil {
 			return err
 		}
 	}
@@ -27,14 +28,20 @@ func (c *TypeReferencesConstraint) Validate(container *goldi.Container) (err err
 	return nil
 }
 
-func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, container *goldi.Container, allArguments []interface{}) error {
-	typeRefParameters := c.typeReferenceArguments(allArguments)
+func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, container *goldi.Container, typeFactory goldi.TypeFactory) error {
+	typeRefParameters := c.typeReferenceArguments(container, typeFactory)
 	for _, referencedTypeID := range typeRefParameters {
 		if c.checkedTypes.Contains(referencedTypeID) {
 			// TEST: test this for improved code coverage
 			continue
 		}
 
+		if t := goldi.NewTypeID(referencedTypeID); t.IsOptional {
+			if _, isDefined := container.TypeRegistry[t.ID]; isDefined == false {
+				continue
+			}
+		}
+
 		referencedTypeFactory, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, container)
 		if err != nil {
 			return err
@@ -51,13 +58,17 @@ func (c *TypeReferencesConstraint) validateTypeReferences(typeID string, contain
 	return nil
 }
 
-func (c *TypeReferencesConstraint) typeReferenceArguments(allArguments []interface{}) []string {
+// typeReferenceArguments returns all type references of the given type factory without the leading @ sign
+// (see goldi.Container.References).
+func (c *TypeReferencesConstraint) typeReferenceArguments(container *goldi.Container, typeFactory goldi.TypeFactory) []string {
 	var typeRefParameters []string
-	for _, argument := range allArguments {
-		stringArgument, isString := argument.(string)
-		if isString && goldi.IsTypeReference(stringArgument) {
-			typeRefParameters = append(typeRefParameters, stringArgument[1:])
+	for _, reference := range container.References(typeFactory) {
+		referencedTypeID := reference.Raw[1:]
+		if reference.IsAlternative {
+			// the type references of fallback chains are treated like optional references
+			referencedTypeID = "?" + strings.TrimPrefix(referencedTypeID, "?")
 		}
+		typeRefParameters = append(typeRefParameters, referencedTypeID)
 	}
 	return typeRefParameters
 }
@@ -72,8 +83,7 @@ func (c *TypeReferencesConstraint) checkTypeIsDefined(t, referencedType string,
 }
 
 func (c *TypeReferencesConstraint) checkCircularDependency(typeFactory goldi.TypeFactory, typeID string, container *goldi.Container) error {
-	allArguments := typeFactory.Arguments()
-	typeRefParameters := c.typeReferenceArguments(allArguments)
+	typeRefParameters := c.typeReferenceArguments(container, typeFactory)
 
 	for _, referencedTypeID := range typeRefParameters {
 		referencedType, err := c.checkTypeIsDefined(goldi.NewTypeID(typeID).ID, goldi.NewTypeID(referencedTypeID).ID, container)
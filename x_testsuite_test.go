@@ -1,7 +1,9 @@
 package goldi_test
 
 import (
+	"io"
 	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -83,6 +85,16 @@ func NewVariadicMockTypeFuncs(funcs ...someFunc) *MockType {
 	return m
 }
 
+// NewMultiWriter combines the given writers just like io.MultiWriter but returns a type whose writers can be inspected.
+func NewMultiWriter(name string, writers ...io.Writer) *MultiWriter {
+	return &MultiWriter{Name: name, Writers: writers}
+}
+
+type MultiWriter struct {
+	Name    string
+	Writers []io.Writer
+}
+
 type MockTypeFactory struct {
 	HasBeenUsed bool
 }
@@ -92,6 +104,21 @@ func (g *MockTypeFactory) NewMockType() *MockType {
 	return &MockType{}
 }
 
+type mockTypeBuilder struct {
+	prefix string
+	calls  int
+}
+
+func (b *mockTypeBuilder) Build(name string, flag bool) *MockType {
+	b.calls++
+	return &MockType{StringParameter: b.prefix + " " + name, BoolParameter: flag}
+}
+
+func (b *mockTypeBuilder) BuildFromMock(mock *MockType) *MockType {
+	b.calls++
+	return mock
+}
+
 type TypeForServiceInjection struct {
 	InjectedType *MockType
 }
@@ -103,3 +130,18 @@ func NewTypeForServiceInjection(injectedType *MockType) *TypeForServiceInjection
 func NewTypeForServiceInjectionWithArgs(injectedType *MockType, name, location string, flag bool) *TypeForServiceInjection {
 	return &TypeForServiceInjection{injectedType}
 }
+
+// A closeRecorder records the order in which it has been closed and returns the configured error.
+type closeRecorder struct {
+	mutex  *sync.Mutex
+	closed *[]string
+	name   string
+	err    error
+}
+
+func (r *closeRecorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}